@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+)
+
+// Mints an API key for an internal caller, signed with SERVICE_API_KEY_SECRET:
+//
+//	go run ./cmd/apikey -client notification-service -scopes rides:read -ttl 720h
+//
+// The key goes to stdout and its key id, for SERVICE_API_KEY_REVOKED, to
+// stderr.
+func main() {
+	configs.Init()
+
+	client := flag.String("client", "", "client id of the calling service")
+	scopes := flag.String("scopes", "", "comma separated scopes")
+	ttl := flag.Duration("ttl", 720*time.Hour, "key lifetime")
+	flag.Parse()
+
+	secret := configs.GetEnv("SERVICE_API_KEY_SECRET", "")
+	if secret == "" {
+		log.Fatal("SERVICE_API_KEY_SECRET is not set")
+	}
+	if *client == "" {
+		log.Fatal("-client is required")
+	}
+
+	if *ttl <= 0 {
+		log.Fatal("-ttl must be positive, API keys must expire")
+	}
+
+	var scopeList []string
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopeList = append(scopeList, scope)
+		}
+	}
+
+	key, keyId, err := repository.SignApiKey(secret, *client, scopeList, time.Now().Add(*ttl))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "key id: %s\n", keyId)
+	fmt.Println(key)
+}
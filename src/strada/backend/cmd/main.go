@@ -2,20 +2,24 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
-	config "github.com/244Walyson/shared-ride/configs/db"
+	"github.com/244Walyson/shared-ride/configs"
 	"github.com/244Walyson/shared-ride/internal/adapters/dto"
 	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
 	"github.com/244Walyson/shared-ride/internal/adapters/in/api/routes"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/rpc"
 	"github.com/244Walyson/shared-ride/internal/adapters/in/websocket/dispatcher"
 	"github.com/244Walyson/shared-ride/internal/adapters/in/websocket/handlers"
 	"github.com/244Walyson/shared-ride/internal/adapters/in/websocket/websocket"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/payment"
 	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	"github.com/244Walyson/shared-ride/internal/application/core/services"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -29,34 +33,92 @@ func main() {
 		log.Fatal("Error loading .env file")
 	}
 
-	database, err := config.ConnectDB()
-	if err != nil {
-		log.Fatal("Cannot connect to the database")
+	autoMigrateFlag := flag.Bool("auto-migrate", configs.GetEnv("DB_AUTO_MIGRATE", "false") == "true", "apply pending database migrations on start")
+	flag.Parse()
+
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(context.Background(), flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	defer database.Close()
+	repositoryDriver := configs.GetEnv("REPOSITORY_DRIVER", repositoryDriverPostgres)
+
+	if *autoMigrateFlag && repositoryDriver == repositoryDriverPostgres {
+		if err := autoMigrate(context.Background()); err != nil {
+			log.Fatalf("Cannot migrate the database: %v", err)
+		}
+	}
 
-	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	repos, err := openRepositories(repositoryDriver)
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		log.Fatal(err)
 	}
-	defer conn.Close()
+	defer repos.close()
 
 	verifyTokenRepository := repository.NewVerifyTokenRepository()
 
-	userRepository := repository.NewUserRepository(conn)
-
-	rideRequestRepository := repository.NewRideRequestRepository(database)
-	rideRepository := repository.NewRideRepository(database)
-
-	rideRequestService := services.NewRideRequestService(rideRequestRepository)
-	rideService := services.NewRideService(rideRepository)
-	userService := services.NewUserService(userRepository)
+	rideRequestService := services.NewRideRequestService(repos.rideRequest)
+	rideService := services.NewRideService(repos.ride)
+	userService := services.NewUserService(repos.user)
 
 	rideService.SetRideRequestService(rideRequestService)
 	rideService.SetUserService(userService)
 	rideRequestService.SetRideService(rideService)
 	rideRequestService.SetUserService(userService)
+	rideService.SetTransactionManager(repos.transactionManager)
+	rideRequestService.SetTransactionManager(repos.transactionManager)
+
+	changeHistoryService := services.NewChangeHistoryService(repos.changeHistory)
+	rideService.SetChangeHistoryService(changeHistoryService)
+	rideRequestService.SetChangeHistoryService(changeHistoryService)
+
+	schoolService := services.NewSchoolService(repos.school)
+	rideService.SetSchoolService(schoolService)
+	rideRequestService.SetSchoolService(schoolService)
+
+	farePerKm, err := models.ParseMoney(configs.GetEnv("FARE_PRICE_PER_KM", "0.60"), configs.GetEnv("FARE_CURRENCY", models.DefaultCurrency))
+	if err != nil {
+		log.Fatalf("Invalid fare configuration: %v", err)
+	}
+	rideService.SetPricingService(services.NewPricingService(farePerKm))
+	rideService.SetEmissionService(services.NewEmissionService(repos.vehicle))
+
+	routingProvider, err := openRoutingProvider(configs.GetEnv("ROUTING_PROVIDER", routingProviderHaversine))
+	if err != nil {
+		log.Fatal(err)
+	}
+	rideService.SetRoutingProvider(routingProvider)
+
+	drivingCostPerKm, err := models.ParseMoney(configs.GetEnv("STATS_DRIVING_COST_PER_KM", "0.90"), farePerKm.Currency)
+	if err != nil {
+		log.Fatalf("Invalid stats configuration: %v", err)
+	}
+	statsService := services.NewStatsService(repos.ride, repos.userPreferences, drivingCostPerKm)
+	statsService.SetSchoolService(schoolService)
+
+	paymentProvider, err := openPaymentProvider(configs.GetEnv("PAYMENT_PROVIDER", payment.FakeProviderName))
+	if err != nil {
+		log.Fatal(err)
+	}
+	paymentService := services.NewPaymentService(repos.payment, repos.ride, paymentProvider)
+	paymentService.SetUserService(userService)
+	paymentService.SetTransactionManager(repos.transactionManager)
+
+	statementService := services.NewStatementService(repos.ride, repos.payment)
+	statementService.SetUserService(userService)
+
+	seriesHorizon := time.Duration(configs.GetEnvAsInt("SERIES_HORIZON_DAYS", 14)) * 24 * time.Hour
+	seriesService := services.NewSeriesService(repos.rideSeries, repos.rideRequestSeries, seriesHorizon)
+	seriesService.SetRideService(rideService)
+	seriesService.SetRideRequestService(rideRequestService)
+	seriesService.SetUserService(userService)
+	seriesService.SetSchoolService(schoolService)
+
+	rideEventService := services.NewRideEventService()
+	rideService.SetRideEventService(rideEventService)
+	rideRequestService.SetRideEventService(rideEventService)
 
 	dispatcher := dispatcher.NewDispatcher()
 	// dispatcher.Register("find_near_rides", dto.PayloadIdDTO{}, func(data any, ctx context.Context) (any, error) {
@@ -84,13 +146,55 @@ func main() {
 	websocket := websocket.NewWebsocketRoute(dispatcher)
 
 	createRideRequestRoute := routes.NewCreateRideRequest(rideRequestService)
-	findNearRideRequestRoute := routes.NewFindNearRideRequest(rideRequestService)
-	findNearRideRoute := routes.NewFindNearRide(rideService)
+	findNearRideRequestRoute := routes.NewFindNearRideRequest(rideRequestService, userService)
+	findNearRideRoute := routes.NewFindNearRide(rideService, userService)
 	createRideRoute := routes.NewCreateRide(rideService)
-	findRideById := routes.NewFindRideById(rideService)
-	findRideRequestById := routes.NewFindRideRequestById(rideRequestService)
+	findRideById := routes.NewFindRideById(rideService, userService)
+	findRideRequestById := routes.NewFindRideRequestById(rideRequestService, userService)
+	findChangeHistory := routes.NewFindChangeHistory(changeHistoryService)
+	listRides := routes.NewListRides(rideService, userService)
+	listRideRequests := routes.NewListRideRequests(rideRequestService, userService)
+	updateRide := routes.NewUpdateRide(rideService)
+	updateRideRequest := routes.NewUpdateRideRequest(rideRequestService)
+	joinRide := routes.NewJoinRide(rideService)
+	leaveRide := routes.NewLeaveRide(rideService)
+	findRideFares := routes.NewFindRideFares(rideService)
+	findRideEmissions := routes.NewFindRideEmissions(rideService)
+	findRideStops := routes.NewFindRideStops(rideService)
+	completeRide := routes.NewCompleteRide(rideService)
+	findUserStats := routes.NewFindUserStats(statsService)
+	findLeaderboard := routes.NewFindLeaderboard(statsService, userService)
+	findUserPreferences := routes.NewFindUserPreferences(statsService)
+	updateUserPreferences := routes.NewUpdateUserPreferences(statsService)
+	chargeRidePassenger := routes.NewChargeRidePassenger(paymentService)
+	findRidePayments := routes.NewFindRidePayments(paymentService)
+	refundPayment := routes.NewRefundPayment(paymentService)
+	findDriverStatement := routes.NewFindDriverStatement(statementService)
+	createRideSeries := routes.NewCreateRideSeries(seriesService)
+	findRideSeries := routes.NewFindRideSeries(seriesService)
+	createRideRequestSeries := routes.NewCreateRideRequestSeries(seriesService)
+	findRideRequestSeries := routes.NewFindRideRequestSeries(seriesService)
+	matchRideRequestSeries := routes.NewMatchRideRequestSeries(seriesService)
+	createSchool := routes.NewCreateSchool(schoolService)
+	findSchools := routes.NewFindSchools(schoolService)
+	findSchoolById := routes.NewFindSchoolById(schoolService)
+	updateSchool := routes.NewUpdateSchool(schoolService)
+	verifySchoolMember := routes.NewVerifySchoolMember(statsService)
 	verifyTokenService := services.NewVerifyTokenService(verifyTokenRepository)
 
+	apiKeyRepository := repository.NewApiKeyRepository(configs.GetEnv("SERVICE_API_KEY_SECRET", ""), envList("SERVICE_API_KEY_REVOKED"))
+	serviceAuthService := services.NewServiceAuthService(verifyTokenRepository, apiKeyRepository)
+
+	internalRoutes := []api.InternalRoute{
+		routes.NewInternalFindRideById(rideService),
+		routes.NewInternalFindRidesByUser(rideService),
+	}
+
+	// Payment providers sign their callbacks instead of sending user tokens.
+	callbackRoutes := []api.Route{
+		routes.NewPaymentCallback(paymentService),
+	}
+
 	routes := []api.Route{
 		createRideRequestRoute,
 		findNearRideRequestRoute,
@@ -98,6 +202,35 @@ func main() {
 		createRideRoute,
 		findRideById,
 		findRideRequestById,
+		findChangeHistory,
+		listRides,
+		listRideRequests,
+		updateRide,
+		updateRideRequest,
+		joinRide,
+		leaveRide,
+		findRideFares,
+		findRideEmissions,
+		findRideStops,
+		completeRide,
+		findUserStats,
+		findLeaderboard,
+		findUserPreferences,
+		updateUserPreferences,
+		chargeRidePassenger,
+		findRidePayments,
+		refundPayment,
+		findDriverStatement,
+		createRideSeries,
+		findRideSeries,
+		createRideRequestSeries,
+		findRideRequestSeries,
+		matchRideRequestSeries,
+		createSchool,
+		findSchools,
+		findSchoolById,
+		updateSchool,
+		verifySchoolMember,
 		websocket,
 	}
 
@@ -110,28 +243,47 @@ func main() {
 	router := gin.Default()
 
 	authMiddleware := api.NewAuthMiddleware(verifyTokenService)
+	serviceAuthMiddleware := api.NewServiceAuthMiddleware(serviceAuthService)
 
-	router.Use(authMiddleware.AuthMiddlewareHandler())
+	userRouter := router.Group("/", authMiddleware.AuthMiddlewareHandler())
 
 	for _, route := range routes {
-		switch route.GetMethod() {
-		case "GET":
-			router.GET(route.GetPath(), route.GetHandler())
-		case "POST":
-			router.POST(route.GetPath(), route.GetHandler())
-		case "PUT":
-			router.PUT(route.GetPath(), route.GetHandler())
-		case "DELETE":
-			router.DELETE(route.GetPath(), route.GetHandler())
-		default:
-			fmt.Printf("Unsupported method: %s for path: %s\n", route.GetMethod(), route.GetPath())
-		}
+		api.Register(userRouter, route)
 	}
 
-	router.GET("/", func(c *gin.Context) {
+	for _, route := range internalRoutes {
+		api.Register(router, route, serviceAuthMiddleware.ServiceAuthMiddlewareHandler(route.GetScopes()...))
+	}
+
+	for _, route := range callbackRoutes {
+		api.Register(router, route)
+	}
+
+	userRouter.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"message": "its working"})
 	})
+	seriesInterval := time.Duration(configs.GetEnvAsInt("SERIES_MATERIALIZE_INTERVAL_MS", 3600000)) * time.Millisecond
+	go materializeSeries(context.Background(), seriesService, seriesInterval)
+
+	rideServer := rpc.NewRideServer(rideService, rideRequestService, rideEventService)
+	go func() {
+		if err := rpc.Serve(configs.GetEnv("GRPC_SERVER_ADDR", ":50052"), rideServer, serviceAuthService); err != nil {
+			log.Fatalf("gRPC server stopped: %v", err)
+		}
+	}()
+
 	router.Run(":8080")
 
 }
+
+// envList reads a comma separated environment variable, skipping blanks.
+func envList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(configs.GetEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	config "github.com/244Walyson/shared-ride/configs/db"
+	"github.com/244Walyson/shared-ride/db"
+	"github.com/244Walyson/shared-ride/db/migrate"
+)
+
+const migrationsDir = "migrations"
+
+// runMigrate handles `migrate up|down|status`.
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s migrate up|down|status", os.Args[0])
+	}
+
+	conn, err := config.ConnectMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	migrator := migrate.New(conn, db.Migrations, migrationsDir)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %s\n", migration.Script)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %s\n", reverted.Script)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tDESCRIPTION\tSTATE\tINSTALLED ON")
+		for _, status := range statuses {
+			installedOn := ""
+			if !status.InstalledOn.IsZero() {
+				installedOn = status.InstalledOn.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Version, status.Description, status.State, installedOn)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", args[0])
+	}
+}
+
+// autoMigrate applies pending migrations before the application starts.
+func autoMigrate(ctx context.Context) error {
+	conn, err := config.ConnectMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	applied, err := migrate.New(conn, db.Migrations, migrationsDir).Up(ctx)
+	for _, migration := range applied {
+		fmt.Printf("applied %s\n", migration.Script)
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs"
+	config "github.com/244Walyson/shared-ride/configs/db"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/nearbench"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// Benchmarks the ride near search against a migrated development database:
+//
+//	go run ./cmd/nearbench -rides 300000 -queries 200 -compare
+//
+// The synthetic rides are inserted in a transaction that is rolled back at
+// the end, so the database is left untouched. With -compare the GiST indexes
+// are dropped inside the same transaction and the queries run again, which
+// locks tb_rides until the tool exits; do not point it at a shared database.
+func main() {
+	configs.Init()
+
+	rides := flag.Int("rides", 300000, "number of synthetic rides to insert")
+	queries := flag.Int("queries", 200, "number of near searches to time")
+	compare := flag.Bool("compare", false, "also time the searches without the GiST indexes")
+	seed := flag.Int64("seed", 1, "random seed for the searched routes")
+	flag.Parse()
+
+	ctx := context.Background()
+	pool, err := config.ConnectDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer tx.Rollback(ctx)
+
+	start := time.Now()
+	if err := nearbench.SeedRides(ctx, tx, *rides); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("seeded %d rides in %s\n", *rides, time.Since(start).Round(time.Millisecond))
+
+	routes := nearbench.RandomRoutes(rand.New(rand.NewSource(*seed)), *queries)
+	rideRepository := repository.NewRideRepository(tx)
+
+	if err := run(ctx, "with GiST indexes", rideRepository.FindNear, routes); err != nil {
+		log.Fatal(err)
+	}
+
+	if *compare {
+		for _, index := range nearbench.Indexes {
+			if _, err := tx.Exec(ctx, "DROP INDEX "+index); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if err := run(ctx, "without GiST indexes", rideRepository.FindNear, routes); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func run(ctx context.Context, label string, findNear func(context.Context, []*models.Location, int32) ([]*models.Ride, error), routes [][]*models.Location) error {
+	durations := make([]time.Duration, len(routes))
+	found := 0
+	for i, route := range routes {
+		start := time.Now()
+		// No school, so only the spatial search is measured.
+		rides, err := findNear(ctx, route, 0)
+		if err != nil {
+			return err
+		}
+		durations[i] = time.Since(start)
+		found += len(rides)
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	slices.Sort(durations)
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	percentile := func(p int) time.Duration {
+		return durations[(len(durations)-1)*p/100]
+	}
+	fmt.Printf("%s: %d searches, avg %s, p50 %s, p95 %s, max %s, %.1f rides per search\n",
+		label, len(durations),
+		(total / time.Duration(len(durations))).Round(time.Microsecond),
+		percentile(50).Round(time.Microsecond),
+		percentile(95).Round(time.Microsecond),
+		durations[len(durations)-1].Round(time.Microsecond),
+		float64(found)/float64(len(durations)))
+	return nil
+}
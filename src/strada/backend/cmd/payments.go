@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/244Walyson/shared-ride/configs"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/payment"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// openPaymentProvider builds the provider selected by PAYMENT_PROVIDER.
+func openPaymentProvider(name string) (out.PaymentProvider, error) {
+	switch name {
+	case payment.FakeProviderName:
+		return payment.NewFakeProvider(configs.GetEnv("PAYMENT_FAKE_WEBHOOK_SECRET", "")), nil
+	}
+	return nil, fmt.Errorf("unknown PAYMENT_PROVIDER %q, expected %s", name, payment.FakeProviderName)
+}
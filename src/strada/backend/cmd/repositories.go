@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/244Walyson/shared-ride/configs"
+	config "github.com/244Walyson/shared-ride/configs/db"
+	"github.com/244Walyson/shared-ride/configs/grpc_client"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+const (
+	repositoryDriverPostgres = "postgres"
+	repositoryDriverMemory   = "memory"
+)
+
+type repositories struct {
+	ride               out.RideRepository
+	rideRequest        out.RideRequestRepository
+	user               out.UserRepository
+	vehicle            out.VehicleRepository
+	userPreferences    out.UserPreferencesRepository
+	payment            out.PaymentRepository
+	rideSeries         out.RideSeriesRepository
+	rideRequestSeries  out.RideRequestSeriesRepository
+	school             out.SchoolRepository
+	changeHistory      out.ChangeHistoryRepository
+	transactionManager out.TransactionManager
+	close              func()
+}
+
+// openRepositories builds the repositories selected by REPOSITORY_DRIVER.
+func openRepositories(driver string) (*repositories, error) {
+	switch driver {
+	case repositoryDriverPostgres:
+		return openPostgresRepositories()
+	case repositoryDriverMemory:
+		return openMemoryRepositories()
+	}
+	return nil, fmt.Errorf("unknown REPOSITORY_DRIVER %q, expected %s or %s", driver, repositoryDriverPostgres, repositoryDriverMemory)
+}
+
+func openPostgresRepositories() (*repositories, error) {
+	database, err := config.ConnectDB()
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the database: %w", err)
+	}
+
+	conn, err := grpc_client.Connect("user.UserService", grpc_client.LoadUserServiceConfig())
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("did not connect: %w", err)
+	}
+
+	return &repositories{
+		ride:               repository.NewRideRepository(database),
+		rideRequest:        repository.NewRideRequestRepository(database),
+		user:               repository.NewUserRepository(conn),
+		vehicle:            repository.NewVehicleRepository(database),
+		userPreferences:    repository.NewUserPreferencesRepository(database),
+		payment:            repository.NewPaymentRepository(database),
+		rideSeries:         repository.NewRideSeriesRepository(database),
+		rideRequestSeries:  repository.NewRideRequestSeriesRepository(database),
+		school:             repository.NewSchoolRepository(database),
+		changeHistory:      repository.NewChangeHistoryRepository(database),
+		transactionManager: repository.NewTransactionManager(database),
+		close: func() {
+			conn.Close()
+			database.Close()
+		},
+	}, nil
+}
+
+// openMemoryRepositories keeps everything in process, for local development
+// without Postgres or the user service. Users come from MEMORY_USERS_FILE.
+func openMemoryRepositories() (*repositories, error) {
+	user := memory.NewUserRepository(nil, nil)
+	if path := configs.GetEnv("MEMORY_USERS_FILE", ""); path != "" {
+		var err error
+		if user, err = memory.NewUserRepositoryFromFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &repositories{
+		ride:               memory.NewRideRepository(),
+		rideRequest:        memory.NewRideRequestRepository(),
+		user:               user,
+		vehicle:            memory.NewVehicleRepository(nil),
+		userPreferences:    memory.NewUserPreferencesRepository(),
+		payment:            memory.NewPaymentRepository(),
+		rideSeries:         memory.NewRideSeriesRepository(),
+		rideRequestSeries:  memory.NewRideRequestSeriesRepository(),
+		school:             memory.NewSchoolRepository(),
+		changeHistory:      memory.NewChangeHistoryRepository(),
+		transactionManager: repository.NewNoopTransactionManager(),
+		close:              func() {},
+	}, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/routing"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+const (
+	routingProviderHaversine = "haversine"
+	routingProviderOsrm      = "osrm"
+)
+
+// openRoutingProvider builds the provider selected by ROUTING_PROVIDER.
+func openRoutingProvider(name string) (out.RoutingProvider, error) {
+	switch name {
+	case routingProviderHaversine:
+		detourFactor, err := strconv.ParseFloat(configs.GetEnv("ROUTING_DETOUR_FACTOR", "1.3"), 64)
+		if err != nil || detourFactor < 1 {
+			return nil, fmt.Errorf("invalid ROUTING_DETOUR_FACTOR, expected a number of at least 1")
+		}
+		speedKmh, err := strconv.ParseFloat(configs.GetEnv("ROUTING_SPEED_KMH", "30"), 64)
+		if err != nil || speedKmh <= 0 {
+			return nil, fmt.Errorf("invalid ROUTING_SPEED_KMH, expected a positive number")
+		}
+		return routing.NewHaversineRouter(detourFactor, speedKmh), nil
+	case routingProviderOsrm:
+		timeout := time.Duration(configs.GetEnvAsInt("OSRM_TIMEOUT_MS", 2000)) * time.Millisecond
+		return routing.NewOsrmRouter(configs.GetEnv("OSRM_URL", "http://localhost:5000"), configs.GetEnv("OSRM_PROFILE", "driving"), timeout), nil
+	}
+	return nil, fmt.Errorf("unknown ROUTING_PROVIDER %q, expected %s or %s", name, routingProviderHaversine, routingProviderOsrm)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+)
+
+// materializeSeries creates the upcoming occurrences of every series right
+// away and then every interval, so series keep a full horizon ahead.
+func materializeSeries(ctx context.Context, seriesService in.SeriesService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := seriesService.Materialize(ctx, time.Now()); err != nil {
+			log.Printf("Cannot materialize series occurrences: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -5,12 +5,20 @@ import (
 	"log"
 
 	"github.com/244Walyson/shared-ride/configs"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func ConnectDB() (*pgxpool.Pool, error) {
 
-	dbpool, err := pgxpool.New(context.Background(), getConnectionString())
+	poolConfig, err := pgxpool.ParseConfig(getConnectionString())
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.AfterConnect = postgis.Register
+
+	dbpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -28,3 +36,9 @@ func ConnectDB() (*pgxpool.Pool, error) {
 func getConnectionString() string {
 	return configs.GetEnv("DATABASE_URL", "")
 }
+
+// ConnectMigrator opens a single connection for schema migrations. It skips
+// the pool setup so it works on a database where PostGIS is not yet usable.
+func ConnectMigrator(ctx context.Context) (*pgx.Conn, error) {
+	return pgx.Connect(ctx, getConnectionString())
+}
@@ -0,0 +1,145 @@
+package grpc_client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// CircuitBreaker stops calling a downstream service after a number of
+// consecutive transport failures and lets a single probe through once the
+// reset timeout has elapsed. Only the probe closes or reopens the circuit.
+type CircuitBreaker struct {
+	failureThreshold int
+	resetTimeout     time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	// generation changes with every state change, so results of calls
+	// started before it are ignored.
+	generation uint64
+}
+
+// breakerCall is what allow hands a call so its result is recorded against
+// the state it was let through in.
+type breakerCall struct {
+	generation uint64
+	probe      bool
+}
+
+func NewCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+func (b *CircuitBreaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call, ok := b.allow()
+		if !ok {
+			return status.Errorf(codes.Unavailable, "circuit breaker open for %s", method)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(call, method, err)
+		return err
+	}
+}
+
+func (b *CircuitBreaker) allow() (breakerCall, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Since(b.openedAt) < b.resetTimeout {
+			return breakerCall{}, false
+		}
+		b.setState(stateHalfOpen)
+		b.probing = true
+		return breakerCall{generation: b.generation, probe: true}, true
+	case stateHalfOpen:
+		if b.probing {
+			return breakerCall{}, false
+		}
+		b.probing = true
+		return breakerCall{generation: b.generation, probe: true}, true
+	default:
+		return breakerCall{generation: b.generation}, true
+	}
+}
+
+func (b *CircuitBreaker) record(call breakerCall, method string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A call started before the circuit opened or half opened says nothing
+	// about the downstream service now.
+	if call.generation != b.generation {
+		return
+	}
+
+	if call.probe {
+		b.probing = false
+		if isBreakerFailure(err) {
+			b.open(method)
+		} else {
+			logger.Info("circuit breaker closed", zap.String("method", method))
+			b.setState(stateClosed)
+		}
+		return
+	}
+
+	if !isBreakerFailure(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.open(method)
+	}
+}
+
+func (b *CircuitBreaker) open(method string) {
+	if b.state == stateClosed {
+		logger.Info("circuit breaker opened", zap.String("method", method), zap.Int("failures", b.failures))
+	}
+	b.setState(stateOpen)
+	b.openedAt = time.Now()
+}
+
+// setState must be called with b.mu held.
+func (b *CircuitBreaker) setState(state breakerState) {
+	b.state = state
+	b.failures = 0
+	b.generation++
+}
+
+// isBreakerFailure only counts errors that signal the downstream service is
+// unhealthy; application errors such as NotFound keep the circuit closed.
+func isBreakerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package grpc_client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const resetTimeout = 20 * time.Millisecond
+
+var errUnavailable = status.Error(codes.Unavailable, "down")
+
+// invoke calls through the interceptor with an invoker returning result, and
+// reports whether the breaker let the call reach it.
+func invoke(t *testing.T, b *CircuitBreaker, result error) bool {
+	t.Helper()
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		return result
+	}
+	err := b.UnaryClientInterceptor()(t.Context(), "/user.UserService/FindById", nil, nil, nil, invoker)
+	if !called && status.Code(err) != codes.Unavailable {
+		t.Fatalf("rejected call error = %v, want Unavailable", err)
+	}
+	return called
+}
+
+func TestCircuitBreakerOpensProbesAndCloses(t *testing.T) {
+	b := NewCircuitBreaker(2, resetTimeout)
+
+	invoke(t, b, errUnavailable)
+	// NotFound is the service answering, it does not count.
+	invoke(t, b, status.Error(codes.NotFound, "no user"))
+	invoke(t, b, errUnavailable)
+	if !invoke(t, b, nil) {
+		t.Fatal("breaker opened before two consecutive failures")
+	}
+	invoke(t, b, errUnavailable)
+	invoke(t, b, errUnavailable)
+	if invoke(t, b, nil) {
+		t.Fatal("open breaker let a call through before the reset timeout")
+	}
+
+	// A failed probe opens the circuit again.
+	time.Sleep(resetTimeout)
+	if !invoke(t, b, errUnavailable) {
+		t.Fatal("breaker did not let the probe through after the reset timeout")
+	}
+	if invoke(t, b, nil) {
+		t.Fatal("breaker let a call through after the probe failed")
+	}
+
+	time.Sleep(resetTimeout)
+	if !invoke(t, b, nil) {
+		t.Fatal("breaker did not let the second probe through")
+	}
+	for range 3 {
+		if !invoke(t, b, nil) {
+			t.Fatal("breaker stayed open after a successful probe")
+		}
+	}
+}
+
+func TestCircuitBreakerAllowsOneProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, resetTimeout)
+	invoke(t, b, errUnavailable)
+	time.Sleep(resetTimeout)
+
+	probe, ok := b.allow()
+	if !ok || !probe.probe {
+		t.Fatalf("allow after the reset timeout = %+v, %v, want the probe", probe, ok)
+	}
+	if _, ok := b.allow(); ok {
+		t.Fatal("breaker let a second call through while probing")
+	}
+	b.record(probe, "probe", nil)
+	if call, ok := b.allow(); !ok || call.probe {
+		t.Errorf("allow after the probe succeeded = %+v, %v, want a regular call", call, ok)
+	}
+}
+
+func TestCircuitBreakerIgnoresLateResults(t *testing.T) {
+	b := NewCircuitBreaker(1, resetTimeout)
+
+	// A slow call starts while closed and finishes after the circuit opened.
+	slow, _ := b.allow()
+	failed, _ := b.allow()
+	b.record(failed, "failed", errUnavailable)
+	b.record(slow, "slow", nil)
+	if _, ok := b.allow(); ok {
+		t.Fatal("late success closed the open breaker")
+	}
+
+	// Nor does it close or free the probe while half open.
+	time.Sleep(resetTimeout)
+	probe, ok := b.allow()
+	if !ok || !probe.probe {
+		t.Fatalf("allow after the reset timeout = %+v, %v, want the probe", probe, ok)
+	}
+	b.record(slow, "slow", nil)
+	if _, ok := b.allow(); ok {
+		t.Fatal("late result let a second probe through")
+	}
+
+	// A late failure does not reopen the circuit the probe closed.
+	b.record(probe, "probe", nil)
+	b.record(slow, "slow", errUnavailable)
+	if _, ok := b.allow(); !ok {
+		t.Error("late failure opened the closed breaker")
+	}
+}
@@ -0,0 +1,182 @@
+package grpc_client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	TLSModeInsecure = "insecure"
+	TLSModeTLS      = "tls"
+	TLSModeMTLS     = "mtls"
+)
+
+type Config struct {
+	Address    string
+	TLSMode    string
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+
+	Timeout        time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	BreakerFailureThreshold int
+	BreakerResetTimeout     time.Duration
+}
+
+// LoadUserServiceConfig reads the user service client settings from the
+// USER_SERVICE_* environment variables.
+func LoadUserServiceConfig() Config {
+	return Config{
+		Address:                 configs.GetEnv("USER_SERVICE_ADDR", "localhost:50051"),
+		TLSMode:                 strings.ToLower(configs.GetEnv("USER_SERVICE_TLS_MODE", TLSModeInsecure)),
+		CAFile:                  configs.GetEnv("USER_SERVICE_CA_FILE", ""),
+		CertFile:                configs.GetEnv("USER_SERVICE_CERT_FILE", ""),
+		KeyFile:                 configs.GetEnv("USER_SERVICE_KEY_FILE", ""),
+		ServerName:              configs.GetEnv("USER_SERVICE_SERVER_NAME", ""),
+		Timeout:                 time.Duration(configs.GetEnvAsInt("USER_SERVICE_TIMEOUT_MS", 2000)) * time.Millisecond,
+		MaxAttempts:             configs.GetEnvAsInt("USER_SERVICE_MAX_ATTEMPTS", 3),
+		InitialBackoff:          time.Duration(configs.GetEnvAsInt("USER_SERVICE_INITIAL_BACKOFF_MS", 100)) * time.Millisecond,
+		MaxBackoff:              time.Duration(configs.GetEnvAsInt("USER_SERVICE_MAX_BACKOFF_MS", 1000)) * time.Millisecond,
+		BreakerFailureThreshold: configs.GetEnvAsInt("USER_SERVICE_BREAKER_FAILURES", 5),
+		BreakerResetTimeout:     time.Duration(configs.GetEnvAsInt("USER_SERVICE_BREAKER_RESET_MS", 30000)) * time.Millisecond,
+	}
+}
+
+// Connect creates a client connection for the given service. The timeout and
+// retry policy are applied through the gRPC service config, so every method
+// of the service inherits them.
+func Connect(service string, cfg Config) (*grpc.ClientConn, error) {
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceConfig, err := buildServiceConfig(service, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+	}
+	if cfg.BreakerFailureThreshold > 0 {
+		breaker := NewCircuitBreaker(cfg.BreakerFailureThreshold, cfg.BreakerResetTimeout)
+		opts = append(opts, grpc.WithChainUnaryInterceptor(breaker.UnaryClientInterceptor()))
+	}
+
+	conn, err := grpc.NewClient(cfg.Address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("gRPC client for %s configured at %s (tls: %s)", service, cfg.Address, cfg.TLSMode)
+	return conn, nil
+}
+
+func transportCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	switch cfg.TLSMode {
+	case "", TLSModeInsecure:
+		return insecure.NewCredentials(), nil
+	case TLSModeTLS, TLSModeMTLS:
+	default:
+		return nil, fmt.Errorf("unknown TLS mode: %s", cfg.TLSMode)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.TLSMode == TLSModeMTLS {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("mtls requires a client certificate and key")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type methodName struct {
+	Service string `json:"service"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+func buildServiceConfig(service string, cfg Config) (string, error) {
+	method := methodConfig{
+		Name: []methodName{{Service: service}},
+	}
+	if cfg.Timeout > 0 {
+		method.Timeout = durationString(cfg.Timeout)
+	}
+	// gRPC rejects retry policies with less than two attempts or without backoff.
+	if cfg.MaxAttempts >= 2 && cfg.InitialBackoff > 0 && cfg.MaxBackoff > 0 {
+		method.RetryPolicy = &retryPolicy{
+			MaxAttempts:          cfg.MaxAttempts,
+			InitialBackoff:       durationString(cfg.InitialBackoff),
+			MaxBackoff:           durationString(cfg.MaxBackoff),
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE"},
+		}
+	}
+
+	raw, err := json.Marshal(serviceConfig{MethodConfig: []methodConfig{method}})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func durationString(d time.Duration) string {
+	return fmt.Sprintf("%.3fs", d.Seconds())
+}
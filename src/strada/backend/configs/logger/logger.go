@@ -9,10 +9,12 @@ import (
 )
 
 var (
-	log *zap.Logger
+	log   *zap.Logger
+	audit *zap.Logger
 
-	LOG_OUTPUT = "LOG_OUTPUT"
-	LOG_LEVEL  = "LOG_LEVEL"
+	LOG_OUTPUT       = "LOG_OUTPUT"
+	LOG_LEVEL        = "LOG_LEVEL"
+	AUDIT_LOG_OUTPUT = "AUDIT_LOG_OUTPUT"
 )
 
 func init() {
@@ -31,6 +33,11 @@ func init() {
 	}
 
 	log, _ = logConfig.Build()
+
+	auditConfig := logConfig
+	auditConfig.OutputPaths = []string{getAuditOutputLogs()}
+	auditConfig.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	audit, _ = auditConfig.Build(zap.Fields(zap.String("log_type", "service_audit")))
 }
 
 func Info(message string, tags ...zap.Field) {
@@ -44,6 +51,22 @@ func Error(message string, err error, tags ...zap.Field) {
 	log.Sync()
 }
 
+// Audit records service-to-service traffic in its own stream so it can be
+// retained and reviewed apart from user traffic.
+func Audit(message string, tags ...zap.Field) {
+	audit.Info(message, tags...)
+	audit.Sync()
+}
+
+func getAuditOutputLogs() string {
+	output := strings.ToLower(strings.TrimSpace(os.Getenv(AUDIT_LOG_OUTPUT)))
+	if output == "" {
+		return "stdout"
+	}
+
+	return output
+}
+
 func getOutputLogs() string {
 	output := strings.ToLower(strings.TrimSpace(os.Getenv(LOG_OUTPUT)))
 	if output == "" {
@@ -86,3 +86,27 @@ func NewForbiddenError(message string) *RestErr {
 		Code:    http.StatusForbidden,
 	}
 }
+
+func NewServiceUnavailableError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "service_unavailable",
+		Code:    http.StatusServiceUnavailable,
+	}
+}
+
+func NewPreconditionRequiredError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "precondition_required",
+		Code:    http.StatusPreconditionRequired,
+	}
+}
+
+func NewConflictError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "conflict",
+		Code:    http.StatusConflict,
+	}
+}
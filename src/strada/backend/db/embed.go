@@ -0,0 +1,9 @@
+package db
+
+import "embed"
+
+// Migrations holds the Flyway-style V<n>__*.sql scripts and their optional
+// U<n>__*.sql undo counterparts.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
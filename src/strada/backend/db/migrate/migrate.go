@@ -0,0 +1,340 @@
+// Package migrate applies the embedded SQL migrations, keeping the history
+// in Flyway's flyway_schema_history table so both tools can be used on the
+// same database.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	historyTable = "flyway_schema_history"
+
+	// lockKey serializes migrators running against the same database.
+	lockKey = 0x73686172
+)
+
+const (
+	StateApplied          = "Success"
+	StatePending          = "Pending"
+	StateFailed           = "Failed"
+	StateMissing          = "Missing"
+	StateChecksumMismatch = "Checksum mismatch"
+	StateBelowBaseline    = "Below baseline"
+)
+
+var ErrNoUndo = errors.New("migrate: no undo script for the latest applied version")
+
+type Status struct {
+	Version     string
+	Description string
+	Script      string
+	State       string
+	InstalledOn time.Time
+}
+
+type appliedRow struct {
+	rank        int
+	version     string
+	description string
+	kind        string
+	script      string
+	checksum    *int32
+	installedOn time.Time
+	success     bool
+}
+
+type Migrator struct {
+	conn *pgx.Conn
+	fsys fs.FS
+	dir  string
+}
+
+func New(conn *pgx.Conn, fsys fs.FS, dir string) *Migrator {
+	return &Migrator{
+		conn: conn,
+		fsys: fsys,
+		dir:  dir,
+	}
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	err := m.locked(ctx, func() error {
+		migrations, history, err := m.state(ctx)
+		if err != nil {
+			return err
+		}
+		if err := validate(migrations, history); err != nil {
+			return err
+		}
+
+		current := latestVersion(history)
+		baseline := baselineVersion(history)
+		appliedVersions := versionsOf(history)
+		for _, migration := range migrations {
+			if appliedVersions[migration.Version] {
+				continue
+			}
+			if baseline != "" && compareVersions(migration.Version, baseline) <= 0 {
+				continue
+			}
+			if current != "" && compareVersions(migration.Version, current) <= 0 {
+				return fmt.Errorf("migrate: %s is older than the applied version %s", migration.Script, current)
+			}
+			if err := m.apply(ctx, migration, nextRank(history)); err != nil {
+				return err
+			}
+			history = append(history, appliedRow{rank: nextRank(history), version: migration.Version, kind: "SQL", success: true})
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the latest applied migration with its U<n>__ undo script and
+// removes it from the history, so Flyway sees it as pending again.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	var reverted *Migration
+	err := m.locked(ctx, func() error {
+		migrations, history, err := m.state(ctx)
+		if err != nil {
+			return err
+		}
+		if err := validate(migrations, history); err != nil {
+			return err
+		}
+
+		current := latestVersion(history)
+		if current == "" {
+			return errors.New("migrate: nothing to revert")
+		}
+		for _, migration := range migrations {
+			if migration.Version != current {
+				continue
+			}
+			if migration.Undo == nil {
+				return fmt.Errorf("%w (%s)", ErrNoUndo, migration.Script)
+			}
+			if err := m.revert(ctx, migration); err != nil {
+				return err
+			}
+			reverted = migration
+			return nil
+		}
+		return fmt.Errorf("migrate: applied version %s has no script", current)
+	})
+	return reverted, err
+}
+
+// Status lists every known migration, applied or not, in version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, history, err := m.state(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := baselineVersion(history)
+	rows := map[string]appliedRow{}
+	for _, row := range history {
+		if row.version != "" && row.kind != "BASELINE" {
+			rows[row.version] = row
+		}
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		status := Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Script:      migration.Script,
+			State:       StatePending,
+		}
+		row, ok := rows[migration.Version]
+		switch {
+		case ok && !row.success:
+			status.State, status.InstalledOn = StateFailed, row.installedOn
+		case ok && row.checksum != nil && *row.checksum != migration.Checksum:
+			status.State, status.InstalledOn = StateChecksumMismatch, row.installedOn
+		case ok:
+			status.State, status.InstalledOn = StateApplied, row.installedOn
+		case baseline != "" && compareVersions(migration.Version, baseline) <= 0:
+			status.State = StateBelowBaseline
+		}
+		delete(rows, migration.Version)
+		statuses = append(statuses, status)
+	}
+
+	for _, row := range history {
+		if _, missing := rows[row.version]; missing {
+			statuses = append(statuses, Status{
+				Version:     row.version,
+				Description: row.description,
+				Script:      row.script,
+				State:       StateMissing,
+				InstalledOn: row.installedOn,
+			})
+		}
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer m.conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if err := m.ensureHistoryTable(ctx); err != nil {
+		return err
+	}
+	return fn()
+}
+
+func (m *Migrator) ensureHistoryTable(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS `+historyTable+` (
+			installed_rank INT NOT NULL PRIMARY KEY,
+			version VARCHAR(50),
+			description VARCHAR(200) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			script VARCHAR(1000) NOT NULL,
+			checksum INT,
+			installed_by VARCHAR(100) NOT NULL,
+			installed_on TIMESTAMP NOT NULL DEFAULT now(),
+			execution_time INT NOT NULL,
+			success BOOLEAN NOT NULL
+		)`)
+	return err
+}
+
+func (m *Migrator) state(ctx context.Context) ([]*Migration, []appliedRow, error) {
+	migrations, err := load(m.fsys, m.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var exists bool
+	if err := m.conn.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", historyTable).Scan(&exists); err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		return migrations, nil, nil
+	}
+
+	rows, err := m.conn.Query(ctx, `
+		SELECT installed_rank, COALESCE(version, ''), description, type, script, checksum, installed_on, success
+		FROM `+historyTable+`
+		ORDER BY installed_rank`)
+	if err != nil {
+		return nil, nil, err
+	}
+	history, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (appliedRow, error) {
+		var r appliedRow
+		err := row.Scan(&r.rank, &r.version, &r.description, &r.kind, &r.script, &r.checksum, &r.installedOn, &r.success)
+		return r, err
+	})
+	return migrations, history, err
+}
+
+func (m *Migrator) apply(ctx context.Context, migration *Migration, rank int) error {
+	return pgx.BeginFunc(ctx, m.conn, func(tx pgx.Tx) error {
+		start := time.Now()
+		if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+			return fmt.Errorf("migrate: %s: %w", migration.Script, err)
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO `+historyTable+` (installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+			VALUES ($1, $2, $3, 'SQL', $4, $5, current_user, $6, true)`,
+			rank, migration.Version, migration.Description, migration.Script, migration.Checksum, time.Since(start).Milliseconds())
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, migration *Migration) error {
+	return pgx.BeginFunc(ctx, m.conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Undo.SQL); err != nil {
+			return fmt.Errorf("migrate: %s: %w", migration.Undo.Script, err)
+		}
+		_, err := tx.Exec(ctx, "DELETE FROM "+historyTable+" WHERE version = $1", migration.Version)
+		return err
+	})
+}
+
+// validate refuses to run over failed migrations or scripts edited after
+// they were applied, as Flyway's validate does.
+func validate(migrations []*Migration, history []appliedRow) error {
+	byVersion := make(map[string]*Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	for _, row := range history {
+		if row.kind == "BASELINE" || row.version == "" {
+			continue
+		}
+		if !row.success {
+			return fmt.Errorf("migrate: %s failed previously, repair the history before migrating", row.script)
+		}
+		migration, ok := byVersion[row.version]
+		if !ok {
+			return fmt.Errorf("migrate: applied version %s (%s) has no script", row.version, row.script)
+		}
+		if row.checksum != nil && *row.checksum != migration.Checksum {
+			return fmt.Errorf("migrate: checksum mismatch for %s: applied %d, local %d", migration.Script, *row.checksum, migration.Checksum)
+		}
+	}
+	return nil
+}
+
+func versionsOf(history []appliedRow) map[string]bool {
+	versions := make(map[string]bool, len(history))
+	for _, row := range history {
+		if row.version != "" && row.kind != "BASELINE" {
+			versions[row.version] = true
+		}
+	}
+	return versions
+}
+
+func baselineVersion(history []appliedRow) string {
+	for _, row := range history {
+		if row.kind == "BASELINE" {
+			return row.version
+		}
+	}
+	return ""
+}
+
+// latestVersion is the highest version applied by a migration script.
+func latestVersion(history []appliedRow) string {
+	latest := ""
+	for _, row := range history {
+		if row.version == "" || row.kind == "BASELINE" {
+			continue
+		}
+		if latest == "" || compareVersions(row.version, latest) > 0 {
+			latest = row.version
+		}
+	}
+	return latest
+}
+
+func nextRank(history []appliedRow) int {
+	rank := 0
+	for _, row := range history {
+		if row.rank > rank {
+			rank = row.rank
+		}
+	}
+	return rank + 1
+}
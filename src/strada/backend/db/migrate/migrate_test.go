@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	migrations := []*Migration{
+		{Version: "1", Script: "V1__init.sql", Checksum: 11},
+		{Version: "2", Script: "V2__add_stop_points.sql", Checksum: 22},
+		{Version: "10", Script: "V10__ten.sql", Checksum: 100},
+	}
+	checksum := func(c int32) *int32 { return &c }
+
+	tests := []struct {
+		name    string
+		history []appliedRow
+		wantErr string
+	}{
+		{"nothing applied", nil, ""},
+		{"applied in order", []appliedRow{
+			{version: "1", kind: "SQL", script: "V1__init.sql", checksum: checksum(11), success: true},
+			{version: "2", kind: "SQL", script: "V2__add_stop_points.sql", checksum: checksum(22), success: true},
+		}, ""},
+		{"baseline and schema rows skipped", []appliedRow{
+			{version: "", kind: "SCHEMA", success: true},
+			{version: "5", kind: "BASELINE", success: true},
+			{version: "10", kind: "SQL", script: "V10__ten.sql", checksum: checksum(100), success: true},
+		}, ""},
+		{"no checksum recorded", []appliedRow{
+			{version: "1", kind: "SQL", script: "V1__init.sql", success: true},
+		}, ""},
+		{"edited after applied", []appliedRow{
+			{version: "2", kind: "SQL", script: "V2__add_stop_points.sql", checksum: checksum(23), success: true},
+		}, "checksum mismatch for V2__add_stop_points.sql"},
+		{"failed previously", []appliedRow{
+			{version: "1", kind: "SQL", script: "V1__init.sql", checksum: checksum(11), success: false},
+		}, "V1__init.sql failed previously"},
+		{"script removed", []appliedRow{
+			{version: "3", kind: "SQL", script: "V3__gone.sql", checksum: checksum(33), success: true},
+		}, "applied version 3 (V3__gone.sql) has no script"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(migrations, tt.history)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("validate: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHistoryHelpers(t *testing.T) {
+	history := []appliedRow{
+		{rank: 1, version: "", kind: "SCHEMA"},
+		{rank: 2, version: "1", kind: "BASELINE"},
+		{rank: 3, version: "2", kind: "SQL"},
+		{rank: 5, version: "10", kind: "SQL"},
+		{rank: 4, version: "9", kind: "SQL"},
+	}
+	if got := latestVersion(history); got != "10" {
+		t.Errorf("latestVersion = %q, want 10", got)
+	}
+	if got := baselineVersion(history); got != "1" {
+		t.Errorf("baselineVersion = %q, want 1", got)
+	}
+	if got := nextRank(history); got != 6 {
+		t.Errorf("nextRank = %d, want 6", got)
+	}
+	versions := versionsOf(history)
+	if len(versions) != 3 || !versions["2"] || !versions["9"] || !versions["10"] || versions["1"] {
+		t.Errorf("versionsOf = %v, want 2, 9 and 10", versions)
+	}
+	if got := latestVersion(nil); got != "" {
+		t.Errorf("latestVersion of an empty history = %q", got)
+	}
+}
@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var scriptName = regexp.MustCompile(`^([VU])(\d+(?:[._]\d+)*)__(.+)\.sql$`)
+
+type Migration struct {
+	Version     string
+	Description string
+	Script      string
+	Checksum    int32
+	SQL         string
+	Undo        *Migration
+}
+
+// load reads the versioned migrations in dir, attaching undo scripts to the
+// version they revert and sorting them by version.
+func load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[string]*Migration{}
+	undos := map[string]*Migration{}
+	for _, entry := range entries {
+		matches := scriptName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration := &Migration{
+			Version:     strings.ReplaceAll(matches[2], "_", "."),
+			Description: strings.ReplaceAll(matches[3], "_", " "),
+			Script:      entry.Name(),
+			Checksum:    checksum(content),
+			SQL:         string(content),
+		}
+
+		target := byVersion
+		if matches[1] == "U" {
+			target = undos
+		}
+		if existing, ok := target[migration.Version]; ok {
+			return nil, fmt.Errorf("migrate: version %s found in both %s and %s", migration.Version, existing.Script, migration.Script)
+		}
+		target[migration.Version] = migration
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		migration.Undo = undos[version]
+		migrations = append(migrations, migration)
+	}
+	for version, undo := range undos {
+		if byVersion[version] == nil {
+			return nil, fmt.Errorf("migrate: undo script %s has no versioned migration", undo.Script)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return compareVersions(migrations[i].Version, migrations[j].Version) < 0
+	})
+	return migrations, nil
+}
+
+// checksum reproduces Flyway's CRC32: every line is hashed without its line
+// terminator, after stripping a leading byte order mark.
+func checksum(content []byte) int32 {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	hash := crc32.NewIEEE()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	scanner.Split(scanLines)
+	for scanner.Scan() {
+		hash.Write(scanner.Bytes())
+	}
+	return int32(hash.Sum32())
+}
+
+// scanLines splits on \n, \r and \r\n like Java's BufferedReader.readLine.
+func scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\r' {
+			if i+1 < len(data) {
+				if data[i+1] == '\n' {
+					return i + 2, data[:i], nil
+				}
+				return i + 1, data[:i], nil
+			}
+			if !atEOF {
+				return 0, nil, nil
+			}
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func compareVersions(a, b string) int {
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r int
+		if i < len(left) {
+			l, _ = strconv.Atoi(left[i])
+		}
+		if i < len(right) {
+			r, _ = strconv.Atoi(right[i])
+		}
+		if l != r {
+			if l < r {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
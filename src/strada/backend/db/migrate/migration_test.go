@@ -0,0 +1,140 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/244Walyson/shared-ride/db"
+)
+
+// The expected values are Flyway's checksums, CRC32 over the lines without
+// their terminators, as stored in flyway_schema_history.checksum.
+func TestChecksumMatchesFlyway(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int32
+	}{
+		{"two statements", "CREATE TABLE t (id INT);\nINSERT INTO t VALUES (1);\n", 194980085},
+		{"without final newline", "CREATE TABLE t (id INT);\nINSERT INTO t VALUES (1);", 194980085},
+		{"CRLF", "CREATE TABLE t (id INT);\r\nINSERT INTO t VALUES (1);\r\n", 194980085},
+		{"CR", "CREATE TABLE t (id INT);\rINSERT INTO t VALUES (1);\r", 194980085},
+		{"empty lines", "CREATE TABLE t (id INT);\n\n\nINSERT INTO t VALUES (1);\n", 194980085},
+		{"single line", "SELECT 1;\n", 78787420},
+		{"byte order mark", "\xef\xbb\xbfSELECT 1;\n", 78787420},
+		{"UTF-8", "-- Comentário\nSELECT 1;\n", -481125660},
+		{"empty", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checksum([]byte(tt.content)); got != tt.want {
+				t.Errorf("checksum = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecksumLongLine(t *testing.T) {
+	line := strings.Repeat("x", 200_000)
+	if checksum([]byte(line+"\n")) != checksum([]byte(line)) {
+		t.Error("a line longer than the scanner's default buffer changed the checksum")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"10", "2", 1},
+		{"1", "1", 0},
+		{"1", "1.0", 0},
+		{"1.9", "1.10", -1},
+		{"1.1", "2", -1},
+		{"22", "21.5", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/V10__ten.sql":            {Data: []byte("SELECT 10;")},
+		"migrations/V2__add_stop_points.sql": {Data: []byte("SELECT 2;")},
+		"migrations/U2__add_stop_points.sql": {Data: []byte("SELECT -2;")},
+		"migrations/V1_1__patch.sql":         {Data: []byte("SELECT 1.1;")},
+		"migrations/V1__init.sql":            {Data: []byte("SELECT 1;")},
+		"migrations/README.md":               {Data: []byte("not a migration")},
+	}
+	migrations, err := load(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	var versions []string
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	if got, want := strings.Join(versions, " "), "1 1.1 2 10"; got != want {
+		t.Errorf("versions = %s, want %s", got, want)
+	}
+
+	v2 := migrations[2]
+	if v2.Description != "add stop points" || v2.Script != "V2__add_stop_points.sql" || v2.Checksum != checksum([]byte("SELECT 2;")) {
+		t.Errorf("V2 = %+v", v2)
+	}
+	if v2.Undo == nil || v2.Undo.Script != "U2__add_stop_points.sql" || v2.Undo.SQL != "SELECT -2;" {
+		t.Errorf("V2 undo = %+v", v2.Undo)
+	}
+	if migrations[0].Undo != nil {
+		t.Errorf("V1 undo = %+v, want none", migrations[0].Undo)
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"duplicate version", fstest.MapFS{
+			"migrations/V1__a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/V1__b.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"duplicate version spelled differently", fstest.MapFS{
+			"migrations/V1_1__a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/V1.1__b.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{"undo without migration", fstest.MapFS{
+			"migrations/V1__a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/U2__b.sql": {Data: []byte("SELECT 1;")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := load(tt.fsys, "migrations"); err == nil {
+				t.Error("load succeeded")
+			}
+		})
+	}
+}
+
+// The shipped migrations must load: versions unique and every undo paired.
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := load(db.Migrations, "migrations")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != "1" {
+		t.Fatalf("migrations start at %v, want version 1", migrations)
+	}
+	for i := 1; i < len(migrations); i++ {
+		if compareVersions(migrations[i-1].Version, migrations[i].Version) >= 0 {
+			t.Errorf("%s sorted before %s", migrations[i-1].Script, migrations[i].Script)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+COMMENT ON COLUMN tb_rides.distance IS NULL;
+COMMENT ON COLUMN tb_rides.co2_emission IS NULL;
+COMMENT ON COLUMN tb_rides.cost IS NULL;
+
+ALTER TABLE tb_rides DROP COLUMN currency;
+
+UPDATE tb_rides SET cost = cost * 100 WHERE cost IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP TABLE tb_change_history;
+
+DELETE FROM tb_ride_requests WHERE deleted_at IS NOT NULL;
+DELETE FROM tb_rides WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE tb_ride_requests DROP COLUMN deleted_at;
+ALTER TABLE tb_rides DROP COLUMN deleted_at;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_rides_start_point;
+DROP INDEX IF EXISTS idx_rides_end_point;
+DROP INDEX IF EXISTS idx_rides_stop_points;
+DROP INDEX IF EXISTS idx_ride_requests_origin;
+DROP INDEX IF EXISTS idx_ride_requests_destination;
+DROP INDEX IF EXISTS idx_rides_start_point_geom;
+DROP INDEX IF EXISTS idx_rides_end_point_geom;
+DROP INDEX IF EXISTS idx_ride_requests_origin_geom;
+DROP INDEX IF EXISTS idx_ride_requests_destination_geom;
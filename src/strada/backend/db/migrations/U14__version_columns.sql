@@ -0,0 +1,2 @@
+ALTER TABLE tb_ride_requests DROP COLUMN version;
+ALTER TABLE tb_rides DROP COLUMN version;
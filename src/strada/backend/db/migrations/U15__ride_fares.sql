@@ -0,0 +1,3 @@
+ALTER TABLE tb_ride_passengers DROP COLUMN fare;
+ALTER TABLE tb_ride_passengers DROP COLUMN distance_meters;
+ALTER TABLE tb_rides DROP COLUMN cost_ceiling;
@@ -0,0 +1,3 @@
+ALTER TABLE tb_ride_passengers DROP COLUMN co2_saved_kg;
+ALTER TABLE tb_rides DROP COLUMN co2_saved_kg;
+ALTER TABLE tb_vehicles DROP COLUMN fuel_consumption;
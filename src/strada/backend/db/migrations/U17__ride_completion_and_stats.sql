@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tb_user_preferences;
+DROP INDEX IF EXISTS idx_ride_passengers_user_id;
+DROP INDEX IF EXISTS idx_rides_completed_at;
+ALTER TABLE tb_rides DROP COLUMN completed_at;
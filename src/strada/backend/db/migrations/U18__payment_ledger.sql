@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tb_payment_events;
+DROP TABLE IF EXISTS tb_payments;
@@ -0,0 +1,2 @@
+ALTER TABLE tb_rides DROP COLUMN max_detour_ms;
+ALTER TABLE tb_rides DROP COLUMN max_detour_meters;
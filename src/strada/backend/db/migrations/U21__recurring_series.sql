@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tb_ride_request_occurrences;
+DROP TABLE IF EXISTS tb_ride_occurrences;
+DROP TABLE IF EXISTS tb_ride_request_series;
+DROP TABLE IF EXISTS tb_ride_series;
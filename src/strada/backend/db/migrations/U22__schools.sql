@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_ride_requests_school_id;
+DROP INDEX IF EXISTS idx_rides_school_id;
+
+ALTER TABLE tb_ride_request_series DROP COLUMN school_id;
+ALTER TABLE tb_ride_series DROP COLUMN school_id;
+ALTER TABLE tb_ride_requests DROP COLUMN school_id;
+ALTER TABLE tb_rides DROP COLUMN school_id;
+
+DROP TABLE IF EXISTS tb_schools;
@@ -0,0 +1,6 @@
+ALTER TABLE tb_user_preferences DROP CONSTRAINT IF EXISTS fk_user_preferences_school_id;
+
+ALTER TABLE tb_user_preferences
+ALTER COLUMN school_id TYPE VARCHAR(255) USING school_id::text;
+
+COMMENT ON COLUMN tb_user_preferences.school_id IS 'School whose leaderboard the user takes part in';
@@ -0,0 +1,5 @@
+ALTER TABLE tb_user_preferences DROP COLUMN leaderboard_opt_in;
+ALTER TABLE tb_user_preferences DROP COLUMN minor;
+ALTER TABLE tb_user_preferences DROP COLUMN school_verified_at;
+
+COMMENT ON COLUMN tb_user_preferences.leaderboard_opt_out IS 'Hides the user from the school leaderboard';
@@ -0,0 +1,7 @@
+DELETE FROM tb_ride_request_occurrences WHERE ride_request_id IS NULL;
+ALTER TABLE tb_ride_request_occurrences DROP COLUMN claimed_at;
+ALTER TABLE tb_ride_request_occurrences ALTER COLUMN ride_request_id SET NOT NULL;
+
+DELETE FROM tb_ride_occurrences WHERE ride_id IS NULL;
+ALTER TABLE tb_ride_occurrences DROP COLUMN claimed_at;
+ALTER TABLE tb_ride_occurrences ALTER COLUMN ride_id SET NOT NULL;
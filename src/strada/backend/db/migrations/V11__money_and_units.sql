@@ -0,0 +1,10 @@
+-- Costs were written multiplied by 100 into a DECIMAL(10,2) column, so every
+-- stored value is 100x the real amount.
+UPDATE tb_rides SET cost = cost / 100 WHERE cost IS NOT NULL;
+
+ALTER TABLE tb_rides ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+COMMENT ON COLUMN tb_rides.distance IS 'Distance in meters';
+COMMENT ON COLUMN tb_rides.co2_emission IS 'CO2 emission in kilograms';
+COMMENT ON COLUMN tb_rides.cost IS 'Cost in currency units, two decimal places';
+COMMENT ON COLUMN tb_rides.currency IS 'ISO 4217 currency code';
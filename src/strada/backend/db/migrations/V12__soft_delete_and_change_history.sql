@@ -0,0 +1,14 @@
+ALTER TABLE tb_rides ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE tb_ride_requests ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE TABLE tb_change_history (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(32) NOT NULL,  -- ride ou ride_request
+    entity_id INT NOT NULL,
+    action VARCHAR(16) NOT NULL,  -- created, updated ou deleted
+    changed_by VARCHAR(255) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',  -- campo -> {"from": ..., "to": ...}
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_change_history_entity ON tb_change_history (entity_type, entity_id, created_at);
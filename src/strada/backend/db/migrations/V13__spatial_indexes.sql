@@ -0,0 +1,14 @@
+-- Índices GiST para as buscas por proximidade (ST_DWithin e ordenação KNN <->).
+-- Parciais porque todas as consultas ignoram registros removidos.
+CREATE INDEX idx_rides_start_point ON tb_rides USING GIST (start_point) WHERE deleted_at IS NULL;
+CREATE INDEX idx_rides_end_point ON tb_rides USING GIST (end_point) WHERE deleted_at IS NULL;
+CREATE INDEX idx_rides_stop_points ON tb_rides USING GIST (stop_points) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_ride_requests_origin ON tb_ride_requests USING GIST (origin) WHERE deleted_at IS NULL;
+CREATE INDEX idx_ride_requests_destination ON tb_ride_requests USING GIST (destination) WHERE deleted_at IS NULL;
+
+-- O filtro por bbox das listagens compara em geometry.
+CREATE INDEX idx_rides_start_point_geom ON tb_rides USING GIST ((start_point::geometry)) WHERE deleted_at IS NULL;
+CREATE INDEX idx_rides_end_point_geom ON tb_rides USING GIST ((end_point::geometry)) WHERE deleted_at IS NULL;
+CREATE INDEX idx_ride_requests_origin_geom ON tb_ride_requests USING GIST ((origin::geometry)) WHERE deleted_at IS NULL;
+CREATE INDEX idx_ride_requests_destination_geom ON tb_ride_requests USING GIST ((destination::geometry)) WHERE deleted_at IS NULL;
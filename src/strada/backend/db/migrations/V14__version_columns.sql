@@ -0,0 +1,3 @@
+-- Versão para controle de concorrência otimista; incrementada a cada update.
+ALTER TABLE tb_rides ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE tb_ride_requests ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- Teto de custo definido pelo motorista; NULL quando não há teto.
+ALTER TABLE tb_rides ADD COLUMN cost_ceiling NUMERIC(10, 2);
+
+-- Parte de cada passageiro no custo, proporcional à distância percorrida.
+ALTER TABLE tb_ride_passengers ADD COLUMN distance_meters DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tb_ride_passengers ADD COLUMN fare NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tb_rides.cost IS 'Sum of the passenger fares in currency units, two decimal places';
+COMMENT ON COLUMN tb_ride_passengers.distance_meters IS 'Distance travelled by the passenger in meters';
+COMMENT ON COLUMN tb_ride_passengers.fare IS 'Passenger share of the cost in the ride currency';
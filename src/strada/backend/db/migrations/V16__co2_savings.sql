@@ -0,0 +1,11 @@
+-- Consumo informado pelo motorista; NULL usa o consumo médio do combustível.
+ALTER TABLE tb_vehicles ADD COLUMN fuel_consumption DOUBLE PRECISION;
+
+-- CO2 que os passageiros deixaram de emitir por não dirigirem sozinhos.
+ALTER TABLE tb_rides ADD COLUMN co2_saved_kg DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tb_ride_passengers ADD COLUMN co2_saved_kg DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tb_vehicles.fuel_consumption IS 'Liters (kWh for electric vehicles) per 100 km';
+COMMENT ON COLUMN tb_rides.co2_emission IS 'CO2 emitted by the vehicle over the ride in kilograms, computed by the server';
+COMMENT ON COLUMN tb_rides.co2_saved_kg IS 'Sum of the CO2 saved by the passengers in kilograms';
+COMMENT ON COLUMN tb_ride_passengers.co2_saved_kg IS 'CO2 saved by the passenger versus driving alone in kilograms';
@@ -0,0 +1,23 @@
+-- Momento em que o motorista encerrou a corrida; NULL enquanto não terminou.
+ALTER TABLE tb_rides ADD COLUMN completed_at TIMESTAMP;
+
+CREATE INDEX idx_rides_completed_at ON tb_rides (completed_at)
+WHERE
+    deleted_at IS NULL
+    AND completed_at IS NOT NULL;
+
+CREATE INDEX idx_ride_passengers_user_id ON tb_ride_passengers (user_id);
+
+-- Preferências locais do usuário; o serviço de usuários não guarda a escola.
+CREATE TABLE tb_user_preferences (
+    user_id VARCHAR(255) PRIMARY KEY,
+    school_id VARCHAR(255),
+    leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_preferences_school_id ON tb_user_preferences (school_id);
+
+COMMENT ON COLUMN tb_rides.completed_at IS 'When the driver completed the ride, NULL until then';
+COMMENT ON COLUMN tb_user_preferences.school_id IS 'School whose leaderboard the user takes part in';
+COMMENT ON COLUMN tb_user_preferences.leaderboard_opt_out IS 'Hides the user from the school leaderboard';
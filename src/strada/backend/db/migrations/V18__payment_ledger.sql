@@ -0,0 +1,52 @@
+-- Livro de pagamentos por passageiro da corrida: cobranças do passageiro,
+-- repasses ao motorista e estornos. Substitui tb_payments e tb_ride_payments,
+-- removidas na V4.
+CREATE TABLE tb_payments (
+    id SERIAL PRIMARY KEY,
+    ride_id INT NOT NULL REFERENCES tb_rides (id),
+    passenger_id VARCHAR(255) NOT NULL,
+    driver_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('charge', 'payout', 'refund')),
+    amount NUMERIC(10, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    parent_id INT REFERENCES tb_payments (id),
+    provider VARCHAR(50) NOT NULL,
+    provider_ref VARCHAR(255),
+    failure_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payments_ride_id ON tb_payments (ride_id);
+
+-- No máximo uma cobrança em aberto ou paga por passageiro da corrida.
+CREATE UNIQUE INDEX idx_payments_active_charge ON tb_payments (ride_id, passenger_id)
+WHERE
+    kind = 'charge'
+    AND status <> 'failed';
+
+-- Um único repasse por cobrança.
+CREATE UNIQUE INDEX idx_payments_payout ON tb_payments (parent_id)
+WHERE
+    kind = 'payout';
+
+CREATE UNIQUE INDEX idx_payments_provider_ref ON tb_payments (provider, provider_ref)
+WHERE
+    provider_ref IS NOT NULL;
+
+-- Eventos recebidos dos provedores, para processar cada callback uma só vez.
+CREATE TABLE tb_payment_events (
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    payment_id INT NOT NULL REFERENCES tb_payments (id),
+    status VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, event_id)
+);
+
+COMMENT ON COLUMN tb_payments.kind IS 'charge collects the passenger fare, payout passes it on to the driver, refund returns part of a charge';
+COMMENT ON COLUMN tb_payments.amount IS 'Amount in currency units, two decimal places';
+COMMENT ON COLUMN tb_payments.parent_id IS 'Charge settled by a payout or refund';
+COMMENT ON COLUMN tb_payments.provider_ref IS 'Identifier of the payment at the provider';
+COMMENT ON COLUMN tb_payment_events.event_id IS 'Provider event identifier, unique per provider';
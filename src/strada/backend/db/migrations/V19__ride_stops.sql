@@ -0,0 +1,16 @@
+-- Itinerário calculado pelo servidor: partida, paradas do motorista, embarques
+-- e desembarques dos passageiros e destino, na ordem em que são visitados.
+CREATE TABLE tb_ride_stops (
+    ride_id INT NOT NULL REFERENCES tb_rides (id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('start', 'waypoint', 'pickup', 'dropoff', 'end')),
+    user_id VARCHAR(255),
+    location GEOGRAPHY (Point, 4326) NOT NULL,
+    distance_meters DOUBLE PRECISION NOT NULL,
+    eta_ms BIGINT NOT NULL,
+    PRIMARY KEY (ride_id, position)
+);
+
+COMMENT ON COLUMN tb_ride_stops.user_id IS 'Passenger picked up or dropped off, NULL for the driver stops';
+COMMENT ON COLUMN tb_ride_stops.distance_meters IS 'Distance driven from the start to the stop';
+COMMENT ON COLUMN tb_ride_stops.eta_ms IS 'Estimated time from departure to the stop in milliseconds';
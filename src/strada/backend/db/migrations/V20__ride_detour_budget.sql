@@ -0,0 +1,6 @@
+-- Desvio máximo que o motorista aceita para buscar passageiros; 0 quando não há limite.
+ALTER TABLE tb_rides ADD COLUMN max_detour_meters DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tb_rides ADD COLUMN max_detour_ms BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN tb_rides.max_detour_meters IS 'Extra distance in meters the driver accepts per passenger, 0 for no limit';
+COMMENT ON COLUMN tb_rides.max_detour_ms IS 'Extra driving time in milliseconds the driver accepts per passenger, 0 for no limit';
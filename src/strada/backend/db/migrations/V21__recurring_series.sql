@@ -0,0 +1,75 @@
+-- Séries recorrentes de corridas e pedidos de corrida, para o trajeto diário
+-- até a escola. Cada série guarda o modelo das ocorrências e a regra de
+-- recorrência; as ocorrências dos próximos dias são criadas como corridas e
+-- pedidos comuns.
+CREATE TABLE tb_ride_series (
+    id SERIAL PRIMARY KEY,
+    driver_id VARCHAR(255) NOT NULL,
+    vehicle_id INT NOT NULL REFERENCES tb_vehicles (id),
+    start_point GEOGRAPHY (Point, 4326) NOT NULL,
+    end_point GEOGRAPHY (Point, 4326) NOT NULL,
+    stop_points GEOGRAPHY (MultiPoint, 4326) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    img_url TEXT NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    cost_ceiling NUMERIC(10, 2),
+    max_detour_meters DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_detour_ms BIGINT NOT NULL DEFAULT 0,
+    weekdays INT[] NOT NULL,
+    departure_minutes INT NOT NULL CHECK (departure_minutes BETWEEN 0 AND 1439),
+    time_zone VARCHAR(64) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    exceptions DATE[] NOT NULL DEFAULT '{}',
+    materialized_until TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_ride_series_driver_id ON tb_ride_series (driver_id);
+
+CREATE TABLE tb_ride_request_series (
+    id SERIAL PRIMARY KEY,
+    passenger_id VARCHAR(255) NOT NULL,
+    origin GEOGRAPHY (Point, 4326) NOT NULL,
+    destination GEOGRAPHY (Point, 4326) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    img_url TEXT NOT NULL DEFAULT '',
+    ride_series_id INT REFERENCES tb_ride_series (id),
+    weekdays INT[] NOT NULL,
+    departure_minutes INT NOT NULL CHECK (departure_minutes BETWEEN 0 AND 1439),
+    time_zone VARCHAR(64) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    exceptions DATE[] NOT NULL DEFAULT '{}',
+    materialized_until TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_ride_request_series_passenger_id ON tb_ride_request_series (passenger_id);
+
+-- Uma ocorrência por série e horário de partida, para materializar cada dia
+-- uma só vez.
+CREATE TABLE tb_ride_occurrences (
+    series_id INT NOT NULL REFERENCES tb_ride_series (id) ON DELETE CASCADE,
+    departs_at TIMESTAMP NOT NULL,
+    ride_id INT NOT NULL REFERENCES tb_rides (id) ON DELETE CASCADE,
+    PRIMARY KEY (series_id, departs_at)
+);
+
+CREATE TABLE tb_ride_request_occurrences (
+    series_id INT NOT NULL REFERENCES tb_ride_request_series (id) ON DELETE CASCADE,
+    departs_at TIMESTAMP NOT NULL,
+    ride_request_id INT NOT NULL REFERENCES tb_ride_requests (id) ON DELETE CASCADE,
+    ride_id INT REFERENCES tb_rides (id) ON DELETE SET NULL,
+    PRIMARY KEY (series_id, departs_at)
+);
+
+COMMENT ON COLUMN tb_ride_series.weekdays IS 'Days the ride repeats on, 0 for Sunday through 6 for Saturday';
+COMMENT ON COLUMN tb_ride_series.departure_minutes IS 'Local departure time in minutes after midnight, in time_zone';
+COMMENT ON COLUMN tb_ride_series.end_date IS 'Last day of the series, NULL to repeat without end';
+COMMENT ON COLUMN tb_ride_series.exceptions IS 'Days skipped, such as holidays';
+COMMENT ON COLUMN tb_ride_series.materialized_until IS 'Occurrences departing before this instant, in UTC, were already created';
+COMMENT ON COLUMN tb_ride_request_series.ride_series_id IS 'Ride series the occurrences are matched with';
+COMMENT ON COLUMN tb_ride_request_occurrences.ride_id IS 'Ride the occurrence joined, NULL while unmatched';
@@ -0,0 +1,33 @@
+-- Escolas e instituições que caronas e pedidos podem ter como destino.
+CREATE TABLE tb_schools (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    address VARCHAR(500) NOT NULL DEFAULT '',
+    -- Perímetro do campus; os pontos de desembarque precisam estar dentro dele ou perto de um portão.
+    campus GEOGRAPHY(Polygon, 4326) NOT NULL,
+    gates GEOGRAPHY(MultiPoint, 4326) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL,
+    -- Horários das aulas em minutos depois da meia-noite, no fuso da escola.
+    class_start_minutes INT[] NOT NULL DEFAULT '{}',
+    class_end_minutes INT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (cardinality(class_start_minutes) = cardinality(class_end_minutes))
+);
+
+CREATE INDEX idx_schools_campus ON tb_schools USING GIST (campus);
+
+-- Escola de destino; NULL quando o destino é um endereço qualquer.
+ALTER TABLE tb_rides ADD COLUMN school_id INT REFERENCES tb_schools (id);
+ALTER TABLE tb_ride_requests ADD COLUMN school_id INT REFERENCES tb_schools (id);
+ALTER TABLE tb_ride_series ADD COLUMN school_id INT REFERENCES tb_schools (id);
+ALTER TABLE tb_ride_request_series ADD COLUMN school_id INT REFERENCES tb_schools (id);
+
+-- A busca por proximidade também traz quem vai para a mesma escola.
+CREATE INDEX idx_rides_school_id ON tb_rides (school_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_ride_requests_school_id ON tb_ride_requests (school_id) WHERE deleted_at IS NULL;
+
+COMMENT ON TABLE tb_schools IS 'Schools and institutions rides and ride requests can target';
+COMMENT ON COLUMN tb_schools.gates IS 'Entry gates, drop-offs within 100 meters of one are accepted';
+COMMENT ON COLUMN tb_rides.school_id IS 'School the ride goes to, NULL for none';
+COMMENT ON COLUMN tb_ride_requests.school_id IS 'School the passenger goes to, NULL for none';
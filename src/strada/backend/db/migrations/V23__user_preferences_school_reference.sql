@@ -0,0 +1,20 @@
+-- A escola das preferências passa a referenciar tb_schools; valores de texto livre que não são o id de uma escola cadastrada são descartados.
+UPDATE tb_user_preferences p
+SET
+    school_id = NULL
+WHERE
+    school_id IS NOT NULL
+    AND NOT EXISTS (
+        SELECT 1
+        FROM tb_schools s
+        WHERE
+            s.id::text = p.school_id
+    );
+
+ALTER TABLE tb_user_preferences
+ALTER COLUMN school_id TYPE INT USING school_id::int;
+
+ALTER TABLE tb_user_preferences
+ADD CONSTRAINT fk_user_preferences_school_id FOREIGN KEY (school_id) REFERENCES tb_schools (id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN tb_user_preferences.school_id IS 'School whose leaderboard the user takes part in, NULL for none';
@@ -0,0 +1,10 @@
+-- A participação no ranking exige que um administrador confirme que o usuário é da escola.
+ALTER TABLE tb_user_preferences ADD COLUMN school_verified_at TIMESTAMP;
+-- Registrado pelo administrador na confirmação; menores só entram no ranking se optarem por isso.
+ALTER TABLE tb_user_preferences ADD COLUMN minor BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tb_user_preferences ADD COLUMN leaderboard_opt_in BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN tb_user_preferences.school_verified_at IS 'When an admin confirmed the user belongs to the school, NULL until then';
+COMMENT ON COLUMN tb_user_preferences.minor IS 'Set by the admin confirming the membership; minors are left off the leaderboard unless they opt in';
+COMMENT ON COLUMN tb_user_preferences.leaderboard_opt_in IS 'Shows a minor on the school leaderboard';
+COMMENT ON COLUMN tb_user_preferences.leaderboard_opt_out IS 'Hides an adult from the school leaderboard';
@@ -0,0 +1,13 @@
+-- A materialização reserva cada ocorrência antes de criar a corrida ou o
+-- pedido, que são criados fora da transação; a reserva sem corrida ou pedido
+-- expira para que outra execução a assuma se a primeira falhar no meio.
+ALTER TABLE tb_ride_occurrences ALTER COLUMN ride_id DROP NOT NULL;
+ALTER TABLE tb_ride_occurrences ADD COLUMN claimed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE tb_ride_request_occurrences ALTER COLUMN ride_request_id DROP NOT NULL;
+ALTER TABLE tb_ride_request_occurrences ADD COLUMN claimed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+COMMENT ON COLUMN tb_ride_occurrences.ride_id IS 'Ride created for the occurrence, NULL while it is being created';
+COMMENT ON COLUMN tb_ride_occurrences.claimed_at IS 'When a materialization reserved the occurrence, in UTC; a reservation without ride is taken over once stale';
+COMMENT ON COLUMN tb_ride_request_occurrences.ride_request_id IS 'Ride request created for the occurrence, NULL while it is being created';
+COMMENT ON COLUMN tb_ride_request_occurrences.claimed_at IS 'When a materialization reserved the occurrence, in UTC; a reservation without ride request is taken over once stale';
@@ -0,0 +1,28 @@
+-- name: CreateChangeRecord :one
+INSERT INTO
+    tb_change_history (
+        entity_type,
+        entity_id,
+        action,
+        changed_by,
+        changes
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING
+    id,
+    created_at;
+
+-- name: FindChangeRecordsByEntity :many
+SELECT
+    id,
+    entity_type,
+    entity_id,
+    action,
+    changed_by,
+    changes,
+    created_at
+FROM tb_change_history
+WHERE
+    entity_type = $1
+    AND entity_id = $2
+ORDER BY created_at, id;
@@ -0,0 +1,70 @@
+-- name: CreatePayment :one
+INSERT INTO
+    tb_payments (
+        ride_id,
+        passenger_id,
+        driver_id,
+        kind,
+        amount,
+        currency,
+        status,
+        parent_id,
+        provider,
+        created_at,
+        updated_at
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        NOW(),
+        NOW()
+    )
+RETURNING *;
+
+-- name: FindPaymentByID :one
+SELECT * FROM tb_payments WHERE id = $1;
+
+-- name: FindPaymentsByRideID :many
+SELECT * FROM tb_payments WHERE ride_id = $1 ORDER BY id;
+
+-- name: FindPaymentsByRideIDs :many
+SELECT * FROM tb_payments WHERE ride_id = ANY ($1::int []) ORDER BY ride_id, id;
+
+-- name: FindPaymentsByParentID :many
+SELECT * FROM tb_payments WHERE parent_id = $1 ORDER BY id;
+
+-- name: FindPaymentByProviderRef :one
+SELECT *
+FROM tb_payments
+WHERE
+    provider = $1
+    AND provider_ref = $2;
+
+-- name: UpdatePayment :one
+UPDATE tb_payments
+SET
+    status = $2,
+    provider_ref = $3,
+    failure_reason = $4,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: CreatePaymentEvent :execrows
+INSERT INTO
+    tb_payment_events (
+        provider,
+        event_id,
+        payment_id,
+        status
+    )
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (provider, event_id) DO NOTHING;
@@ -12,6 +12,13 @@ INSERT INTO
         cost,
         description,
         img_url,
+        currency,
+        cost_ceiling,
+        max_detour_meters,
+        max_detour_ms,
+        co2_saved_kg,
+        completed_at,
+        school_id,
         created_at,
         updated_at
     )
@@ -27,6 +34,13 @@ VALUES (
         $11,
         $12,
         $13,
+        $14,
+        $15,
+        $16,
+        $17,
+        $18,
+        $19,
+        $20,
         NOW(),
         NOW()
     )
@@ -34,13 +48,21 @@ RETURNING
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
@@ -51,38 +73,57 @@ SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
     updated_at
 FROM tb_rides
 WHERE
-    id = $1;
+    id = $1
+    AND deleted_at IS NULL;
 
 -- name: FindAllRides :many
 SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
     updated_at
-FROM tb_rides;
+FROM tb_rides
+WHERE
+    deleted_at IS NULL;
 
 -- name: UpdateRide :one
 UPDATE tb_rides
@@ -95,126 +136,409 @@ SET
     estimated_time_ms = $9,
     co2_emission = $10,
     cost = $11,
+    currency = $15,
+    cost_ceiling = $17,
+    max_detour_meters = $20,
+    max_detour_ms = $21,
+    school_id = $22,
+    co2_saved_kg = $18,
+    completed_at = $19,
     stop_points = $12,
     description = $13,
     img_url = $14,
-    updated_at = NOW()
+    updated_at = NOW(),
+    version = version + 1
 WHERE
     id = $1
+    AND deleted_at IS NULL
+    AND version = $16
 RETURNING
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     description,
     created_at,
     updated_at;
 
--- name: DeleteRide :exec
-DELETE FROM tb_rides WHERE id = $1;
+-- name: DeleteRide :execrows
+UPDATE tb_rides
+SET
+    deleted_at = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL;
 
 -- name: FindNearRides :many
-WITH
-    trajeto AS (
-        SELECT unnest($1::point []) AS ponto
-    )
+-- The route arrives as a single MultiPoint geography, so every ST_DWithin
+-- below is answered by the GiST indexes on start_point, end_point and
+-- stop_points, and the results are ordered nearest first with KNN.
 SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
     cost,
-    ST_AsText (stop_points) AS stop_points,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    stop_points,
     description,
     img_url,
     created_at,
     updated_at
 FROM tb_rides
 WHERE
-    EXISTS (
-        SELECT 1
-        FROM trajeto
-        WHERE (
-                ST_DWithin (
-                    ST_SetSRID (
-                        ST_MakePoint (
-                            trajeto.ponto[0],
-                            trajeto.ponto[1]
-                        ),
-                        4326
-                    )::geography,
-                    start_point::geography,
-                    $2
-                )
-                OR ST_DWithin (
-                    ST_SetSRID (
-                        ST_MakePoint (
-                            trajeto.ponto[0],
-                            trajeto.ponto[1]
-                        ),
-                        4326
-                    )::geography,
-                    end_point::geography,
-                    $2
-                )
-            )
+    deleted_at IS NULL
+    -- Completed rides cannot be joined any more.
+    AND completed_at IS NULL
+    AND (
+        ST_DWithin (
+            start_point,
+            sqlc.arg(route)::geography,
+            sqlc.arg(radius_meters)::float8
+        )
+        OR ST_DWithin (
+            end_point,
+            sqlc.arg(route)::geography,
+            sqlc.arg(radius_meters)::float8
+        )
+        OR ST_DWithin (
+            stop_points,
+            sqlc.arg(route)::geography,
+            sqlc.arg(radius_meters)::float8
+        )
+        -- Everyone going to the same school, wherever they start.
+        OR school_id = sqlc.arg(school_id)::int
+    )
+ORDER BY start_point <-> sqlc.arg(route)::geography, id
+LIMIT sqlc.arg(max_results)::int;
+
+-- name: FindRidePassengersByRideIDs :many
+SELECT
+    rp.ride_id,
+    rp.user_id,
+    rp.start_point,
+    rp.end_point,
+    rp.role,
+    rp.created_at,
+    rp.distance_meters,
+    rp.fare,
+    rp.co2_saved_kg,
+    r.currency
+FROM tb_ride_passengers rp
+    JOIN tb_rides r ON r.id = rp.ride_id
+WHERE
+    rp.ride_id = ANY ($1::int [])
+ORDER BY rp.ride_id, rp.created_at, rp.user_id;
+
+-- name: AddRidePassenger :one
+INSERT INTO
+    tb_ride_passengers (
+        ride_id,
+        user_id,
+        start_point,
+        end_point,
+        role,
+        distance_meters
+    )
+VALUES (
+        $1,
+        $2,
+        ST_SetSRID (ST_MakePoint ($3, $4), 4326),
+        ST_SetSRID (ST_MakePoint ($5, $6), 4326),
+        $7,
+        $8
+    )
+RETURNING
+    created_at;
+
+-- name: RemoveRidePassenger :execrows
+DELETE FROM tb_ride_passengers
+WHERE
+    ride_id = $1
+    AND user_id = $2
+    AND role = 'passenger';
+
+-- name: UpdateRidePassengerFare :exec
+UPDATE tb_ride_passengers
+SET
+    distance_meters = $3,
+    fare = $4
+WHERE
+    ride_id = $1
+    AND user_id = $2;
+
+-- name: UpdateRidePassengerCo2Saved :exec
+UPDATE tb_ride_passengers
+SET
+    co2_saved_kg = $3
+WHERE
+    ride_id = $1
+    AND user_id = $2;
+
+-- name: DeleteRideStops :exec
+DELETE FROM tb_ride_stops WHERE ride_id = $1;
+
+-- name: CreateRideStop :exec
+INSERT INTO
+    tb_ride_stops (
+        ride_id,
+        position,
+        kind,
+        user_id,
+        location,
+        distance_meters,
+        eta_ms
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        ST_SetSRID (ST_MakePoint ($5, $6), 4326),
+        $7,
+        $8
     );
 
+-- name: FindRideStops :many
+SELECT
+    ride_id,
+    position,
+    kind,
+    user_id,
+    location,
+    distance_meters,
+    eta_ms
+FROM tb_ride_stops
+WHERE
+    ride_id = $1
+ORDER BY position;
+
+-- name: FindCompletedRidesByUserIDs :many
+-- Rides completed in the period where any of the users was the driver or a
+-- passenger.
 SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
+    stop_points,
+    cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
-    ST_AsText (stop_points) AS stop_points,
+    description,
+    created_at,
+    updated_at
+FROM tb_rides
+WHERE
+    deleted_at IS NULL
+    AND completed_at IS NOT NULL
+    AND (
+        sqlc.narg(completed_from)::timestamp IS NULL
+        OR completed_at >= sqlc.narg(completed_from)::timestamp
+    )
+    AND (
+        sqlc.narg(completed_to)::timestamp IS NULL
+        OR completed_at < sqlc.narg(completed_to)::timestamp
+    )
+    AND (
+        driver_id = ANY (sqlc.arg(user_ids)::varchar [])
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = ANY (sqlc.arg(user_ids)::varchar [])
+        )
+    )
+ORDER BY completed_at, id;
+
+-- name: FindRidesByUserID :many
+SELECT
+    id,
+    driver_id,
+    vehicle_id,
+    start_point,
+    end_point,
+    distance,
+    estimated_time_ms,
+    co2_emission,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    img_url,
+    description,
     created_at,
-    updated_at,
+    updated_at
+FROM tb_rides
+WHERE
+    deleted_at IS NULL
+    AND (
+        driver_id = $1
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = $1
+        )
+    )
+ORDER BY created_at DESC;
+
+-- name: ListRides :many
+SELECT
+    id,
+    driver_id,
+    vehicle_id,
+    start_point,
+    end_point,
+    distance,
+    estimated_time_ms,
+    co2_emission,
+    stop_points,
+    cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    img_url,
     description,
-    ST_Distance (
-        start_point,
-        ST_SetSRID (ST_MakePoint ($1, $2), 4326)
-    ) AS distance_start,
-    ST_Distance (
-        end_point,
-        ST_SetSRID (ST_MakePoint ($1, $2), 4326)
-    ) AS distance_end
+    created_at,
+    updated_at
 FROM tb_rides
 WHERE
-    ST_DWithin (
-        start_point,
-        ST_SetSRID (ST_MakePoint ($1, $2), 4326),
-        $3
+    deleted_at IS NULL
+    AND (
+        sqlc.narg(driver_id)::varchar IS NULL
+        OR driver_id = sqlc.narg(driver_id)::varchar
     )
-    OR ST_DWithin (
-        end_point,
-        ST_SetSRID (ST_MakePoint ($1, $2), 4326),
-        $3
-    )
-    OR EXISTS (
-        SELECT 1
-        FROM unnest(stop_points) AS sp
-        WHERE
-            ST_DWithin (
-                sp,
-                ST_SetSRID (ST_MakePoint ($1, $2), 4326),
-                $3
+    AND (
+        sqlc.narg(passenger_id)::varchar IS NULL
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = sqlc.narg(passenger_id)::varchar
+                AND role = 'passenger'
+        )
+    )
+    AND (
+        sqlc.narg(created_from)::timestamp IS NULL
+        OR created_at >= sqlc.narg(created_from)::timestamp
+    )
+    AND (
+        sqlc.narg(created_to)::timestamp IS NULL
+        OR created_at < sqlc.narg(created_to)::timestamp
+    )
+    AND (
+        sqlc.narg(min_lon)::float8 IS NULL
+        OR ST_Intersects (
+            start_point::geometry,
+            ST_MakeEnvelope (
+                sqlc.narg(min_lon)::float8,
+                sqlc.narg(min_lat)::float8,
+                sqlc.narg(max_lon)::float8,
+                sqlc.narg(max_lat)::float8,
+                4326
             )
+        )
+        OR ST_Intersects (
+            end_point::geometry,
+            ST_MakeEnvelope (
+                sqlc.narg(min_lon)::float8,
+                sqlc.narg(min_lat)::float8,
+                sqlc.narg(max_lon)::float8,
+                sqlc.narg(max_lat)::float8,
+                4326
+            )
+        )
+    )
+    -- Keyset pagination: rows strictly after the cursor in the chosen order.
+    AND (
+        sqlc.narg(cursor_id)::int IS NULL
+        OR (
+            sqlc.arg(sort_by)::text = 'created_at'
+            AND NOT sqlc.arg(descending)::bool
+            AND (created_at, id) > (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'created_at'
+            AND sqlc.arg(descending)::bool
+            AND (created_at, id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'cost'
+            AND NOT sqlc.arg(descending)::bool
+            AND (COALESCE(cost, 0), id) > (sqlc.narg(cursor_number)::numeric, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'cost'
+            AND sqlc.arg(descending)::bool
+            AND (COALESCE(cost, 0), id) < (sqlc.narg(cursor_number)::numeric, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'distance'
+            AND NOT sqlc.arg(descending)::bool
+            AND (distance, id) > (sqlc.narg(cursor_number)::numeric, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'distance'
+            AND sqlc.arg(descending)::bool
+            AND (distance, id) < (sqlc.narg(cursor_number)::numeric, sqlc.narg(cursor_id)::int)
+        )
     )
-ORDER BY distance_start ASC, distance_end ASC
-LIMIT 10;
\ No newline at end of file
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by)::text = 'created_at' AND NOT sqlc.arg(descending)::bool THEN created_at END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'created_at' AND sqlc.arg(descending)::bool THEN created_at END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'cost' AND NOT sqlc.arg(descending)::bool THEN COALESCE(cost, 0) END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'cost' AND sqlc.arg(descending)::bool THEN COALESCE(cost, 0) END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'distance' AND NOT sqlc.arg(descending)::bool THEN distance END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'distance' AND sqlc.arg(descending)::bool THEN distance END DESC,
+    CASE WHEN NOT sqlc.arg(descending)::bool THEN id END ASC,
+    CASE WHEN sqlc.arg(descending)::bool THEN id END DESC
+LIMIT sqlc.arg(page_size)::int;
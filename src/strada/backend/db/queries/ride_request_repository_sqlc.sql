@@ -2,17 +2,19 @@
 SELECT
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     status,
+    version,
+    school_id,
     img_url,
     description
- FROM tb_ride_requests WHERE id = $1;
+ FROM tb_ride_requests WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: FindRideRequestByPassengerID :many
-SELECT * FROM tb_ride_requests WHERE passenger_id = $1;
+SELECT * FROM tb_ride_requests WHERE passenger_id = $1 AND deleted_at IS NULL;
 
 -- name: CreateRideRequest :one
 INSERT INTO
@@ -22,7 +24,8 @@ INSERT INTO
         destination,
         ride_datetime,
         description,
-        img_url
+        img_url,
+        school_id
     )
 VALUES (
         $1,
@@ -30,58 +33,62 @@ VALUES (
         ST_SetSRID (ST_MakePoint ($4, $5), 4326),
         $6,
         $7,
-        $8
+        $8,
+        $9
     )
 RETURNING
     id,
     passenger_id,
-    ST_AsText (origin) AS origin,
-    ST_AsText (destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     description,
     img_url,
-    status;
+    status,
+    version,
+    school_id;
 
 -- name: UpdateRideRequestStatus :one
-UPDATE tb_ride_requests SET status = $2 WHERE id = $1 RETURNING *;
+UPDATE tb_ride_requests SET status = $2, version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING *;
 
--- name: DeleteRideRequest :one
-DELETE FROM tb_ride_requests WHERE id = $1 RETURNING *;
+-- name: DeleteRideRequest :execrows
+UPDATE tb_ride_requests SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: FindNearRideRequests :many
-WITH trajeto AS (
-    SELECT unnest($1::point[]) as ponto
-)
+-- See FindNearRides: one MultiPoint route, GiST-indexed ST_DWithin on origin
+-- and destination, nearest origin first.
 SELECT
-    rr.id,
-    rr.passenger_id,
-    ST_AsText(rr.origin) AS origin,
-    ST_AsText(rr.destination) AS destination,
-    rr.ride_datetime,
-    rr.drive_offer_id,
-    rr.status,
-    rr.img_url,
+    id,
+    passenger_id,
+    origin,
+    destination,
+    ride_datetime,
+    drive_offer_id,
+    status,
+    version,
+    school_id,
+    img_url,
     description
-FROM tb_ride_requests rr
-WHERE EXISTS (
-    SELECT 1
-    FROM trajeto
-    WHERE
-        (
-            ST_DWithin(
-                ST_SetSRID(ST_MakePoint(trajeto.ponto[0], trajeto.ponto[1]), 4326)::geography,
-                rr.origin::geography,
-                $2
-            )
-        OR
-            ST_DWithin(
-                ST_SetSRID(ST_MakePoint(trajeto.ponto[0], trajeto.ponto[1]), 4326)::geography,
-                rr.destination::geography,
-                $2
-            )
+FROM tb_ride_requests
+WHERE
+    deleted_at IS NULL
+    AND (
+        ST_DWithin (
+            origin,
+            sqlc.arg(route)::geography,
+            sqlc.arg(radius_meters)::float8
         )
-);
+        OR ST_DWithin (
+            destination,
+            sqlc.arg(route)::geography,
+            sqlc.arg(radius_meters)::float8
+        )
+        -- See FindNearRides: everyone going to the same school.
+        OR school_id = sqlc.arg(school_id)::int
+    )
+ORDER BY origin <-> sqlc.arg(route)::geography, id
+LIMIT sqlc.arg(max_results)::int;
 
 -- name: UpdateRideRequest :one
 UPDATE tb_ride_requests
@@ -93,29 +100,122 @@ SET
     drive_offer_id = $8,
     status = $9,
     description = $10,
-    img_url = $11
-WHERE id = $1
+    img_url = $11,
+    school_id = $13,
+    version = version + 1
+WHERE id = $1 AND deleted_at IS NULL AND version = $12
 RETURNING
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     description,
     img_url,
-    status;
+    status,
+    version,
+    school_id;
 
 
 -- name: FindAllRideRequests :many
 SELECT
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     img_url,
     status,
+    version,
+    school_id,
     description
-FROM tb_ride_requests;
\ No newline at end of file
+FROM tb_ride_requests
+WHERE deleted_at IS NULL;
+
+-- name: ListRideRequests :many
+SELECT
+    id,
+    passenger_id,
+    origin,
+    destination,
+    ride_datetime,
+    drive_offer_id,
+    img_url,
+    status,
+    version,
+    school_id,
+    description
+FROM tb_ride_requests
+WHERE
+    deleted_at IS NULL
+    AND (
+        sqlc.narg(passenger_id)::varchar IS NULL
+        OR passenger_id = sqlc.narg(passenger_id)::varchar
+    )
+    AND (
+        sqlc.narg(status)::varchar IS NULL
+        OR status = sqlc.narg(status)::varchar
+    )
+    AND (
+        sqlc.narg(ride_from)::timestamp IS NULL
+        OR ride_datetime >= sqlc.narg(ride_from)::timestamp
+    )
+    AND (
+        sqlc.narg(ride_to)::timestamp IS NULL
+        OR ride_datetime < sqlc.narg(ride_to)::timestamp
+    )
+    AND (
+        sqlc.narg(min_lon)::float8 IS NULL
+        OR ST_Intersects (
+            origin::geometry,
+            ST_MakeEnvelope (
+                sqlc.narg(min_lon)::float8,
+                sqlc.narg(min_lat)::float8,
+                sqlc.narg(max_lon)::float8,
+                sqlc.narg(max_lat)::float8,
+                4326
+            )
+        )
+        OR ST_Intersects (
+            destination::geometry,
+            ST_MakeEnvelope (
+                sqlc.narg(min_lon)::float8,
+                sqlc.narg(min_lat)::float8,
+                sqlc.narg(max_lon)::float8,
+                sqlc.narg(max_lat)::float8,
+                4326
+            )
+        )
+    )
+    -- Keyset pagination: rows strictly after the cursor in the chosen order.
+    AND (
+        sqlc.narg(cursor_id)::int IS NULL
+        OR (
+            sqlc.arg(sort_by)::text = 'ride_datetime'
+            AND NOT sqlc.arg(descending)::bool
+            AND (ride_datetime, id) > (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'ride_datetime'
+            AND sqlc.arg(descending)::bool
+            AND (ride_datetime, id) < (sqlc.narg(cursor_time)::timestamp, sqlc.narg(cursor_id)::int)
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'id'
+            AND NOT sqlc.arg(descending)::bool
+            AND id > sqlc.narg(cursor_id)::int
+        )
+        OR (
+            sqlc.arg(sort_by)::text = 'id'
+            AND sqlc.arg(descending)::bool
+            AND id < sqlc.narg(cursor_id)::int
+        )
+    )
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by)::text = 'ride_datetime' AND NOT sqlc.arg(descending)::bool THEN ride_datetime END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'ride_datetime' AND sqlc.arg(descending)::bool THEN ride_datetime END DESC,
+    CASE WHEN NOT sqlc.arg(descending)::bool THEN id END ASC,
+    CASE WHEN sqlc.arg(descending)::bool THEN id END DESC
+LIMIT sqlc.arg(page_size)::int;
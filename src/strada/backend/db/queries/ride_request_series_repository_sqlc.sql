@@ -0,0 +1,116 @@
+-- name: CreateRideRequestSeries :one
+INSERT INTO
+    tb_ride_request_series (
+        passenger_id,
+        origin,
+        destination,
+        description,
+        img_url,
+        weekdays,
+        departure_minutes,
+        time_zone,
+        start_date,
+        end_date,
+        exceptions,
+        materialized_until,
+        school_id
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13
+    )
+RETURNING
+    *;
+
+-- name: FindRideRequestSeriesByID :one
+SELECT * FROM tb_ride_request_series WHERE id = $1;
+
+-- name: FindDueRideRequestSeries :many
+SELECT *
+FROM tb_ride_request_series
+WHERE
+    materialized_until < $1
+    AND (
+        end_date IS NULL
+        OR end_date >= materialized_until::date
+    )
+ORDER BY materialized_until, id;
+
+-- name: UpdateRideRequestSeriesMaterializedUntil :execrows
+UPDATE tb_ride_request_series
+SET
+    materialized_until = $2,
+    updated_at = NOW()
+WHERE
+    id = $1;
+
+-- name: UpdateRideRequestSeriesRideSeries :execrows
+UPDATE tb_ride_request_series
+SET
+    ride_series_id = $2,
+    updated_at = NOW()
+WHERE
+    id = $1;
+
+-- name: ClaimRideRequestOccurrence :execrows
+INSERT INTO
+    tb_ride_request_occurrences (series_id, departs_at, claimed_at)
+VALUES (
+        sqlc.arg(series_id),
+        sqlc.arg(departs_at),
+        sqlc.arg(claimed_at)
+    )
+ON CONFLICT (series_id, departs_at) DO
+UPDATE
+SET
+    claimed_at = EXCLUDED.claimed_at
+WHERE
+    tb_ride_request_occurrences.ride_request_id IS NULL
+    AND tb_ride_request_occurrences.claimed_at < sqlc.arg(stale_before);
+
+-- name: UpdateRideRequestOccurrenceRideRequest :execrows
+UPDATE tb_ride_request_occurrences
+SET
+    ride_request_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2;
+
+-- name: DeleteRideRequestOccurrenceClaim :exec
+DELETE FROM tb_ride_request_occurrences
+WHERE
+    series_id = $1
+    AND departs_at = $2
+    AND ride_request_id IS NULL;
+
+-- name: UpdateRideRequestOccurrenceRide :execrows
+UPDATE tb_ride_request_occurrences
+SET
+    ride_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2;
+
+-- name: FindRideRequestOccurrences :many
+SELECT
+    series_id,
+    departs_at,
+    ride_request_id,
+    ride_id
+FROM tb_ride_request_occurrences
+WHERE
+    series_id = $1
+    AND departs_at >= $2
+    AND ride_request_id IS NOT NULL
+ORDER BY departs_at;
@@ -0,0 +1,108 @@
+-- name: CreateRideSeries :one
+INSERT INTO
+    tb_ride_series (
+        driver_id,
+        vehicle_id,
+        start_point,
+        end_point,
+        stop_points,
+        description,
+        img_url,
+        currency,
+        cost_ceiling,
+        max_detour_meters,
+        max_detour_ms,
+        weekdays,
+        departure_minutes,
+        time_zone,
+        start_date,
+        end_date,
+        exceptions,
+        materialized_until,
+        school_id
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13,
+        $14,
+        $15,
+        $16,
+        $17,
+        $18,
+        $19
+    )
+RETURNING
+    *;
+
+-- name: FindRideSeriesByID :one
+SELECT * FROM tb_ride_series WHERE id = $1;
+
+-- name: FindDueRideSeries :many
+SELECT *
+FROM tb_ride_series
+WHERE
+    materialized_until < $1
+    AND (
+        end_date IS NULL
+        OR end_date >= materialized_until::date
+    )
+ORDER BY materialized_until, id;
+
+-- name: UpdateRideSeriesMaterializedUntil :execrows
+UPDATE tb_ride_series
+SET
+    materialized_until = $2,
+    updated_at = NOW()
+WHERE
+    id = $1;
+
+-- name: ClaimRideOccurrence :execrows
+INSERT INTO
+    tb_ride_occurrences (series_id, departs_at, claimed_at)
+VALUES (
+        sqlc.arg(series_id),
+        sqlc.arg(departs_at),
+        sqlc.arg(claimed_at)
+    )
+ON CONFLICT (series_id, departs_at) DO
+UPDATE
+SET
+    claimed_at = EXCLUDED.claimed_at
+WHERE
+    tb_ride_occurrences.ride_id IS NULL
+    AND tb_ride_occurrences.claimed_at < sqlc.arg(stale_before);
+
+-- name: UpdateRideOccurrenceRide :execrows
+UPDATE tb_ride_occurrences
+SET
+    ride_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2;
+
+-- name: DeleteRideOccurrenceClaim :exec
+DELETE FROM tb_ride_occurrences
+WHERE
+    series_id = $1
+    AND departs_at = $2
+    AND ride_id IS NULL;
+
+-- name: FindRideOccurrences :many
+SELECT series_id, departs_at, ride_id
+FROM tb_ride_occurrences
+WHERE
+    series_id = $1
+    AND departs_at >= $2
+    AND ride_id IS NOT NULL
+ORDER BY departs_at;
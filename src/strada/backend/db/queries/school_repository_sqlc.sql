@@ -0,0 +1,41 @@
+-- name: CreateSchool :one
+INSERT INTO
+    tb_schools (
+        name,
+        address,
+        campus,
+        gates,
+        time_zone,
+        class_start_minutes,
+        class_end_minutes
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+    *;
+
+-- name: FindSchoolByID :one
+SELECT * FROM tb_schools WHERE id = $1;
+
+-- name: FindSchools :many
+SELECT *
+FROM tb_schools
+WHERE
+    sqlc.narg(name)::varchar IS NULL
+    OR name ILIKE '%' || sqlc.narg(name)::varchar || '%'
+ORDER BY name, id;
+
+-- name: UpdateSchool :one
+UPDATE tb_schools
+SET
+    name = $2,
+    address = $3,
+    campus = $4,
+    gates = $5,
+    time_zone = $6,
+    class_start_minutes = $7,
+    class_end_minutes = $8,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING
+    *;
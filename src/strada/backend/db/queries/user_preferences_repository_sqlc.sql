@@ -0,0 +1,56 @@
+-- name: FindUserPreferences :one
+SELECT
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in
+FROM tb_user_preferences
+WHERE
+    user_id = $1;
+
+-- name: SaveUserPreferences :one
+INSERT INTO
+    tb_user_preferences (
+        user_id,
+        school_id,
+        leaderboard_opt_out,
+        school_verified_at,
+        minor,
+        leaderboard_opt_in,
+        updated_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (user_id) DO
+UPDATE
+SET
+    school_id = EXCLUDED.school_id,
+    leaderboard_opt_out = EXCLUDED.leaderboard_opt_out,
+    school_verified_at = EXCLUDED.school_verified_at,
+    minor = EXCLUDED.minor,
+    leaderboard_opt_in = EXCLUDED.leaderboard_opt_in,
+    updated_at = NOW()
+RETURNING
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in;
+
+-- name: FindUserPreferencesBySchool :many
+SELECT
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in
+FROM tb_user_preferences
+WHERE
+    school_id = $1
+ORDER BY user_id;
@@ -0,0 +1,13 @@
+-- name: FindVehicleByID :one
+SELECT
+    id,
+    driver_id,
+    make,
+    model,
+    year,
+    license_plate,
+    fuel_type,
+    fuel_consumption
+FROM tb_vehicles
+WHERE
+    id = $1;
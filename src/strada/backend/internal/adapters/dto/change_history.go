@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type FieldChangeDto struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type ChangeRecordDto struct {
+	ID         int64                     `json:"id"`
+	EntityType string                    `json:"entityType"`
+	EntityID   int32                     `json:"entityId"`
+	Action     string                    `json:"action"`
+	ChangedBy  string                    `json:"changedBy"`
+	Changes    map[string]FieldChangeDto `json:"changes"`
+	CreatedAt  time.Time                 `json:"createdAt"`
+}
+
+func ToChangeRecordDto(r *models.ChangeRecord) *ChangeRecordDto {
+	changes := make(map[string]FieldChangeDto, len(r.Changes))
+	for field, change := range r.Changes {
+		changes[field] = FieldChangeDto{From: change.From, To: change.To}
+	}
+
+	return &ChangeRecordDto{
+		ID:         r.ID,
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		Action:     r.Action,
+		ChangedBy:  r.ChangedBy,
+		Changes:    changes,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func ToChangeRecordDtoList(records []*models.ChangeRecord) []*ChangeRecordDto {
+	dtos := make([]*ChangeRecordDto, len(records))
+	for i := range records {
+		dtos[i] = ToChangeRecordDto(records[i])
+	}
+	return dtos
+}
@@ -0,0 +1,33 @@
+package dto
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+type PassengerEmissionDto struct {
+	UserID         string  `json:"userId"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	Co2SavedKg     float64 `json:"co2SavedKg"`
+}
+
+type RideEmissionDto struct {
+	RideID        int32                   `json:"rideId"`
+	Co2EmissionKg float64                 `json:"co2EmissionKg"`
+	Co2SavedKg    float64                 `json:"co2SavedKg"`
+	Passengers    []*PassengerEmissionDto `json:"passengers"`
+}
+
+func ToRideEmissionDto(emission *models.RideEmission) *RideEmissionDto {
+	passengers := make([]*PassengerEmissionDto, len(emission.Passengers))
+	for i, passenger := range emission.Passengers {
+		passengers[i] = &PassengerEmissionDto{
+			UserID:         passenger.UserID,
+			DistanceMeters: passenger.DistanceMeters,
+			Co2SavedKg:     passenger.SavedKg,
+		}
+	}
+	return &RideEmissionDto{
+		RideID:        emission.RideID,
+		Co2EmissionKg: emission.EmissionKg,
+		Co2SavedKg:    emission.SavedKg,
+		Passengers:    passengers,
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+type JoinRideDto struct {
+	UserID     string      `json:"userId"`
+	StartPoint LocationDto `json:"startPoint"`
+	EndPoint   LocationDto `json:"endPoint"`
+}
+
+func (j *JoinRideDto) ToModel() *models.RidePassenger {
+	return &models.RidePassenger{
+		UserID:     j.UserID,
+		StartPoint: *j.StartPoint.ToModel(),
+		EndPoint:   *j.EndPoint.ToModel(),
+	}
+}
+
+type PassengerFareDto struct {
+	UserID         string   `json:"userId"`
+	DistanceMeters float64  `json:"distanceMeters"`
+	Fare           MoneyDto `json:"fare"`
+}
+
+type FareSplitDto struct {
+	RideID  int32               `json:"rideId"`
+	Total   MoneyDto            `json:"total"`
+	Ceiling *MoneyDto           `json:"ceiling,omitempty"`
+	Capped  bool                `json:"capped"`
+	Shares  []*PassengerFareDto `json:"shares"`
+}
+
+func ToFareSplitDto(split *models.FareSplit) *FareSplitDto {
+	shares := make([]*PassengerFareDto, len(split.Shares))
+	for i, share := range split.Shares {
+		shares[i] = &PassengerFareDto{
+			UserID:         share.UserID,
+			DistanceMeters: share.DistanceMeters,
+			Fare:           *ToMoneyDto(share.Fare),
+		}
+	}
+	return &FareSplitDto{
+		RideID:  split.RideID,
+		Total:   *ToMoneyDto(split.Total),
+		Ceiling: toCostCeilingDto(split.Ceiling),
+		Capped:  split.Capped,
+		Shares:  shares,
+	}
+}
@@ -0,0 +1,37 @@
+package dto
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+type RideStopDto struct {
+	Kind           string      `json:"kind"`
+	UserID         string      `json:"userId,omitempty"`
+	Location       LocationDto `json:"location"`
+	DistanceMeters float64     `json:"distanceMeters"`
+	EtaMs          int64       `json:"etaMs"`
+}
+
+type ItineraryDto struct {
+	RideID         int32          `json:"rideId"`
+	DistanceMeters float64        `json:"distanceMeters"`
+	DurationMs     int64          `json:"durationMs"`
+	Stops          []*RideStopDto `json:"stops"`
+}
+
+func ToItineraryDto(itinerary *models.Itinerary) *ItineraryDto {
+	stops := make([]*RideStopDto, len(itinerary.Stops))
+	for i, stop := range itinerary.Stops {
+		stops[i] = &RideStopDto{
+			Kind:           stop.Kind,
+			UserID:         stop.UserID,
+			Location:       *ToLocationDto(&stop.Location),
+			DistanceMeters: stop.DistanceMeters,
+			EtaMs:          stop.EtaMs,
+		}
+	}
+	return &ItineraryDto{
+		RideID:         itinerary.RideID,
+		DistanceMeters: itinerary.DistanceMeters,
+		DurationMs:     itinerary.DurationMs,
+		Stops:          stops,
+	}
+}
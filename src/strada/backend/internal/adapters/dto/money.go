@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// MoneyDto carries the amount as a decimal string ("15.50") so clients never
+// round-trip money through floats. Numbers are accepted on input too.
+type MoneyDto struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m *MoneyDto) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var raw struct {
+		Amount   json.Number `json:"amount"`
+		Currency string      `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	money, err := models.ParseMoney(raw.Amount.String(), raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = *ToMoneyDto(money)
+	return nil
+}
+
+func (m *MoneyDto) ToModel() models.Money {
+	money, err := models.ParseMoney(m.Amount, m.Currency)
+	if err != nil {
+		// Only a zero-valued dto gets here; bound dtos were validated on decode.
+		return models.NewMoney(0, m.Currency)
+	}
+	return money
+}
+
+func ToMoneyDto(m models.Money) *MoneyDto {
+	return &MoneyDto{
+		Amount:   m.String(),
+		Currency: m.Currency,
+	}
+}
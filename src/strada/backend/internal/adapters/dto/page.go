@@ -0,0 +1,6 @@
+package dto
+
+type PageDto[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
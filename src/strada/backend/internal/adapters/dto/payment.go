@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type ChargeDto struct {
+	PassengerID string `json:"passengerId"`
+}
+
+type RefundDto struct {
+	// Amount defaults to all that is left of the charge.
+	Amount *MoneyDto `json:"amount"`
+}
+
+func (r *RefundDto) ToModel() models.Money {
+	if r.Amount == nil {
+		return models.Money{}
+	}
+	return r.Amount.ToModel()
+}
+
+type PaymentDto struct {
+	ID            int32     `json:"id"`
+	RideID        int32     `json:"rideId"`
+	PassengerID   string    `json:"passengerId"`
+	DriverID      string    `json:"driverId"`
+	Kind          string    `json:"kind"`
+	Amount        MoneyDto  `json:"amount"`
+	Status        string    `json:"status"`
+	ParentID      int32     `json:"parentId,omitempty"`
+	Provider      string    `json:"provider"`
+	ProviderRef   string    `json:"providerRef,omitempty"`
+	FailureReason string    `json:"failureReason,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func ToPaymentDto(payment *models.Payment) *PaymentDto {
+	return &PaymentDto{
+		ID:            payment.ID,
+		RideID:        payment.RideID,
+		PassengerID:   payment.PassengerID,
+		DriverID:      payment.DriverID,
+		Kind:          payment.Kind,
+		Amount:        *ToMoneyDto(payment.Amount),
+		Status:        payment.Status,
+		ParentID:      payment.ParentID,
+		Provider:      payment.Provider,
+		ProviderRef:   payment.ProviderRef,
+		FailureReason: payment.FailureReason,
+		CreatedAt:     payment.CreatedAt,
+		UpdatedAt:     payment.UpdatedAt,
+	}
+}
+
+func ToPaymentDtos(payments []*models.Payment) []*PaymentDto {
+	dtos := make([]*PaymentDto, len(payments))
+	for i, payment := range payments {
+		dtos[i] = ToPaymentDto(payment)
+	}
+	return dtos
+}
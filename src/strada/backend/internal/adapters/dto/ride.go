@@ -12,33 +12,53 @@ type RideDto struct {
 	VehicleID          int32         `json:"vehicleId"`
 	StartPoint         LocationDto   `json:"startPoint"`
 	EndPoint           LocationDto   `json:"endPoint"`
-	Distance           int32         `json:"distance"`
+	DistanceMeters     float64       `json:"distanceMeters"`
 	EstimatedTimeMs    int32         `json:"estimatedTimeMs"`
-	Co2Emission        int32         `json:"co2Emission"`
-	Cost               int32         `json:"cost"`
+	Co2EmissionKg      float64       `json:"co2EmissionKg"`
+	Co2SavedKg         float64       `json:"co2SavedKg"`
+	Cost               MoneyDto      `json:"cost"`
+	CostCeiling        *MoneyDto     `json:"costCeiling,omitempty"`
+	MaxDetourMeters    float64       `json:"maxDetourMeters"`
+	MaxDetourMs        int64         `json:"maxDetourMs"`
+	SchoolID           int32         `json:"schoolId,omitempty"`
+	DetourUnchecked    bool          `json:"detourUnchecked,omitempty"`
 	SustainableRouteID int32         `json:"sustainableRouteId"`
 	StopPoints         []LocationDto `json:"stopPoints"`
 	Description        string        `json:"description"`
 	CreatedAt          time.Time     `json:"createdAt"`
 	UpdatedAt          time.Time     `json:"updatedAt"`
+	CompletedAt        *time.Time    `json:"completedAt,omitempty"`
 	ImgUrl             string        `json:"imgUrl"`
+	Version            int32         `json:"version"`
+	Driver             *UserDto      `json:"driver,omitempty"`
+	Passengers         []*UserDto    `json:"passengers,omitempty"`
 }
 
 func (r *RideDto) ToModel() *models.Ride {
+	var costCeiling models.Money
+	if r.CostCeiling != nil {
+		costCeiling = r.CostCeiling.ToModel()
+	}
 	return &models.Ride{
 		ID:              r.ID,
 		DriverID:        r.DriverID,
+		VehicleID:       r.VehicleID,
 		StartPoint:      *r.StartPoint.ToModel(),
 		EndPoint:        *r.EndPoint.ToModel(),
-		Distance:        r.Distance,
+		DistanceMeters:  r.DistanceMeters,
 		EstimatedTimeMs: r.EstimatedTimeMs,
-		Co2Emission:     r.Co2Emission,
-		Cost:            r.Cost,
+		Co2EmissionKg:   r.Co2EmissionKg,
+		Cost:            r.Cost.ToModel(),
+		CostCeiling:     costCeiling,
+		MaxDetourMeters: r.MaxDetourMeters,
+		MaxDetourMs:     r.MaxDetourMs,
+		SchoolID:        r.SchoolID,
 		StopPoints:      ToModelLocationDtoList(r.StopPoints),
 		Description:     r.Description,
 		CreatedAt:       r.CreatedAt,
 		UpdatedAt:       r.UpdatedAt,
 		ImgUrl:          r.ImgUrl,
+		Version:         r.Version,
 	}
 }
 
@@ -46,18 +66,41 @@ func ToRideDto(r *models.Ride) *RideDto {
 	return &RideDto{
 		ID:              r.ID,
 		DriverID:        r.DriverID,
+		VehicleID:       r.VehicleID,
 		StartPoint:      *ToLocationDto(&r.StartPoint),
 		EndPoint:        *ToLocationDto(&r.EndPoint),
-		Distance:        r.Distance,
+		DistanceMeters:  r.DistanceMeters,
 		EstimatedTimeMs: r.EstimatedTimeMs,
-		Co2Emission:     r.Co2Emission,
+		Co2EmissionKg:   r.Co2EmissionKg,
+		Co2SavedKg:      r.Co2SavedKg,
 		Description:     r.Description,
-		Cost:            r.Cost,
+		Cost:            *ToMoneyDto(r.Cost),
+		CostCeiling:     toCostCeilingDto(r.CostCeiling),
+		MaxDetourMeters: r.MaxDetourMeters,
+		MaxDetourMs:     r.MaxDetourMs,
+		SchoolID:        r.SchoolID,
+		DetourUnchecked: r.DetourUnchecked,
 		ImgUrl:          r.ImgUrl,
 		CreatedAt:       r.CreatedAt,
 		StopPoints:      ToLocationDtoList(r.StopPoints),
 		UpdatedAt:       r.UpdatedAt,
+		CompletedAt:     optionalTime(r.CompletedAt),
+		Version:         r.Version,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func toCostCeilingDto(ceiling models.Money) *MoneyDto {
+	if ceiling.IsZero() {
+		return nil
 	}
+	return ToMoneyDto(ceiling)
 }
 
 func ToRideDtoList(rides []*models.Ride) []*RideDto {
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	ridepb "github.com/244Walyson/shared-ride/internal/adapters/in/rpc/proto"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToLocationProto(l *models.Location) *ridepb.LocationDto {
+	return &ridepb.LocationDto{
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+	}
+}
+
+func ToMoneyProto(m models.Money) *ridepb.MoneyDto {
+	return &ridepb.MoneyDto{
+		Amount:   m.String(),
+		Currency: m.Currency,
+	}
+}
+
+func ToRideProto(r *models.Ride) *ridepb.RideResponseDto {
+	stopPoints := make([]*ridepb.LocationDto, len(r.StopPoints))
+	for i := range r.StopPoints {
+		stopPoints[i] = ToLocationProto(&r.StopPoints[i])
+	}
+
+	return &ridepb.RideResponseDto{
+		Id:              r.ID,
+		DriverId:        r.DriverID,
+		VehicleId:       r.VehicleID,
+		StartPoint:      ToLocationProto(&r.StartPoint),
+		EndPoint:        ToLocationProto(&r.EndPoint),
+		StopPoints:      stopPoints,
+		DistanceMeters:  r.DistanceMeters,
+		EstimatedTimeMs: r.EstimatedTimeMs,
+		Co2EmissionKg:   r.Co2EmissionKg,
+		Cost:            ToMoneyProto(r.Cost),
+		Description:     r.Description,
+		ImgUrl:          r.ImgUrl,
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		UpdatedAt:       timestamppb.New(r.UpdatedAt),
+	}
+}
+
+func ToRideProtoList(rides []*models.Ride) []*ridepb.RideResponseDto {
+	dtos := make([]*ridepb.RideResponseDto, len(rides))
+	for i := range rides {
+		dtos[i] = ToRideProto(rides[i])
+	}
+	return dtos
+}
+
+func ToRideRequestProto(r *models.RideRequest) *ridepb.RideRequestResponseDto {
+	return &ridepb.RideRequestResponseDto{
+		Id:           r.ID,
+		PassengerId:  r.PassengerID,
+		Origin:       ToLocationProto(&r.Origin),
+		Destination:  ToLocationProto(&r.Destination),
+		Description:  r.Description,
+		RideDatetime: timestamppb.New(r.RideDatetime),
+		ImgUrl:       r.ImgUrl,
+		Status:       r.Status,
+		SchoolId:     r.SchoolID,
+	}
+}
+
+func ToRideRequestProtoList(rideRequests []*models.RideRequest) []*ridepb.RideRequestResponseDto {
+	dtos := make([]*ridepb.RideRequestResponseDto, len(rideRequests))
+	for i := range rideRequests {
+		dtos[i] = ToRideRequestProto(rideRequests[i])
+	}
+	return dtos
+}
+
+func ToParticipantProto(p *models.RidePassenger) *ridepb.ParticipantDto {
+	return &ridepb.ParticipantDto{
+		UserId:     p.UserID,
+		Role:       p.Role,
+		StartPoint: ToLocationProto(&p.StartPoint),
+		EndPoint:   ToLocationProto(&p.EndPoint),
+	}
+}
+
+func ToRideEventProto(e *models.RideEvent) *ridepb.RideEventDto {
+	return &ridepb.RideEventDto{
+		Type:          e.Type,
+		RideId:        e.RideID,
+		RideRequestId: e.RideRequestID,
+		UserId:        e.UserID,
+		OccurredAt:    timestamppb.New(e.OccurredAt),
+	}
+}
@@ -15,6 +15,27 @@ type RideRequestDto struct {
 	Status       string      `json:"status"`
 	Description  string      `json:"description"`
 	ImgUrl       string      `json:"imgUrl"`
+	Version      int32       `json:"version"`
+	SchoolID     int32       `json:"schoolId,omitempty"`
+	Passenger    *UserDto    `json:"passenger,omitempty"`
+	Detour       *DetourDto  `json:"detour,omitempty"`
+}
+
+type DetourDto struct {
+	DistanceMeters float64 `json:"distanceMeters"`
+	DurationMs     int64   `json:"durationMs"`
+	WithinBudget   bool    `json:"withinBudget"`
+}
+
+func toDetourDto(detour *models.Detour) *DetourDto {
+	if detour == nil {
+		return nil
+	}
+	return &DetourDto{
+		DistanceMeters: detour.DistanceMeters,
+		DurationMs:     detour.DurationMs,
+		WithinBudget:   detour.WithinBudget,
+	}
 }
 
 func (r *RideRequestDto) ToModel() *models.RideRequest {
@@ -27,6 +48,8 @@ func (r *RideRequestDto) ToModel() *models.RideRequest {
 		Status:       r.Status,
 		Description:  r.Description,
 		ImgUrl:       r.ImgUrl,
+		Version:      r.Version,
+		SchoolID:     r.SchoolID,
 	}
 }
 
@@ -40,13 +63,16 @@ func ToRideRequestDto(r *models.RideRequest) *RideRequestDto {
 		Status:       r.Status,
 		Description:  r.Description,
 		ImgUrl:       r.ImgUrl,
+		Version:      r.Version,
+		SchoolID:     r.SchoolID,
+		Detour:       toDetourDto(r.Detour),
 	}
 }
 
-func ToRideRequestDtoList(models []*models.RideRequest) []RideRequestDto {
-	dtos := make([]RideRequestDto, len(models))
+func ToRideRequestDtoList(models []*models.RideRequest) []*RideRequestDto {
+	dtos := make([]*RideRequestDto, len(models))
 	for i, model := range models {
-		dtos[i] = *ToRideRequestDto(model)
+		dtos[i] = ToRideRequestDto(model)
 	}
 	return dtos
 }
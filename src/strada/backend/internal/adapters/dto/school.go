@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// SchoolDto lists the campus polygon as its corners in order; closing it is
+// optional. Class times are written as "HH:MM" in the school time zone.
+type SchoolDto struct {
+	ID         int32          `json:"id"`
+	Name       string         `json:"name"`
+	Address    string         `json:"address"`
+	Campus     []LocationDto  `json:"campus"`
+	Gates      []LocationDto  `json:"gates"`
+	TimeZone   string         `json:"timeZone"`
+	ClassTimes []ClassTimeDto `json:"classTimes"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+type ClassTimeDto struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func (s *SchoolDto) ToModel() (*models.School, error) {
+	school := &models.School{
+		Name:       s.Name,
+		Address:    s.Address,
+		Campus:     ToModelLocationDtoList(s.Campus),
+		Gates:      ToModelLocationDtoList(s.Gates),
+		TimeZone:   s.TimeZone,
+		ClassTimes: make([]models.ClassTime, len(s.ClassTimes)),
+	}
+	for i, class := range s.ClassTimes {
+		start, err := parseClock(class.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(class.End)
+		if err != nil {
+			return nil, err
+		}
+		school.ClassTimes[i] = models.ClassTime{StartMinutes: start, EndMinutes: end}
+	}
+	return school, nil
+}
+
+func ToSchoolDto(school *models.School) *SchoolDto {
+	s := &SchoolDto{
+		ID:         school.ID,
+		Name:       school.Name,
+		Address:    school.Address,
+		Campus:     ToLocationDtoList(school.Campus),
+		Gates:      ToLocationDtoList(school.Gates),
+		TimeZone:   school.TimeZone,
+		ClassTimes: make([]ClassTimeDto, len(school.ClassTimes)),
+		CreatedAt:  school.CreatedAt,
+		UpdatedAt:  school.UpdatedAt,
+	}
+	for i, class := range school.ClassTimes {
+		s.ClassTimes[i] = ClassTimeDto{Start: formatClock(class.StartMinutes), End: formatClock(class.EndMinutes)}
+	}
+	return s
+}
+
+func ToSchoolDtoList(schools []*models.School) []*SchoolDto {
+	dtos := make([]*SchoolDto, len(schools))
+	for i, school := range schools {
+		dtos[i] = ToSchoolDto(school)
+	}
+	return dtos
+}
+
+// parseClock reads "HH:MM" as minutes since midnight. "24:00" is accepted so
+// that classes can run until the end of the day.
+func parseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, models.ErrInvalidSchool
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
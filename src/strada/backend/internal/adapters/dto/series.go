@@ -0,0 +1,228 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// RecurrenceDto writes the departure time as "HH:MM" in the time zone and the
+// dates as "YYYY-MM-DD". Weekdays go from 0 for Sunday to 6 for Saturday.
+type RecurrenceDto struct {
+	Weekdays      []int    `json:"weekdays"`
+	DepartureTime string   `json:"departureTime"`
+	TimeZone      string   `json:"timeZone"`
+	StartDate     string   `json:"startDate"`
+	EndDate       string   `json:"endDate,omitempty"`
+	Exceptions    []string `json:"exceptions"`
+}
+
+func (r *RecurrenceDto) ToModel() (models.Recurrence, error) {
+	recurrence := models.Recurrence{
+		Weekdays: make([]time.Weekday, len(r.Weekdays)),
+		TimeZone: r.TimeZone,
+	}
+	for i, weekday := range r.Weekdays {
+		recurrence.Weekdays[i] = time.Weekday(weekday)
+	}
+	departure, err := time.Parse("15:04", r.DepartureTime)
+	if err != nil {
+		return recurrence, models.ErrInvalidRecurrence
+	}
+	recurrence.DepartureMinutes = departure.Hour()*60 + departure.Minute()
+	if recurrence.StartDate, err = time.Parse(models.DateLayout, r.StartDate); err != nil {
+		return recurrence, models.ErrInvalidRecurrence
+	}
+	if r.EndDate != "" {
+		if recurrence.EndDate, err = time.Parse(models.DateLayout, r.EndDate); err != nil {
+			return recurrence, models.ErrInvalidRecurrence
+		}
+	}
+	for _, exception := range r.Exceptions {
+		date, err := time.Parse(models.DateLayout, exception)
+		if err != nil {
+			return recurrence, models.ErrInvalidRecurrence
+		}
+		recurrence.Exceptions = append(recurrence.Exceptions, date)
+	}
+	return recurrence, nil
+}
+
+func ToRecurrenceDto(recurrence models.Recurrence) RecurrenceDto {
+	r := RecurrenceDto{
+		Weekdays:      make([]int, len(recurrence.Weekdays)),
+		DepartureTime: fmt.Sprintf("%02d:%02d", recurrence.DepartureMinutes/60, recurrence.DepartureMinutes%60),
+		TimeZone:      recurrence.TimeZone,
+		StartDate:     recurrence.StartDate.Format(models.DateLayout),
+		Exceptions:    make([]string, len(recurrence.Exceptions)),
+	}
+	for i, weekday := range recurrence.Weekdays {
+		r.Weekdays[i] = int(weekday)
+	}
+	if !recurrence.EndDate.IsZero() {
+		r.EndDate = recurrence.EndDate.Format(models.DateLayout)
+	}
+	for i, exception := range recurrence.Exceptions {
+		r.Exceptions[i] = exception.Format(models.DateLayout)
+	}
+	return r
+}
+
+type OccurrenceDto struct {
+	DepartsAt     time.Time `json:"departsAt"`
+	RideID        int32     `json:"rideId,omitempty"`
+	RideRequestID int32     `json:"rideRequestId,omitempty"`
+}
+
+func ToOccurrenceDtos(occurrences []*models.Occurrence) []*OccurrenceDto {
+	dtos := make([]*OccurrenceDto, len(occurrences))
+	for i, occurrence := range occurrences {
+		dtos[i] = &OccurrenceDto{
+			DepartsAt:     occurrence.DepartsAt,
+			RideID:        occurrence.RideID,
+			RideRequestID: occurrence.RideRequestID,
+		}
+	}
+	return dtos
+}
+
+type RideSeriesDto struct {
+	ID              int32            `json:"id"`
+	DriverID        string           `json:"driverId"`
+	VehicleID       int32            `json:"vehicleId"`
+	StartPoint      LocationDto      `json:"startPoint"`
+	EndPoint        LocationDto      `json:"endPoint"`
+	StopPoints      []LocationDto    `json:"stopPoints"`
+	Description     string           `json:"description"`
+	ImgUrl          string           `json:"imgUrl"`
+	CostCeiling     *MoneyDto        `json:"costCeiling,omitempty"`
+	MaxDetourMeters float64          `json:"maxDetourMeters"`
+	MaxDetourMs     int64            `json:"maxDetourMs"`
+	SchoolID        int32            `json:"schoolId,omitempty"`
+	Recurrence      RecurrenceDto    `json:"recurrence"`
+	Upcoming        []*OccurrenceDto `json:"upcoming"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
+
+func (r *RideSeriesDto) ToModel() (*models.RideSeries, error) {
+	recurrence, err := r.Recurrence.ToModel()
+	if err != nil {
+		return nil, err
+	}
+	var costCeiling models.Money
+	if r.CostCeiling != nil {
+		costCeiling = r.CostCeiling.ToModel()
+	}
+	currency := models.DefaultCurrency
+	if !costCeiling.IsZero() {
+		currency = costCeiling.Currency
+	}
+	return &models.RideSeries{
+		DriverID: r.DriverID,
+		Ride: &models.Ride{
+			DriverID:        r.DriverID,
+			VehicleID:       r.VehicleID,
+			StartPoint:      *r.StartPoint.ToModel(),
+			EndPoint:        *r.EndPoint.ToModel(),
+			StopPoints:      ToModelLocationDtoList(r.StopPoints),
+			Description:     r.Description,
+			ImgUrl:          r.ImgUrl,
+			Cost:            models.NewMoney(0, currency),
+			CostCeiling:     costCeiling,
+			MaxDetourMeters: r.MaxDetourMeters,
+			MaxDetourMs:     r.MaxDetourMs,
+			SchoolID:        r.SchoolID,
+		},
+		Recurrence: recurrence,
+	}, nil
+}
+
+func ToRideSeriesDto(series *models.RideSeries) *RideSeriesDto {
+	return &RideSeriesDto{
+		ID:              series.ID,
+		DriverID:        series.DriverID,
+		VehicleID:       series.Ride.VehicleID,
+		StartPoint:      *ToLocationDto(&series.Ride.StartPoint),
+		EndPoint:        *ToLocationDto(&series.Ride.EndPoint),
+		StopPoints:      ToLocationDtoList(series.Ride.StopPoints),
+		Description:     series.Ride.Description,
+		ImgUrl:          series.Ride.ImgUrl,
+		CostCeiling:     toCostCeilingDto(series.Ride.CostCeiling),
+		MaxDetourMeters: series.Ride.MaxDetourMeters,
+		MaxDetourMs:     series.Ride.MaxDetourMs,
+		SchoolID:        series.Ride.SchoolID,
+		Recurrence:      ToRecurrenceDto(series.Recurrence),
+		Upcoming:        ToOccurrenceDtos(series.Upcoming),
+		CreatedAt:       series.CreatedAt,
+	}
+}
+
+type RideRequestSeriesDto struct {
+	ID           int32            `json:"id"`
+	PassengerID  string           `json:"passengerId"`
+	Origin       LocationDto      `json:"origin"`
+	Destination  LocationDto      `json:"destination"`
+	Description  string           `json:"description"`
+	ImgUrl       string           `json:"imgUrl"`
+	SchoolID     int32            `json:"schoolId,omitempty"`
+	RideSeriesID int32            `json:"rideSeriesId,omitempty"`
+	Recurrence   RecurrenceDto    `json:"recurrence"`
+	Upcoming     []*OccurrenceDto `json:"upcoming"`
+	CreatedAt    time.Time        `json:"createdAt"`
+}
+
+func (r *RideRequestSeriesDto) ToModel() (*models.RideRequestSeries, error) {
+	recurrence, err := r.Recurrence.ToModel()
+	if err != nil {
+		return nil, err
+	}
+	return &models.RideRequestSeries{
+		PassengerID: r.PassengerID,
+		RideRequest: &models.RideRequest{
+			PassengerID: r.PassengerID,
+			Origin:      *r.Origin.ToModel(),
+			Destination: *r.Destination.ToModel(),
+			Description: r.Description,
+			ImgUrl:      r.ImgUrl,
+			SchoolID:    r.SchoolID,
+		},
+		Recurrence: recurrence,
+	}, nil
+}
+
+func ToRideRequestSeriesDto(series *models.RideRequestSeries) *RideRequestSeriesDto {
+	return &RideRequestSeriesDto{
+		ID:           series.ID,
+		PassengerID:  series.PassengerID,
+		Origin:       *ToLocationDto(&series.RideRequest.Origin),
+		Destination:  *ToLocationDto(&series.RideRequest.Destination),
+		Description:  series.RideRequest.Description,
+		ImgUrl:       series.RideRequest.ImgUrl,
+		SchoolID:     series.RideRequest.SchoolID,
+		RideSeriesID: series.RideSeriesID,
+		Recurrence:   ToRecurrenceDto(series.Recurrence),
+		Upcoming:     ToOccurrenceDtos(series.Upcoming),
+		CreatedAt:    series.CreatedAt,
+	}
+}
+
+type MatchSeriesDto struct {
+	RideSeriesID int32 `json:"rideSeriesId"`
+}
+
+type SeriesMatchDto struct {
+	RideSeriesID        int32            `json:"rideSeriesId"`
+	RideRequestSeriesID int32            `json:"rideRequestSeriesId"`
+	Paired              []*OccurrenceDto `json:"paired"`
+	Unpaired            []*OccurrenceDto `json:"unpaired"`
+}
+
+func ToSeriesMatchDto(match *models.SeriesMatch) *SeriesMatchDto {
+	return &SeriesMatchDto{
+		RideSeriesID:        match.RideSeriesID,
+		RideRequestSeriesID: match.RideRequestSeriesID,
+		Paired:              ToOccurrenceDtos(match.Paired),
+		Unpaired:            ToOccurrenceDtos(match.Unpaired),
+	}
+}
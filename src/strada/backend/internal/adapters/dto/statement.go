@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type StatementAmountsDto struct {
+	Fares       MoneyDto `json:"fares"`
+	Paid        MoneyDto `json:"paid"`
+	Refunded    MoneyDto `json:"refunded"`
+	Contributed MoneyDto `json:"contributed"`
+	PaidOut     MoneyDto `json:"paidOut"`
+	Outstanding MoneyDto `json:"outstanding"`
+}
+
+type StatementLineDto struct {
+	PassengerID    string  `json:"passengerId"`
+	DistanceMeters float64 `json:"distanceMeters"`
+	StatementAmountsDto
+}
+
+type StatementRideDto struct {
+	RideID      int32               `json:"rideId"`
+	CompletedAt time.Time           `json:"completedAt"`
+	Passengers  []*StatementLineDto `json:"passengers"`
+	Totals      StatementAmountsDto `json:"totals"`
+}
+
+type StatementDto struct {
+	DriverID string                 `json:"driverId"`
+	From     time.Time              `json:"from"`
+	To       time.Time              `json:"to"`
+	Rides    []*StatementRideDto    `json:"rides"`
+	Totals   []*StatementAmountsDto `json:"totals"`
+}
+
+func ToStatementDto(statement *models.Statement) *StatementDto {
+	rides := make([]*StatementRideDto, len(statement.Rides))
+	for i, ride := range statement.Rides {
+		passengers := make([]*StatementLineDto, len(ride.Passengers))
+		for j, line := range ride.Passengers {
+			passengers[j] = &StatementLineDto{
+				PassengerID:         line.PassengerID,
+				DistanceMeters:      line.DistanceMeters,
+				StatementAmountsDto: toStatementAmountsDto(line.StatementAmounts),
+			}
+		}
+		rides[i] = &StatementRideDto{
+			RideID:      ride.RideID,
+			CompletedAt: ride.CompletedAt,
+			Passengers:  passengers,
+			Totals:      toStatementAmountsDto(ride.Totals),
+		}
+	}
+	totals := make([]*StatementAmountsDto, len(statement.Totals))
+	for i, amounts := range statement.Totals {
+		dto := toStatementAmountsDto(*amounts)
+		totals[i] = &dto
+	}
+	return &StatementDto{
+		DriverID: statement.DriverID,
+		From:     statement.From,
+		To:       statement.To,
+		Rides:    rides,
+		Totals:   totals,
+	}
+}
+
+func toStatementAmountsDto(amounts models.StatementAmounts) StatementAmountsDto {
+	return StatementAmountsDto{
+		Fares:       *ToMoneyDto(amounts.Fares),
+		Paid:        *ToMoneyDto(amounts.Paid),
+		Refunded:    *ToMoneyDto(amounts.Refunded),
+		Contributed: *ToMoneyDto(amounts.Contributed),
+		PaidOut:     *ToMoneyDto(amounts.PaidOut),
+		Outstanding: *ToMoneyDto(amounts.Outstanding),
+	}
+}
+
+var statementCsvHeader = []string{
+	"row", "ride_id", "completed_at", "passenger_id", "distance_meters", "currency",
+	"fares", "paid", "refunded", "contributed", "paid_out", "outstanding",
+}
+
+// WriteStatementCsv writes one "passenger" row per passenger, a "ride" row
+// after the passengers of each ride and a "total" row per currency.
+func WriteStatementCsv(w io.Writer, statement *models.Statement) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(statementCsvHeader); err != nil {
+		return err
+	}
+	for _, ride := range statement.Rides {
+		rideId := strconv.Itoa(int(ride.RideID))
+		completedAt := ride.CompletedAt.UTC().Format(time.RFC3339)
+		for _, line := range ride.Passengers {
+			distance := strconv.FormatFloat(line.DistanceMeters, 'f', -1, 64)
+			if err := writer.Write(statementCsvRow("passenger", rideId, completedAt, line.PassengerID, distance, line.StatementAmounts)); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write(statementCsvRow("ride", rideId, completedAt, "", "", ride.Totals)); err != nil {
+			return err
+		}
+	}
+	for _, totals := range statement.Totals {
+		if err := writer.Write(statementCsvRow("total", "", "", "", "", *totals)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func statementCsvRow(row string, rideId string, completedAt string, passengerId string, distance string, amounts models.StatementAmounts) []string {
+	return []string{
+		row, rideId, completedAt, passengerId, distance, amounts.Fares.Currency,
+		amounts.Fares.String(), amounts.Paid.String(), amounts.Refunded.String(),
+		amounts.Contributed.String(), amounts.PaidOut.String(), amounts.Outstanding.String(),
+	}
+}
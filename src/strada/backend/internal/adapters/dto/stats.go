@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type UserStatsDto struct {
+	UserID               string     `json:"userId"`
+	Period               string     `json:"period"`
+	From                 *time.Time `json:"from,omitempty"`
+	To                   time.Time  `json:"to"`
+	TripsTaken           int        `json:"tripsTaken"`
+	TripsOffered         int        `json:"tripsOffered"`
+	DistanceSharedMeters float64    `json:"distanceSharedMeters"`
+	Co2SavedKg           float64    `json:"co2SavedKg"`
+	MoneySaved           MoneyDto   `json:"moneySaved"`
+}
+
+func ToUserStatsDto(stats *models.UserStats) *UserStatsDto {
+	return &UserStatsDto{
+		UserID:               stats.UserID,
+		Period:               stats.Period,
+		From:                 optionalTime(stats.From),
+		To:                   stats.To,
+		TripsTaken:           stats.TripsTaken,
+		TripsOffered:         stats.TripsOffered,
+		DistanceSharedMeters: stats.DistanceSharedMeters,
+		Co2SavedKg:           stats.Co2SavedKg,
+		MoneySaved:           *ToMoneyDto(stats.MoneySaved),
+	}
+}
+
+type LeaderboardEntryDto struct {
+	Rank int `json:"rank"`
+	*UserStatsDto
+	User *UserDto `json:"user,omitempty"`
+}
+
+type LeaderboardDto struct {
+	SchoolID int32                  `json:"schoolId"`
+	Period   string                 `json:"period"`
+	Entries  []*LeaderboardEntryDto `json:"entries"`
+}
+
+func ToLeaderboardDto(leaderboard *models.Leaderboard) *LeaderboardDto {
+	entries := make([]*LeaderboardEntryDto, len(leaderboard.Entries))
+	for i, entry := range leaderboard.Entries {
+		entries[i] = &LeaderboardEntryDto{
+			Rank:         entry.Rank,
+			UserStatsDto: ToUserStatsDto(entry.Stats),
+		}
+	}
+	return &LeaderboardDto{
+		SchoolID: leaderboard.SchoolID,
+		Period:   leaderboard.Period,
+		Entries:  entries,
+	}
+}
+
+type UserPreferencesDto struct {
+	SchoolID int32 `json:"schoolId,omitempty"`
+	// SchoolVerified and Minor are set by admins and ignored on input.
+	SchoolVerified    bool      `json:"schoolVerified"`
+	Minor             bool      `json:"minor"`
+	LeaderboardOptOut bool      `json:"leaderboardOptOut"`
+	LeaderboardOptIn  bool      `json:"leaderboardOptIn"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+func (p *UserPreferencesDto) ToModel() *models.UserPreferences {
+	return &models.UserPreferences{
+		SchoolID:          p.SchoolID,
+		LeaderboardOptOut: p.LeaderboardOptOut,
+		LeaderboardOptIn:  p.LeaderboardOptIn,
+	}
+}
+
+func ToUserPreferencesDto(preferences *models.UserPreferences) *UserPreferencesDto {
+	return &UserPreferencesDto{
+		SchoolID:          preferences.SchoolID,
+		SchoolVerified:    preferences.SchoolVerified(),
+		Minor:             preferences.Minor,
+		LeaderboardOptOut: preferences.LeaderboardOptOut,
+		LeaderboardOptIn:  preferences.LeaderboardOptIn,
+		UpdatedAt:         preferences.UpdatedAt,
+	}
+}
+
+type SchoolMemberDto struct {
+	Minor bool `json:"minor"`
+}
@@ -42,3 +42,13 @@ func ToModelFromUserResponseDto(dto *proto.FindUserResponseDto) *models.User {
 		ImgUrl:   dto.ImgUrl,
 	}
 }
+
+func ToModelFromGuardianRelationResponseDto(dto *proto.GuardianRelationResponseDto) *models.GuardianRelation {
+	return &models.GuardianRelation{
+		GuardianID:      dto.GuardianId,
+		MinorID:         dto.MinorId,
+		Relationship:    dto.Relationship,
+		CanRequestRides: dto.CanRequestRides,
+		CanAcceptRides:  dto.CanAcceptRides,
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// ToRestErr maps domain errors returned by the services to the matching HTTP
+// error. Unknown errors keep the historical bad request response.
+func ToRestErr(err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrRideNotFound), errors.Is(err, models.ErrRideRequestNotFound),
+		errors.Is(err, models.ErrPassengerNotFound), errors.Is(err, models.ErrPaymentNotFound), errors.Is(err, models.ErrUnknownPaymentProvider),
+		errors.Is(err, models.ErrSeriesNotFound), errors.Is(err, models.ErrSchoolNotFound):
+		return rest_err.NewNotFoundError(err.Error())
+	case errors.Is(err, models.ErrUnauthenticated), errors.Is(err, models.ErrInvalidSignature):
+		return rest_err.NewUnauthorizedRequestError(err.Error())
+	case errors.Is(err, models.ErrForbidden):
+		return rest_err.NewForbiddenError(err.Error())
+	case errors.Is(err, models.ErrVersionConflict), errors.Is(err, models.ErrAlreadyJoined),
+		errors.Is(err, models.ErrRideCompleted), errors.Is(err, models.ErrRideNotCompleted), errors.Is(err, models.ErrPaymentExists),
+		errors.Is(err, models.ErrNotRefundable):
+		return rest_err.NewConflictError(err.Error())
+	case errors.Is(err, models.ErrUserServiceUnavailable), errors.Is(err, models.ErrPaymentProviderFailed),
+		errors.Is(err, models.ErrRoutingUnavailable):
+		return rest_err.NewServiceUnavailableError(err.Error())
+	default:
+		return rest_err.NewBadRequestError(err.Error())
+	}
+}
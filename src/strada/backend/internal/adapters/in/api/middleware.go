@@ -6,6 +6,7 @@ import (
 
 	"github.com/244Walyson/shared-ride/configs/logger"
 	"github.com/244Walyson/shared-ride/configs/rest_err"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	"github.com/244Walyson/shared-ride/internal/application/ports/in"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -41,7 +42,7 @@ func (a *AuthMiddleware) AuthMiddlewareHandler() gin.HandlerFunc {
 			return
 		}
 
-		user, err := a.service.VerifyToken(ctx, parts[1])
+		principal, err := a.service.VerifyToken(ctx, parts[1])
 		if err != nil {
 			logger.Error("Invalid token", err)
 			c.JSON(401, rest_err.NewUnauthorizedRequestError("Invalid credentials"))
@@ -49,12 +50,98 @@ func (a *AuthMiddleware) AuthMiddlewareHandler() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user", user)
+		if principal.IsService() {
+			logger.Error("Service token used on user endpoint", nil, zap.String("clientId", principal.ClientID))
+			c.JSON(401, rest_err.NewUnauthorizedRequestError("Invalid credentials"))
+			c.Abort()
+			return
+		}
+
+		c.Set("user", principal)
+		c.Request = c.Request.WithContext(models.ContextWithPrincipal(ctx, principal))
 
 		c.Next()
 	}
 }
 
+type ServiceAuthMiddleware struct {
+	service in.ServiceAuthService
+}
+
+func NewServiceAuthMiddleware(s in.ServiceAuthService) *ServiceAuthMiddleware {
+	return &ServiceAuthMiddleware{
+		service: s,
+	}
+}
+
+// ServiceAuthMiddlewareHandler authenticates internal callers with either a
+// client-credentials bearer token or an X-Api-Key header, requires every one
+// of the given scopes and writes each call to the audit log.
+func (a *ServiceAuthMiddleware) ServiceAuthMiddlewareHandler(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := c.Request.Context()
+
+		var principal *models.Principal
+		var err error
+		var method string
+
+		if apiKey := c.GetHeader("X-Api-Key"); apiKey != "" {
+			method = "api_key"
+			principal, err = a.service.VerifyApiKey(ctx, apiKey)
+		} else if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			method = "client_credentials"
+			principal, err = a.service.VerifyToken(ctx, token)
+		} else {
+			method = "none"
+			err = models.ErrUnauthenticated
+		}
+
+		if err != nil {
+			logger.Audit("service request rejected",
+				zap.String("auth_method", method),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("remote_addr", c.ClientIP()),
+				zap.NamedError("error", err),
+			)
+			c.JSON(401, rest_err.NewUnauthorizedRequestError("Invalid service credentials"))
+			c.Abort()
+			return
+		}
+
+		for _, scope := range scopes {
+			if !principal.HasScope(scope) {
+				logger.Audit("service request denied",
+					zap.String("client_id", principal.ClientID),
+					zap.String("auth_method", method),
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path),
+					zap.String("missing_scope", scope),
+				)
+				c.JSON(403, rest_err.NewForbiddenError("missing scope "+scope))
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("service", principal)
+		c.Request = c.Request.WithContext(models.ContextWithPrincipal(ctx, principal))
+
+		c.Next()
+
+		logger.Audit("service request",
+			zap.String("client_id", principal.ClientID),
+			zap.Strings("scopes", principal.Scopes),
+			zap.String("auth_method", method),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("duration", time.Since(start)),
+		)
+	}
+}
+
 func LoggingMiddlewareHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
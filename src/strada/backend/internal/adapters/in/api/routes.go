@@ -1,9 +1,38 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
 
 type Route interface {
 	GetPath() string
 	GetMethod() string
 	GetHandler() gin.HandlerFunc
 }
+
+// InternalRoute is a route reserved for service-to-service calls. It is
+// served under the service authentication middleware with the scopes it
+// declares.
+type InternalRoute interface {
+	Route
+	GetScopes() []string
+}
+
+func Register(router gin.IRoutes, route Route, middlewares ...gin.HandlerFunc) {
+	handlers := append(append([]gin.HandlerFunc{}, middlewares...), route.GetHandler())
+
+	switch route.GetMethod() {
+	case "GET":
+		router.GET(route.GetPath(), handlers...)
+	case "POST":
+		router.POST(route.GetPath(), handlers...)
+	case "PUT":
+		router.PUT(route.GetPath(), handlers...)
+	case "DELETE":
+		router.DELETE(route.GetPath(), handlers...)
+	default:
+		fmt.Printf("Unsupported method: %s for path: %s\n", route.GetMethod(), route.GetPath())
+	}
+}
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type ChargeRidePassenger struct {
+	path    string
+	method  string
+	service in.PaymentService
+}
+
+func NewChargeRidePassenger(s in.PaymentService) api.Route {
+	return &ChargeRidePassenger{
+		path:    "/ride/:rideId/payments",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *ChargeRidePassenger) GetPath() string {
+	return c.path
+}
+
+func (c *ChargeRidePassenger) GetMethod() string {
+	return c.method
+}
+
+func (c *ChargeRidePassenger) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		// The body is optional; without it the caller pays their own fare.
+		var chargeDto dto.ChargeDto
+		if err := cc.ShouldBindJSON(&chargeDto); err != nil && !errors.Is(err, io.EOF) {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		charge, err := c.service.Charge(ctx, int32(rideId), chargeDto.PassengerID)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(201, dto.ToPaymentDto(charge))
+	}
+}
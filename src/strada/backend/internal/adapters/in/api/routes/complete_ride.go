@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type CompleteRide struct {
+	path    string
+	method  string
+	service in.RideService
+}
+
+func NewCompleteRide(s in.RideService) api.Route {
+	return &CompleteRide{
+		path:    "/ride/:rideId/complete",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *CompleteRide) GetPath() string {
+	return c.path
+}
+
+func (c *CompleteRide) GetMethod() string {
+	return c.method
+}
+
+func (c *CompleteRide) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		ride, err := c.service.Complete(ctx, int32(rideId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		setETag(cc, ride.Version)
+		cc.JSON(200, dto.ToRideDto(ride))
+	}
+}
@@ -51,7 +51,8 @@ func (c *CreateRide) GetHandler() gin.HandlerFunc {
 
 		created, err := c.service.Create(ctx, ride)
 		if err != nil {
-			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
 			return
 		}
 		cc.JSON(201, dto.ToRideDto(created))
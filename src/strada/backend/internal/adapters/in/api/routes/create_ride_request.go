@@ -43,7 +43,8 @@ func (c *CreateRideRequest) GetHandler() gin.HandlerFunc {
 		ride := rideRequestDto.ToModel()
 		created, err := c.service.Create(ctx, ride)
 		if err != nil {
-			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
 			return
 		}
 		cc.JSON(201, dto.ToRideRequestDto(created))
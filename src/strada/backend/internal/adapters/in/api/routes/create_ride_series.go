@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateRideSeries struct {
+	path    string
+	method  string
+	service in.SeriesService
+}
+
+func NewCreateRideSeries(s in.SeriesService) api.Route {
+	return &CreateRideSeries{
+		path:    "/ride-series",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *CreateRideSeries) GetPath() string {
+	return c.path
+}
+
+func (c *CreateRideSeries) GetMethod() string {
+	return c.method
+}
+
+func (c *CreateRideSeries) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		var seriesDto dto.RideSeriesDto
+		if err := cc.BindJSON(&seriesDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+		series, err := seriesDto.ToModel()
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		created, err := c.service.CreateRideSeries(ctx, series)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(201, dto.ToRideSeriesDto(created))
+	}
+}
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type CreateSchool struct {
+	path    string
+	method  string
+	service in.SchoolService
+}
+
+func NewCreateSchool(s in.SchoolService) api.Route {
+	return &CreateSchool{
+		path:    "/schools",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *CreateSchool) GetPath() string {
+	return c.path
+}
+
+func (c *CreateSchool) GetMethod() string {
+	return c.method
+}
+
+func (c *CreateSchool) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		var schoolDto dto.SchoolDto
+		if err := cc.BindJSON(&schoolDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+		school, err := schoolDto.ToModel()
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		created, err := c.service.Create(ctx, school)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(201, dto.ToSchoolDto(created))
+	}
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/gin-gonic/gin"
+)
+
+func setETag(cc *gin.Context, version int32) {
+	cc.Header("ETag", fmt.Sprintf("%q", strconv.Itoa(int(version))))
+}
+
+// updateVersion returns the version a PUT applies on: the ETag issued by
+// setETag in its If-Match header, or else the version in its body. Updates
+// must name one, so that concurrent writes are not silently lost; only
+// "If-Match: *" opts into overwriting the current version and returns 0.
+func updateVersion(cc *gin.Context, bodyVersion int32) (int32, *rest_err.RestErr) {
+	header := strings.TrimSpace(cc.GetHeader("If-Match"))
+	switch header {
+	case "":
+		if bodyVersion == 0 {
+			return 0, rest_err.NewPreconditionRequiredError(`If-Match header required, send the ETag you read or "*" to overwrite`)
+		}
+		return bodyVersion, nil
+	case "*":
+		return 0, nil
+	}
+	tag, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return 0, rest_err.NewBadRequestError("Invalid If-Match header")
+	}
+	version, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil || version < 1 {
+		return 0, rest_err.NewBadRequestError("Invalid If-Match header")
+	}
+	return int32(version), nil
+}
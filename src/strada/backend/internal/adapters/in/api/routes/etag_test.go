@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestUpdateVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		bodyVersion int32
+		want        int32
+		code        int
+	}{
+		{"ETag", `"3"`, 0, 3, 0},
+		{"weak ETag", `W/"3"`, 0, 3, 0},
+		{"ETag over body", `"3"`, 2, 3, 0},
+		{"body", "", 2, 2, 0},
+		{"wildcard overwrites", "*", 0, 0, 0},
+		{"wildcard over body", "*", 2, 0, 0},
+		{"missing", "", 0, 0, http.StatusPreconditionRequired},
+		{"unquoted", "3", 0, 0, http.StatusBadRequest},
+		{"not a number", `"abc"`, 0, 0, http.StatusBadRequest},
+		{"zero", `"0"`, 0, 0, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc, _ := gin.CreateTestContext(httptest.NewRecorder())
+			cc.Request = httptest.NewRequest(http.MethodPut, "/ride/1", nil)
+			if tt.ifMatch != "" {
+				cc.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, restErr := updateVersion(cc, tt.bodyVersion)
+			code := 0
+			if restErr != nil {
+				code = restErr.Code
+			}
+			if got != tt.want || code != tt.code {
+				t.Errorf("updateVersion = %d, %d, want %d, %d", got, code, tt.want, tt.code)
+			}
+		})
+	}
+}
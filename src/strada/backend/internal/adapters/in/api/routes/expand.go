@@ -0,0 +1,136 @@
+package routes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+const passengerRole = "passenger"
+
+type expandOptions struct {
+	driver     bool
+	passengers bool
+}
+
+func (o expandOptions) any() bool {
+	return o.driver || o.passengers
+}
+
+// parseExpand reads the opt-in ?expand=driver,passengers query parameter.
+func parseExpand(cc *gin.Context) expandOptions {
+	var opts expandOptions
+	for _, field := range strings.Split(cc.Query("expand"), ",") {
+		switch strings.TrimSpace(field) {
+		case "driver":
+			opts.driver = true
+		case "passengers", "passenger":
+			opts.passengers = true
+		}
+	}
+	return opts
+}
+
+// expandRides embeds the driver and passenger profiles in the rides, fetching
+// every user involved with a single batch call to the user service.
+func expandRides(ctx context.Context, rideService in.RideService, userService in.UserService, rides []*dto.RideDto, opts expandOptions) error {
+	if !opts.any() || len(rides) == 0 {
+		return nil
+	}
+
+	var ids []string
+	passengersByRide := map[int32][]string{}
+
+	if opts.driver {
+		for _, ride := range rides {
+			ids = append(ids, ride.DriverID)
+		}
+	}
+
+	if opts.passengers {
+		rideIds := make([]int32, len(rides))
+		for i, ride := range rides {
+			rideIds[i] = ride.ID
+		}
+		passengers, err := rideService.FindPassengers(ctx, rideIds)
+		if err != nil {
+			return err
+		}
+		for _, passenger := range passengers {
+			if passenger.Role != passengerRole {
+				continue
+			}
+			passengersByRide[passenger.RideID] = append(passengersByRide[passenger.RideID], passenger.UserID)
+			ids = append(ids, passenger.UserID)
+		}
+	}
+
+	users, err := findUsers(ctx, userService, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, ride := range rides {
+		if opts.driver {
+			ride.Driver = users[ride.DriverID]
+		}
+		if opts.passengers {
+			ride.Passengers = []*dto.UserDto{}
+			for _, id := range passengersByRide[ride.ID] {
+				if user, ok := users[id]; ok {
+					ride.Passengers = append(ride.Passengers, user)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// expandRideRequests embeds the passenger profile in each ride request.
+// Ride requests have no driver, so only the passengers option applies.
+func expandRideRequests(ctx context.Context, userService in.UserService, rideRequests []*dto.RideRequestDto, opts expandOptions) error {
+	if !opts.passengers || len(rideRequests) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(rideRequests))
+	for i, rideRequest := range rideRequests {
+		ids[i] = rideRequest.PassengerID
+	}
+
+	users, err := findUsers(ctx, userService, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rideRequest := range rideRequests {
+		rideRequest.Passenger = users[rideRequest.PassengerID]
+	}
+	return nil
+}
+
+func findUsers(ctx context.Context, userService in.UserService, ids []string) (map[string]*dto.UserDto, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	users, err := userService.FindByIds(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[string]*dto.UserDto, len(users))
+	for _, user := range users {
+		byId[user.ID] = dto.ToUserDto(user)
+	}
+	return byId, nil
+}
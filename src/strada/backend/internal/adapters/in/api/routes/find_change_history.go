@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindChangeHistory struct {
+	path    string
+	method  string
+	service in.ChangeHistoryService
+}
+
+func NewFindChangeHistory(s in.ChangeHistoryService) api.Route {
+	return &FindChangeHistory{
+		path:    "/admin/history/:entityType/:entityId",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindChangeHistory) GetPath() string {
+	return c.path
+}
+
+func (c *FindChangeHistory) GetMethod() string {
+	return c.method
+}
+
+func (c *FindChangeHistory) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		entityId, err := strconv.Atoi(cc.Param("entityId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid entityId"))
+			return
+		}
+
+		records, err := c.service.FindByEntity(ctx, cc.Param("entityType"), int32(entityId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToChangeRecordDtoList(records))
+	}
+}
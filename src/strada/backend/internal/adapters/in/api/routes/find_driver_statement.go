@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindDriverStatement struct {
+	path    string
+	method  string
+	service in.StatementService
+}
+
+func NewFindDriverStatement(s in.StatementService) api.Route {
+	return &FindDriverStatement{
+		path:    "/me/statement",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindDriverStatement) GetPath() string {
+	return c.path
+}
+
+func (c *FindDriverStatement) GetMethod() string {
+	return c.method
+}
+
+func (c *FindDriverStatement) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		from, err := parseTimeQuery(cc, "from")
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			return
+		}
+		to, err := parseTimeQuery(cc, "to")
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			return
+		}
+		format := cc.DefaultQuery("format", "json")
+		if format != "json" && format != "csv" {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid format, expected json or csv"))
+			return
+		}
+
+		statement, err := c.service.DriverStatement(ctx, cc.Query("driverId"), from, to)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		if format == "json" {
+			cc.JSON(200, dto.ToStatementDto(statement))
+			return
+		}
+		filename := fmt.Sprintf("statement-%s-%s.csv", statement.From.UTC().Format("20060102"), statement.To.UTC().Format("20060102"))
+		cc.Header("Content-Type", "text/csv; charset=utf-8")
+		cc.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		cc.Status(200)
+		if err := dto.WriteStatementCsv(cc.Writer, statement); err != nil {
+			cc.Error(err)
+		}
+	}
+}
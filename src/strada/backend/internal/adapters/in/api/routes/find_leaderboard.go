@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindLeaderboard struct {
+	path        string
+	method      string
+	service     in.StatsService
+	userService in.UserService
+}
+
+func NewFindLeaderboard(s in.StatsService, u in.UserService) api.Route {
+	return &FindLeaderboard{
+		path:        "/me/leaderboard",
+		method:      "GET",
+		service:     s,
+		userService: u,
+	}
+}
+
+func (c *FindLeaderboard) GetPath() string {
+	return c.path
+}
+
+func (c *FindLeaderboard) GetMethod() string {
+	return c.method
+}
+
+func (c *FindLeaderboard) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		var limit int
+		if value := cc.Query("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+				cc.JSON(400, rest_err.NewBadRequestError("Invalid limit"))
+				return
+			}
+		}
+
+		leaderboard, err := c.service.Leaderboard(ctx, cc.Query("period"), limit)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		leaderboardDto := dto.ToLeaderboardDto(leaderboard)
+		ids := make([]string, len(leaderboardDto.Entries))
+		for i, entry := range leaderboardDto.Entries {
+			ids[i] = entry.UserID
+		}
+		users, err := findUsers(ctx, c.userService, ids)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		for _, entry := range leaderboardDto.Entries {
+			entry.User = users[entry.UserID]
+		}
+		cc.JSON(200, leaderboardDto)
+	}
+}
@@ -11,16 +11,18 @@ import (
 )
 
 type FindNearRide struct {
-	path    string
-	method  string
-	service in.RideService
+	path        string
+	method      string
+	service     in.RideService
+	userService in.UserService
 }
 
-func NewFindNearRide(s in.RideService) api.Route {
+func NewFindNearRide(s in.RideService, u in.UserService) api.Route {
 	return &FindNearRide{
-		path:    "/ride/near/:rideRequestId",
-		method:  "GET",
-		service: s,
+		path:        "/ride/near/:rideRequestId",
+		method:      "GET",
+		service:     s,
+		userService: u,
 	}
 }
 
@@ -48,6 +50,12 @@ func (c *FindNearRide) GetHandler() gin.HandlerFunc {
 			cc.JSON(400, gin.H{"error": err.Error()})
 			return
 		}
-		cc.JSON(200, dto.ToRideDtoList(rides))
+		rideDtos := dto.ToRideDtoList(rides)
+		if err := expandRides(ctx, c.service, c.userService, rideDtos, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, rideDtos)
 	}
 }
@@ -12,16 +12,18 @@ import (
 )
 
 type FindNearRideRequest struct {
-	path    string
-	method  string
-	service in.RideRequestService
+	path        string
+	method      string
+	service     in.RideRequestService
+	userService in.UserService
 }
 
-func NewFindNearRideRequest(s in.RideRequestService) api.Route {
+func NewFindNearRideRequest(s in.RideRequestService, u in.UserService) api.Route {
 	return &FindNearRideRequest{
-		path:    "/ride-request/near/:rideId",
-		method:  "GET",
-		service: s,
+		path:        "/ride-request/near/:rideId",
+		method:      "GET",
+		service:     s,
+		userService: u,
 	}
 }
 
@@ -50,7 +52,13 @@ func (c *FindNearRideRequest) GetHandler() gin.HandlerFunc {
 			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
 			return
 		}
-		cc.JSON(200, dto.ToRideRequestDtoList(ridesRequests))
+		rideRequestDtos := dto.ToRideRequestDtoList(ridesRequests)
+		if err := expandRideRequests(ctx, c.userService, rideRequestDtos, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, rideRequestDtos)
 
 	}
 }
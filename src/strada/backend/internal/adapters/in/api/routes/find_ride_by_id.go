@@ -11,16 +11,18 @@ import (
 )
 
 type FindRideById struct {
-	path    string
-	method  string
-	service in.RideService
+	path        string
+	method      string
+	service     in.RideService
+	userService in.UserService
 }
 
-func NewFindRideById(s in.RideService) api.Route {
+func NewFindRideById(s in.RideService, u in.UserService) api.Route {
 	return &FindRideById{
-		path:    "/ride/:rideId",
-		method:  "GET",
-		service: s,
+		path:        "/ride/:rideId",
+		method:      "GET",
+		service:     s,
+		userService: u,
 	}
 }
 
@@ -45,10 +47,18 @@ func (c *FindRideById) GetHandler() gin.HandlerFunc {
 
 		ride, err := c.service.FindById(ctx, int32(rideId))
 		if err != nil {
-			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
 			return
 		}
-		cc.JSON(200, dto.ToRideDto(ride))
+		rideDto := dto.ToRideDto(ride)
+		if err := expandRides(ctx, c.service, c.userService, []*dto.RideDto{rideDto}, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		setETag(cc, ride.Version)
+		cc.JSON(200, rideDto)
 
 	}
 }
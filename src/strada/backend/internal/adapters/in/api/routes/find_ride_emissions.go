@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindRideEmissions struct {
+	path    string
+	method  string
+	service in.RideService
+}
+
+func NewFindRideEmissions(s in.RideService) api.Route {
+	return &FindRideEmissions{
+		path:    "/ride/:rideId/emissions",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindRideEmissions) GetPath() string {
+	return c.path
+}
+
+func (c *FindRideEmissions) GetMethod() string {
+	return c.method
+}
+
+func (c *FindRideEmissions) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		emission, err := c.service.Emissions(ctx, int32(rideId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToRideEmissionDto(emission))
+	}
+}
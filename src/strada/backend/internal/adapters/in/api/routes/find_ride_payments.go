@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindRidePayments struct {
+	path    string
+	method  string
+	service in.PaymentService
+}
+
+func NewFindRidePayments(s in.PaymentService) api.Route {
+	return &FindRidePayments{
+		path:    "/ride/:rideId/payments",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindRidePayments) GetPath() string {
+	return c.path
+}
+
+func (c *FindRidePayments) GetMethod() string {
+	return c.method
+}
+
+func (c *FindRidePayments) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		payments, err := c.service.FindByRide(ctx, int32(rideId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToPaymentDtos(payments))
+	}
+}
@@ -11,16 +11,18 @@ import (
 )
 
 type FindRideRequestById struct {
-	path    string
-	method  string
-	service in.RideRequestService
+	path        string
+	method      string
+	service     in.RideRequestService
+	userService in.UserService
 }
 
-func NewFindRideRequestById(s in.RideRequestService) api.Route {
+func NewFindRideRequestById(s in.RideRequestService, u in.UserService) api.Route {
 	return &FindRideRequestById{
-		path:    "/ride-request/:riderequestId",
-		method:  "GET",
-		service: s,
+		path:        "/ride-request/:riderequestId",
+		method:      "GET",
+		service:     s,
+		userService: u,
 	}
 }
 
@@ -44,10 +46,18 @@ func (c *FindRideRequestById) GetHandler() gin.HandlerFunc {
 		}
 		rideRequest, err := c.service.FindById(ctx, int32(rideId))
 		if err != nil {
-			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
 			return
 		}
-		cc.JSON(200, dto.ToRideRequestDto(rideRequest))
+		rideRequestDto := dto.ToRideRequestDto(rideRequest)
+		if err := expandRideRequests(ctx, c.userService, []*dto.RideRequestDto{rideRequestDto}, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		setETag(cc, rideRequest.Version)
+		cc.JSON(200, rideRequestDto)
 
 	}
 }
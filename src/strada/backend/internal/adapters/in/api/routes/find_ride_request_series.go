@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindRideRequestSeries struct {
+	path    string
+	method  string
+	service in.SeriesService
+}
+
+func NewFindRideRequestSeries(s in.SeriesService) api.Route {
+	return &FindRideRequestSeries{
+		path:    "/ride-request-series/:seriesId",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindRideRequestSeries) GetPath() string {
+	return c.path
+}
+
+func (c *FindRideRequestSeries) GetMethod() string {
+	return c.method
+}
+
+func (c *FindRideRequestSeries) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		seriesId, err := strconv.Atoi(cc.Param("seriesId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid seriesId"))
+			return
+		}
+
+		series, err := c.service.FindRideRequestSeries(ctx, int32(seriesId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToRideRequestSeriesDto(series))
+	}
+}
@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindRideSeries struct {
+	path    string
+	method  string
+	service in.SeriesService
+}
+
+func NewFindRideSeries(s in.SeriesService) api.Route {
+	return &FindRideSeries{
+		path:    "/ride-series/:seriesId",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindRideSeries) GetPath() string {
+	return c.path
+}
+
+func (c *FindRideSeries) GetMethod() string {
+	return c.method
+}
+
+func (c *FindRideSeries) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		seriesId, err := strconv.Atoi(cc.Param("seriesId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid seriesId"))
+			return
+		}
+
+		series, err := c.service.FindRideSeries(ctx, int32(seriesId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToRideSeriesDto(series))
+	}
+}
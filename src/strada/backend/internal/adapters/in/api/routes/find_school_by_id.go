@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindSchoolById struct {
+	path    string
+	method  string
+	service in.SchoolService
+}
+
+func NewFindSchoolById(s in.SchoolService) api.Route {
+	return &FindSchoolById{
+		path:    "/schools/:schoolId",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindSchoolById) GetPath() string {
+	return c.path
+}
+
+func (c *FindSchoolById) GetMethod() string {
+	return c.method
+}
+
+func (c *FindSchoolById) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		schoolId, err := strconv.Atoi(cc.Param("schoolId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid schoolId"))
+			return
+		}
+
+		school, err := c.service.FindById(ctx, int32(schoolId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToSchoolDto(school))
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindSchools struct {
+	path    string
+	method  string
+	service in.SchoolService
+}
+
+func NewFindSchools(s in.SchoolService) api.Route {
+	return &FindSchools{
+		path:    "/schools",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindSchools) GetPath() string {
+	return c.path
+}
+
+func (c *FindSchools) GetMethod() string {
+	return c.method
+}
+
+func (c *FindSchools) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		schools, err := c.service.FindAll(ctx, cc.Query("name"))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToSchoolDtoList(schools))
+	}
+}
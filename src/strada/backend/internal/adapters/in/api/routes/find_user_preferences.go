@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindUserPreferences struct {
+	path    string
+	method  string
+	service in.StatsService
+}
+
+func NewFindUserPreferences(s in.StatsService) api.Route {
+	return &FindUserPreferences{
+		path:    "/me/preferences",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindUserPreferences) GetPath() string {
+	return c.path
+}
+
+func (c *FindUserPreferences) GetMethod() string {
+	return c.method
+}
+
+func (c *FindUserPreferences) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		preferences, err := c.service.FindPreferences(ctx)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToUserPreferencesDto(preferences))
+	}
+}
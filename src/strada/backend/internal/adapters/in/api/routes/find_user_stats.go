@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type FindUserStats struct {
+	path    string
+	method  string
+	service in.StatsService
+}
+
+func NewFindUserStats(s in.StatsService) api.Route {
+	return &FindUserStats{
+		path:    "/me/stats",
+		method:  "GET",
+		service: s,
+	}
+}
+
+func (c *FindUserStats) GetPath() string {
+	return c.path
+}
+
+func (c *FindUserStats) GetMethod() string {
+	return c.method
+}
+
+func (c *FindUserStats) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		stats, err := c.service.UserStats(ctx, cc.Query("period"))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToUserStatsDto(stats))
+	}
+}
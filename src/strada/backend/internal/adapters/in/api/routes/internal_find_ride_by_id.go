@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type InternalFindRideById struct {
+	path    string
+	method  string
+	scopes  []string
+	service in.RideService
+}
+
+func NewInternalFindRideById(s in.RideService) api.InternalRoute {
+	return &InternalFindRideById{
+		path:    "/internal/ride/:rideId",
+		method:  "GET",
+		scopes:  []string{models.ScopeRidesRead},
+		service: s,
+	}
+}
+
+func (c *InternalFindRideById) GetPath() string {
+	return c.path
+}
+
+func (c *InternalFindRideById) GetMethod() string {
+	return c.method
+}
+
+func (c *InternalFindRideById) GetScopes() []string {
+	return c.scopes
+}
+
+func (c *InternalFindRideById) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		ride, err := c.service.FindById(ctx, int32(rideId))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToRideDto(ride))
+	}
+}
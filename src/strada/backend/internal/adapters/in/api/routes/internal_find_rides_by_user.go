@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type InternalFindRidesByUser struct {
+	path    string
+	method  string
+	scopes  []string
+	service in.RideService
+}
+
+func NewInternalFindRidesByUser(s in.RideService) api.InternalRoute {
+	return &InternalFindRidesByUser{
+		path:    "/internal/user/:userId/rides",
+		method:  "GET",
+		scopes:  []string{models.ScopeRidesRead},
+		service: s,
+	}
+}
+
+func (c *InternalFindRidesByUser) GetPath() string {
+	return c.path
+}
+
+func (c *InternalFindRidesByUser) GetMethod() string {
+	return c.method
+}
+
+func (c *InternalFindRidesByUser) GetScopes() []string {
+	return c.scopes
+}
+
+func (c *InternalFindRidesByUser) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rides, err := c.service.FindByUser(ctx, cc.Param("userId"))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToRideDtoList(rides))
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type JoinRide struct {
+	path    string
+	method  string
+	service in.RideService
+}
+
+func NewJoinRide(s in.RideService) api.Route {
+	return &JoinRide{
+		path:    "/ride/:rideId/passengers",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *JoinRide) GetPath() string {
+	return c.path
+}
+
+func (c *JoinRide) GetMethod() string {
+	return c.method
+}
+
+func (c *JoinRide) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		var joinDto dto.JoinRideDto
+		if err := cc.BindJSON(&joinDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		split, err := c.service.Join(ctx, int32(rideId), joinDto.ToModel())
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(201, dto.ToFareSplitDto(split))
+	}
+}
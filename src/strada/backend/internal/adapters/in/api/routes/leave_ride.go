@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type LeaveRide struct {
+	path    string
+	method  string
+	service in.RideService
+}
+
+func NewLeaveRide(s in.RideService) api.Route {
+	return &LeaveRide{
+		path:    "/ride/:rideId/passengers/:userId",
+		method:  "DELETE",
+		service: s,
+	}
+}
+
+func (c *LeaveRide) GetPath() string {
+	return c.path
+}
+
+func (c *LeaveRide) GetMethod() string {
+	return c.method
+}
+
+func (c *LeaveRide) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		split, err := c.service.Leave(ctx, int32(rideId), cc.Param("userId"))
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToFareSplitDto(split))
+	}
+}
@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// listQuery holds the pagination and filter parameters shared by the list
+// endpoints.
+type listQuery struct {
+	from        time.Time
+	to          time.Time
+	boundingBox *models.BoundingBox
+	sortBy      string
+	descending  bool
+	cursor      string
+	limit       int
+}
+
+func parseListQuery(cc *gin.Context, defaultSort string, defaultDescending bool) (*listQuery, error) {
+	q := &listQuery{
+		sortBy: cc.DefaultQuery("sort", defaultSort),
+		cursor: cc.Query("cursor"),
+	}
+
+	var err error
+	if q.from, err = parseTimeQuery(cc, "from"); err != nil {
+		return nil, err
+	}
+	if q.to, err = parseTimeQuery(cc, "to"); err != nil {
+		return nil, err
+	}
+
+	if bbox := cc.Query("bbox"); bbox != "" {
+		if q.boundingBox, err = parseBoundingBox(bbox); err != nil {
+			return nil, err
+		}
+	}
+
+	switch cc.Query("order") {
+	case "":
+		q.descending = q.sortBy == defaultSort && defaultDescending
+	case "asc":
+		q.descending = false
+	case "desc":
+		q.descending = true
+	default:
+		return nil, fmt.Errorf("Invalid order, expected asc or desc")
+	}
+
+	if limit := cc.Query("limit"); limit != "" {
+		if q.limit, err = strconv.Atoi(limit); err != nil || q.limit < 1 {
+			return nil, fmt.Errorf("Invalid limit")
+		}
+	}
+
+	return q, nil
+}
+
+func parseTimeQuery(cc *gin.Context, key string) (time.Time, error) {
+	value := cc.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %s, expected an RFC 3339 timestamp", key)
+	}
+	return t, nil
+}
+
+// parseBoundingBox reads "minLon,minLat,maxLon,maxLat".
+func parseBoundingBox(value string) (*models.BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("Invalid bbox, expected minLon,minLat,maxLon,maxLat")
+	}
+	coords := make([]float64, 4)
+	for i, part := range parts {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid bbox, expected minLon,minLat,maxLon,maxLat")
+		}
+		coords[i] = coord
+	}
+	box := &models.BoundingBox{
+		MinLongitude: coords[0],
+		MinLatitude:  coords[1],
+		MaxLongitude: coords[2],
+		MaxLatitude:  coords[3],
+	}
+	if box.MinLongitude > box.MaxLongitude || box.MinLatitude > box.MaxLatitude ||
+		box.MinLongitude < -180 || box.MaxLongitude > 180 || box.MinLatitude < -90 || box.MaxLatitude > 90 {
+		return nil, fmt.Errorf("Invalid bbox bounds")
+	}
+	return box, nil
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type ListRideRequests struct {
+	path        string
+	method      string
+	service     in.RideRequestService
+	userService in.UserService
+}
+
+func NewListRideRequests(s in.RideRequestService, u in.UserService) api.Route {
+	return &ListRideRequests{
+		path:        "/ride-request",
+		method:      "GET",
+		service:     s,
+		userService: u,
+	}
+}
+
+func (c *ListRideRequests) GetPath() string {
+	return c.path
+}
+
+func (c *ListRideRequests) GetMethod() string {
+	return c.method
+}
+
+func (c *ListRideRequests) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		query, err := parseListQuery(cc, models.RideRequestSortRideDatetime, false)
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			return
+		}
+
+		page, err := c.service.List(ctx, models.RideRequestFilter{
+			PassengerID: cc.Query("passengerId"),
+			Status:      cc.Query("status"),
+			From:        query.from,
+			To:          query.to,
+			BoundingBox: query.boundingBox,
+			SortBy:      query.sortBy,
+			Descending:  query.descending,
+			Cursor:      query.cursor,
+			Limit:       query.limit,
+		})
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		rideRequestDtos := dto.ToRideRequestDtoList(page.Items)
+		if err := expandRideRequests(ctx, c.userService, rideRequestDtos, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.PageDto[*dto.RideRequestDto]{Items: rideRequestDtos, NextCursor: page.NextCursor})
+	}
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type ListRides struct {
+	path        string
+	method      string
+	service     in.RideService
+	userService in.UserService
+}
+
+func NewListRides(s in.RideService, u in.UserService) api.Route {
+	return &ListRides{
+		path:        "/ride",
+		method:      "GET",
+		service:     s,
+		userService: u,
+	}
+}
+
+func (c *ListRides) GetPath() string {
+	return c.path
+}
+
+func (c *ListRides) GetMethod() string {
+	return c.method
+}
+
+func (c *ListRides) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		query, err := parseListQuery(cc, models.RideSortCreatedAt, true)
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError(err.Error()))
+			return
+		}
+
+		page, err := c.service.List(ctx, models.RideFilter{
+			DriverID:    cc.Query("driverId"),
+			PassengerID: cc.Query("passengerId"),
+			From:        query.from,
+			To:          query.to,
+			BoundingBox: query.boundingBox,
+			SortBy:      query.sortBy,
+			Descending:  query.descending,
+			Cursor:      query.cursor,
+			Limit:       query.limit,
+		})
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		rideDtos := dto.ToRideDtoList(page.Items)
+		if err := expandRides(ctx, c.service, c.userService, rideDtos, parseExpand(cc)); err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.PageDto[*dto.RideDto]{Items: rideDtos, NextCursor: page.NextCursor})
+	}
+}
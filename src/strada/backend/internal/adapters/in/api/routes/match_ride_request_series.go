@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type MatchRideRequestSeries struct {
+	path    string
+	method  string
+	service in.SeriesService
+}
+
+func NewMatchRideRequestSeries(s in.SeriesService) api.Route {
+	return &MatchRideRequestSeries{
+		path:    "/ride-request-series/:seriesId/match",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *MatchRideRequestSeries) GetPath() string {
+	return c.path
+}
+
+func (c *MatchRideRequestSeries) GetMethod() string {
+	return c.method
+}
+
+func (c *MatchRideRequestSeries) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		seriesId, err := strconv.Atoi(cc.Param("seriesId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid seriesId"))
+			return
+		}
+		var matchDto dto.MatchSeriesDto
+		if err := cc.BindJSON(&matchDto); err != nil || matchDto.RideSeriesID == 0 {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body, expected rideSeriesId"))
+			return
+		}
+
+		match, err := c.service.Match(ctx, int32(seriesId), matchDto.RideSeriesID)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToSeriesMatchDto(match))
+	}
+}
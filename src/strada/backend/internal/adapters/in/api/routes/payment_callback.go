@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"io"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+// maxCallbackBytes bounds the callback bodies read into memory.
+const maxCallbackBytes = 64 << 10
+
+// PaymentCallback receives status updates from the payment providers. It is
+// served without user authentication; each provider checks the signature of
+// its own callbacks.
+type PaymentCallback struct {
+	path    string
+	method  string
+	service in.PaymentService
+}
+
+func NewPaymentCallback(s in.PaymentService) api.Route {
+	return &PaymentCallback{
+		path:    "/webhooks/payments/:provider",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *PaymentCallback) GetPath() string {
+	return c.path
+}
+
+func (c *PaymentCallback) GetMethod() string {
+	return c.method
+}
+
+func (c *PaymentCallback) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		body, err := io.ReadAll(io.LimitReader(cc.Request.Body, maxCallbackBytes))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("cannot read body"))
+			return
+		}
+
+		payment, err := c.service.HandleCallback(ctx, cc.Param("provider"), cc.Request.Header, body)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToPaymentDto(payment))
+	}
+}
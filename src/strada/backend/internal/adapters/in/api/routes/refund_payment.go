@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type RefundPayment struct {
+	path    string
+	method  string
+	service in.PaymentService
+}
+
+func NewRefundPayment(s in.PaymentService) api.Route {
+	return &RefundPayment{
+		path:    "/payments/:paymentId/refund",
+		method:  "POST",
+		service: s,
+	}
+}
+
+func (c *RefundPayment) GetPath() string {
+	return c.path
+}
+
+func (c *RefundPayment) GetMethod() string {
+	return c.method
+}
+
+func (c *RefundPayment) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		paymentId, err := strconv.Atoi(cc.Param("paymentId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid paymentId"))
+			return
+		}
+
+		// The body is optional; without it the whole charge is refunded.
+		var refundDto dto.RefundDto
+		if err := cc.ShouldBindJSON(&refundDto); err != nil && !errors.Is(err, io.EOF) {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		refund, err := c.service.Refund(ctx, int32(paymentId), refundDto.ToModel())
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(201, dto.ToPaymentDto(refund))
+	}
+}
@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateRide struct {
+	path    string
+	method  string
+	service in.RideService
+}
+
+func NewUpdateRide(s in.RideService) api.Route {
+	return &UpdateRide{
+		path:    "/ride/:rideId",
+		method:  "PUT",
+		service: s,
+	}
+}
+
+func (c *UpdateRide) GetPath() string {
+	return c.path
+}
+
+func (c *UpdateRide) GetMethod() string {
+	return c.method
+}
+
+func (c *UpdateRide) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideId, err := strconv.Atoi(cc.Param("rideId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideId"))
+			return
+		}
+
+		var rideDto dto.RideDto
+		if err := cc.BindJSON(&rideDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		ride := rideDto.ToModel()
+		version, restErr := updateVersion(cc, ride.Version)
+		if restErr != nil {
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		ride.Version = version
+
+		updated, err := c.service.Update(ctx, int32(rideId), ride)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		setETag(cc, updated.Version)
+		cc.JSON(200, dto.ToRideDto(updated))
+	}
+}
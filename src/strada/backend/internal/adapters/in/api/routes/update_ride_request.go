@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateRideRequest struct {
+	path    string
+	method  string
+	service in.RideRequestService
+}
+
+func NewUpdateRideRequest(s in.RideRequestService) api.Route {
+	return &UpdateRideRequest{
+		path:    "/ride-request/:riderequestId",
+		method:  "PUT",
+		service: s,
+	}
+}
+
+func (c *UpdateRideRequest) GetPath() string {
+	return c.path
+}
+
+func (c *UpdateRideRequest) GetMethod() string {
+	return c.method
+}
+
+func (c *UpdateRideRequest) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		rideRequestId, err := strconv.Atoi(cc.Param("riderequestId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid rideRequestId"))
+			return
+		}
+
+		var rideRequestDto dto.RideRequestDto
+		if err := cc.BindJSON(&rideRequestDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		rideRequest := rideRequestDto.ToModel()
+		version, restErr := updateVersion(cc, rideRequest.Version)
+		if restErr != nil {
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		rideRequest.Version = version
+
+		updated, err := c.service.Update(ctx, int32(rideRequestId), rideRequest)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		setETag(cc, updated.Version)
+		cc.JSON(200, dto.ToRideRequestDto(updated))
+	}
+}
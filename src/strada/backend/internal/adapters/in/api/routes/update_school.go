@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateSchool struct {
+	path    string
+	method  string
+	service in.SchoolService
+}
+
+func NewUpdateSchool(s in.SchoolService) api.Route {
+	return &UpdateSchool{
+		path:    "/schools/:schoolId",
+		method:  "PUT",
+		service: s,
+	}
+}
+
+func (c *UpdateSchool) GetPath() string {
+	return c.path
+}
+
+func (c *UpdateSchool) GetMethod() string {
+	return c.method
+}
+
+func (c *UpdateSchool) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		schoolId, err := strconv.Atoi(cc.Param("schoolId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid schoolId"))
+			return
+		}
+
+		var schoolDto dto.SchoolDto
+		if err := cc.BindJSON(&schoolDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+		school, err := schoolDto.ToModel()
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+
+		updated, err := c.service.Update(ctx, int32(schoolId), school)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToSchoolDto(updated))
+	}
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateUserPreferences struct {
+	path    string
+	method  string
+	service in.StatsService
+}
+
+func NewUpdateUserPreferences(s in.StatsService) api.Route {
+	return &UpdateUserPreferences{
+		path:    "/me/preferences",
+		method:  "PUT",
+		service: s,
+	}
+}
+
+func (c *UpdateUserPreferences) GetPath() string {
+	return c.path
+}
+
+func (c *UpdateUserPreferences) GetMethod() string {
+	return c.method
+}
+
+func (c *UpdateUserPreferences) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		var preferencesDto dto.UserPreferencesDto
+		if err := cc.BindJSON(&preferencesDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		preferences, err := c.service.SavePreferences(ctx, preferencesDto.ToModel())
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToUserPreferencesDto(preferences))
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"strconv"
+
+	"github.com/244Walyson/shared-ride/configs/rest_err"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	"github.com/244Walyson/shared-ride/internal/adapters/in/api"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/gin-gonic/gin"
+)
+
+type VerifySchoolMember struct {
+	path    string
+	method  string
+	service in.StatsService
+}
+
+func NewVerifySchoolMember(s in.StatsService) api.Route {
+	return &VerifySchoolMember{
+		path:    "/schools/:schoolId/members/:userId",
+		method:  "PUT",
+		service: s,
+	}
+}
+
+func (c *VerifySchoolMember) GetPath() string {
+	return c.path
+}
+
+func (c *VerifySchoolMember) GetMethod() string {
+	return c.method
+}
+
+func (c *VerifySchoolMember) GetHandler() gin.HandlerFunc {
+	return func(cc *gin.Context) {
+		ctx := cc.Request.Context()
+
+		schoolId, err := strconv.Atoi(cc.Param("schoolId"))
+		if err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("Invalid schoolId"))
+			return
+		}
+
+		var memberDto dto.SchoolMemberDto
+		if err := cc.BindJSON(&memberDto); err != nil {
+			cc.JSON(400, rest_err.NewBadRequestError("invalid json body"))
+			return
+		}
+
+		preferences, err := c.service.VerifySchoolMember(ctx, int32(schoolId), cc.Param("userId"), memberDto.Minor)
+		if err != nil {
+			restErr := api.ToRestErr(err)
+			cc.JSON(restErr.Code, restErr)
+			return
+		}
+		cc.JSON(200, dto.ToUserPreferencesDto(preferences))
+	}
+}
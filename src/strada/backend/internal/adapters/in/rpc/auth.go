@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	ridepb "github.com/244Walyson/shared-ride/internal/adapters/in/rpc/proto"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodScopes is the scope each RPC requires. Methods missing here are
+// refused, so a new RPC stays closed until it is listed. Callers are services,
+// not users: rides:read reads any ride and lists the rides and ride requests
+// of any user, so it is only granted to services trusted with all of them.
+var methodScopes = map[string]string{
+	ridepb.RideService_GetRide_FullMethodName:          models.ScopeRidesRead,
+	ridepb.RideService_ListRidesForUser_FullMethodName: models.ScopeRidesRead,
+	ridepb.RideService_ListParticipants_FullMethodName: models.ScopeRideParticipantsRead,
+	ridepb.RideService_StreamRideEvents_FullMethodName: models.ScopeRideEventsRead,
+}
+
+// serviceAuth authenticates gRPC callers the same way as the internal HTTP
+// endpoints: a client-credentials bearer token in the authorization metadata
+// or an API key in x-api-key. Every call is written to the audit log.
+type serviceAuth struct {
+	service in.ServiceAuthService
+}
+
+func (a *serviceAuth) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	principal, method, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(models.ContextWithPrincipal(ctx, principal), req)
+	auditCall(principal, method, info.FullMethod, err, start)
+	return resp, err
+}
+
+func (a *serviceAuth) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	principal, method, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	err = handler(srv, &principalStream{ServerStream: ss, ctx: models.ContextWithPrincipal(ss.Context(), principal)})
+	auditCall(principal, method, info.FullMethod, err, start)
+	return err
+}
+
+func (a *serviceAuth) authenticate(ctx context.Context, fullMethod string) (*models.Principal, string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var principal *models.Principal
+	var err error
+	var method string
+
+	if apiKey := firstValue(md, "x-api-key"); apiKey != "" {
+		method = "api_key"
+		principal, err = a.service.VerifyApiKey(ctx, apiKey)
+	} else if token, ok := strings.CutPrefix(firstValue(md, "authorization"), "Bearer "); ok {
+		method = "client_credentials"
+		principal, err = a.service.VerifyToken(ctx, token)
+	} else {
+		method = "none"
+		err = models.ErrUnauthenticated
+	}
+
+	if err != nil {
+		logger.Audit("service rpc rejected",
+			zap.String("auth_method", method),
+			zap.String("rpc", fullMethod),
+			zap.NamedError("error", err),
+		)
+		return nil, method, status.Error(codes.Unauthenticated, "invalid service credentials")
+	}
+
+	scope, ok := methodScopes[fullMethod]
+	if !ok || !principal.HasScope(scope) {
+		logger.Audit("service rpc denied",
+			zap.String("client_id", principal.ClientID),
+			zap.String("auth_method", method),
+			zap.String("rpc", fullMethod),
+			zap.String("missing_scope", scope),
+		)
+		return nil, method, status.Error(codes.PermissionDenied, "missing scope "+scope)
+	}
+	return principal, method, nil
+}
+
+func auditCall(principal *models.Principal, method string, fullMethod string, err error, start time.Time) {
+	logger.Audit("service rpc",
+		zap.String("client_id", principal.ClientID),
+		zap.Strings("scopes", principal.Scopes),
+		zap.String("auth_method", method),
+		zap.String("rpc", fullMethod),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// principalStream hands the authenticated context to stream handlers.
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
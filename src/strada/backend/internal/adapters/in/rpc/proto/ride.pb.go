@@ -0,0 +1,1047 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v3.21.6
+// source: proto/ride.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LocationDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationDto) Reset() {
+	*x = LocationDto{}
+	mi := &file_proto_ride_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationDto) ProtoMessage() {}
+
+func (x *LocationDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationDto.ProtoReflect.Descriptor instead.
+func (*LocationDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LocationDto) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LocationDto) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type MoneyDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Decimal amount with two places, e.g. "15.50".
+	Amount        string `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoneyDto) Reset() {
+	*x = MoneyDto{}
+	mi := &file_proto_ride_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoneyDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoneyDto) ProtoMessage() {}
+
+func (x *MoneyDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoneyDto.ProtoReflect.Descriptor instead.
+func (*MoneyDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MoneyDto) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *MoneyDto) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type RideResponseDto struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DriverId        string                 `protobuf:"bytes,2,opt,name=driverId,proto3" json:"driverId,omitempty"`
+	VehicleId       int32                  `protobuf:"varint,3,opt,name=vehicleId,proto3" json:"vehicleId,omitempty"`
+	StartPoint      *LocationDto           `protobuf:"bytes,4,opt,name=startPoint,proto3" json:"startPoint,omitempty"`
+	EndPoint        *LocationDto           `protobuf:"bytes,5,opt,name=endPoint,proto3" json:"endPoint,omitempty"`
+	StopPoints      []*LocationDto         `protobuf:"bytes,6,rep,name=stopPoints,proto3" json:"stopPoints,omitempty"`
+	EstimatedTimeMs int32                  `protobuf:"varint,8,opt,name=estimatedTimeMs,proto3" json:"estimatedTimeMs,omitempty"`
+	Description     string                 `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	ImgUrl          string                 `protobuf:"bytes,12,opt,name=imgUrl,proto3" json:"imgUrl,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	DistanceMeters  float64                `protobuf:"fixed64,15,opt,name=distanceMeters,proto3" json:"distanceMeters,omitempty"`
+	Co2EmissionKg   float64                `protobuf:"fixed64,16,opt,name=co2EmissionKg,proto3" json:"co2EmissionKg,omitempty"`
+	Cost            *MoneyDto              `protobuf:"bytes,17,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RideResponseDto) Reset() {
+	*x = RideResponseDto{}
+	mi := &file_proto_ride_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RideResponseDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RideResponseDto) ProtoMessage() {}
+
+func (x *RideResponseDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RideResponseDto.ProtoReflect.Descriptor instead.
+func (*RideResponseDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RideResponseDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RideResponseDto) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RideResponseDto) GetVehicleId() int32 {
+	if x != nil {
+		return x.VehicleId
+	}
+	return 0
+}
+
+func (x *RideResponseDto) GetStartPoint() *LocationDto {
+	if x != nil {
+		return x.StartPoint
+	}
+	return nil
+}
+
+func (x *RideResponseDto) GetEndPoint() *LocationDto {
+	if x != nil {
+		return x.EndPoint
+	}
+	return nil
+}
+
+func (x *RideResponseDto) GetStopPoints() []*LocationDto {
+	if x != nil {
+		return x.StopPoints
+	}
+	return nil
+}
+
+func (x *RideResponseDto) GetEstimatedTimeMs() int32 {
+	if x != nil {
+		return x.EstimatedTimeMs
+	}
+	return 0
+}
+
+func (x *RideResponseDto) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RideResponseDto) GetImgUrl() string {
+	if x != nil {
+		return x.ImgUrl
+	}
+	return ""
+}
+
+func (x *RideResponseDto) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RideResponseDto) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RideResponseDto) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *RideResponseDto) GetCo2EmissionKg() float64 {
+	if x != nil {
+		return x.Co2EmissionKg
+	}
+	return 0
+}
+
+func (x *RideResponseDto) GetCost() *MoneyDto {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+type GetRideRequestDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int32                  `protobuf:"varint,1,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRideRequestDto) Reset() {
+	*x = GetRideRequestDto{}
+	mi := &file_proto_ride_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRideRequestDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRideRequestDto) ProtoMessage() {}
+
+func (x *GetRideRequestDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRideRequestDto.ProtoReflect.Descriptor instead.
+func (*GetRideRequestDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRideRequestDto) GetRideId() int32 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+type ParticipantDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	StartPoint    *LocationDto           `protobuf:"bytes,3,opt,name=startPoint,proto3" json:"startPoint,omitempty"`
+	EndPoint      *LocationDto           `protobuf:"bytes,4,opt,name=endPoint,proto3" json:"endPoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParticipantDto) Reset() {
+	*x = ParticipantDto{}
+	mi := &file_proto_ride_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParticipantDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantDto) ProtoMessage() {}
+
+func (x *ParticipantDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantDto.ProtoReflect.Descriptor instead.
+func (*ParticipantDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ParticipantDto) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ParticipantDto) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ParticipantDto) GetStartPoint() *LocationDto {
+	if x != nil {
+		return x.StartPoint
+	}
+	return nil
+}
+
+func (x *ParticipantDto) GetEndPoint() *LocationDto {
+	if x != nil {
+		return x.EndPoint
+	}
+	return nil
+}
+
+type ListParticipantsRequestDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int32                  `protobuf:"varint,1,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsRequestDto) Reset() {
+	*x = ListParticipantsRequestDto{}
+	mi := &file_proto_ride_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsRequestDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsRequestDto) ProtoMessage() {}
+
+func (x *ListParticipantsRequestDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsRequestDto.ProtoReflect.Descriptor instead.
+func (*ListParticipantsRequestDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListParticipantsRequestDto) GetRideId() int32 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+type ListParticipantsResponseDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Participants  []*ParticipantDto      `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsResponseDto) Reset() {
+	*x = ListParticipantsResponseDto{}
+	mi := &file_proto_ride_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsResponseDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsResponseDto) ProtoMessage() {}
+
+func (x *ListParticipantsResponseDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsResponseDto.ProtoReflect.Descriptor instead.
+func (*ListParticipantsResponseDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListParticipantsResponseDto) GetParticipants() []*ParticipantDto {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+type ListRidesForUserRequestDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRidesForUserRequestDto) Reset() {
+	*x = ListRidesForUserRequestDto{}
+	mi := &file_proto_ride_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRidesForUserRequestDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRidesForUserRequestDto) ProtoMessage() {}
+
+func (x *ListRidesForUserRequestDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRidesForUserRequestDto.ProtoReflect.Descriptor instead.
+func (*ListRidesForUserRequestDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRidesForUserRequestDto) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RideRequestResponseDto struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PassengerId  string                 `protobuf:"bytes,2,opt,name=passengerId,proto3" json:"passengerId,omitempty"`
+	Origin       *LocationDto           `protobuf:"bytes,3,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination  *LocationDto           `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	Description  string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	RideDatetime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=rideDatetime,proto3" json:"rideDatetime,omitempty"`
+	ImgUrl       string                 `protobuf:"bytes,7,opt,name=imgUrl,proto3" json:"imgUrl,omitempty"`
+	Status       string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// School the passenger goes to, 0 for none.
+	SchoolId      int32 `protobuf:"varint,9,opt,name=schoolId,proto3" json:"schoolId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RideRequestResponseDto) Reset() {
+	*x = RideRequestResponseDto{}
+	mi := &file_proto_ride_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RideRequestResponseDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RideRequestResponseDto) ProtoMessage() {}
+
+func (x *RideRequestResponseDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RideRequestResponseDto.ProtoReflect.Descriptor instead.
+func (*RideRequestResponseDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RideRequestResponseDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RideRequestResponseDto) GetPassengerId() string {
+	if x != nil {
+		return x.PassengerId
+	}
+	return ""
+}
+
+func (x *RideRequestResponseDto) GetOrigin() *LocationDto {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *RideRequestResponseDto) GetDestination() *LocationDto {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *RideRequestResponseDto) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RideRequestResponseDto) GetRideDatetime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RideDatetime
+	}
+	return nil
+}
+
+func (x *RideRequestResponseDto) GetImgUrl() string {
+	if x != nil {
+		return x.ImgUrl
+	}
+	return ""
+}
+
+func (x *RideRequestResponseDto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RideRequestResponseDto) GetSchoolId() int32 {
+	if x != nil {
+		return x.SchoolId
+	}
+	return 0
+}
+
+type ListRidesResponseDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Rides the user drives or joined.
+	Rides []*RideResponseDto `protobuf:"bytes,1,rep,name=rides,proto3" json:"rides,omitempty"`
+	// Ride requests the user made.
+	RideRequests  []*RideRequestResponseDto `protobuf:"bytes,2,rep,name=rideRequests,proto3" json:"rideRequests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRidesResponseDto) Reset() {
+	*x = ListRidesResponseDto{}
+	mi := &file_proto_ride_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRidesResponseDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRidesResponseDto) ProtoMessage() {}
+
+func (x *ListRidesResponseDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRidesResponseDto.ProtoReflect.Descriptor instead.
+func (*ListRidesResponseDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListRidesResponseDto) GetRides() []*RideResponseDto {
+	if x != nil {
+		return x.Rides
+	}
+	return nil
+}
+
+func (x *ListRidesResponseDto) GetRideRequests() []*RideRequestResponseDto {
+	if x != nil {
+		return x.RideRequests
+	}
+	return nil
+}
+
+// Both filters are optional, but an empty request, streaming every event,
+// needs the ride-events:read:all scope.
+type StreamRideEventsRequestDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RideId        int32                  `protobuf:"varint,1,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRideEventsRequestDto) Reset() {
+	*x = StreamRideEventsRequestDto{}
+	mi := &file_proto_ride_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRideEventsRequestDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRideEventsRequestDto) ProtoMessage() {}
+
+func (x *StreamRideEventsRequestDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRideEventsRequestDto.ProtoReflect.Descriptor instead.
+func (*StreamRideEventsRequestDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamRideEventsRequestDto) GetRideId() int32 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *StreamRideEventsRequestDto) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RideEventDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	RideId        int32                  `protobuf:"varint,2,opt,name=rideId,proto3" json:"rideId,omitempty"`
+	RideRequestId int32                  `protobuf:"varint,3,opt,name=rideRequestId,proto3" json:"rideRequestId,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RideEventDto) Reset() {
+	*x = RideEventDto{}
+	mi := &file_proto_ride_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RideEventDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RideEventDto) ProtoMessage() {}
+
+func (x *RideEventDto) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ride_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RideEventDto.ProtoReflect.Descriptor instead.
+func (*RideEventDto) Descriptor() ([]byte, []int) {
+	return file_proto_ride_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RideEventDto) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RideEventDto) GetRideId() int32 {
+	if x != nil {
+		return x.RideId
+	}
+	return 0
+}
+
+func (x *RideEventDto) GetRideRequestId() int32 {
+	if x != nil {
+		return x.RideRequestId
+	}
+	return 0
+}
+
+func (x *RideEventDto) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RideEventDto) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_proto_ride_proto protoreflect.FileDescriptor
+
+var file_proto_ride_proto_rawDesc = string([]byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x72, 0x69, 0x64, 0x65, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x47, 0x0a, 0x0b, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x22, 0x3e, 0x0a, 0x08, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x44, 0x74, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x22, 0xe3, 0x04, 0x0a, 0x0f, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x31, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x32, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x67, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x63, 0x6f,
+	0x32, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x67, 0x12, 0x22, 0x0a, 0x04, 0x63,
+	0x6f, 0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x44, 0x74, 0x6f, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x4a,
+	0x04, 0x08, 0x07, 0x10, 0x08, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0a, 0x4a, 0x04, 0x08, 0x0a, 0x10,
+	0x0b, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x63, 0x6f, 0x32,
+	0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72,
+	0x69, 0x64, 0x65, 0x49, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x69, 0x70, 0x61, 0x6e, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x08, 0x65, 0x6e,
+	0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x34, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x44, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x22, 0x57, 0x0a, 0x1b,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x12, 0x38, 0x0a, 0x0c, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x44, 0x74, 0x6f, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x34, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x69, 0x64,
+	0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x44, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xd8, 0x02, 0x0a, 0x16,
+	0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x73, 0x73, 0x65, 0x6e,
+	0x67, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x06, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x74, 0x6f, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0c, 0x72, 0x69,
+	0x64, 0x65, 0x44, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x72, 0x69,
+	0x64, 0x65, 0x44, 0x61, 0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d,
+	0x67, 0x55, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x67, 0x55,
+	0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63,
+	0x68, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x63,
+	0x68, 0x6f, 0x6f, 0x6c, 0x49, 0x64, 0x22, 0x85, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x69, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x12,
+	0x2b, 0x0a, 0x05, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x44, 0x74, 0x6f, 0x52, 0x05, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0c,
+	0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f,
+	0x52, 0x0c, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x4c,
+	0x0a, 0x1a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x69, 0x64, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x69,
+	0x64, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xb4, 0x01, 0x0a,
+	0x0c, 0x52, 0x69, 0x64, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x74, 0x6f, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x72, 0x69, 0x64, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x69, 0x64,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x72, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x64, 0x41, 0x74, 0x32, 0xc7, 0x02, 0x0a, 0x0b, 0x52, 0x69, 0x64, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52, 0x69, 0x64, 0x65, 0x12, 0x17,
+	0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x69, 0x64, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x1a, 0x15, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x52,
+	0x69, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x22, 0x00,
+	0x12, 0x59, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
+	0x61, 0x6e, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x44, 0x74, 0x6f, 0x1a, 0x21, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x10, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x69, 0x64, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12,
+	0x20, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x69, 0x64, 0x65, 0x73,
+	0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x74,
+	0x6f, 0x1a, 0x1a, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x69, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x74, 0x6f, 0x22, 0x00, 0x12,
+	0x4c, 0x0a, 0x10, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x69, 0x64, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x69, 0x64, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x44, 0x74, 0x6f, 0x1a, 0x12, 0x2e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x52, 0x69, 0x64,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x74, 0x6f, 0x22, 0x00, 0x30, 0x01, 0x42, 0x26, 0x5a,
+	0x24, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65,
+	0x72, 0x73, 0x2f, 0x69, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_proto_ride_proto_rawDescOnce sync.Once
+	file_proto_ride_proto_rawDescData []byte
+)
+
+func file_proto_ride_proto_rawDescGZIP() []byte {
+	file_proto_ride_proto_rawDescOnce.Do(func() {
+		file_proto_ride_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_ride_proto_rawDesc), len(file_proto_ride_proto_rawDesc)))
+	})
+	return file_proto_ride_proto_rawDescData
+}
+
+var file_proto_ride_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_ride_proto_goTypes = []any{
+	(*LocationDto)(nil),                 // 0: ride.LocationDto
+	(*MoneyDto)(nil),                    // 1: ride.MoneyDto
+	(*RideResponseDto)(nil),             // 2: ride.RideResponseDto
+	(*GetRideRequestDto)(nil),           // 3: ride.GetRideRequestDto
+	(*ParticipantDto)(nil),              // 4: ride.ParticipantDto
+	(*ListParticipantsRequestDto)(nil),  // 5: ride.ListParticipantsRequestDto
+	(*ListParticipantsResponseDto)(nil), // 6: ride.ListParticipantsResponseDto
+	(*ListRidesForUserRequestDto)(nil),  // 7: ride.ListRidesForUserRequestDto
+	(*RideRequestResponseDto)(nil),      // 8: ride.RideRequestResponseDto
+	(*ListRidesResponseDto)(nil),        // 9: ride.ListRidesResponseDto
+	(*StreamRideEventsRequestDto)(nil),  // 10: ride.StreamRideEventsRequestDto
+	(*RideEventDto)(nil),                // 11: ride.RideEventDto
+	(*timestamppb.Timestamp)(nil),       // 12: google.protobuf.Timestamp
+}
+var file_proto_ride_proto_depIdxs = []int32{
+	0,  // 0: ride.RideResponseDto.startPoint:type_name -> ride.LocationDto
+	0,  // 1: ride.RideResponseDto.endPoint:type_name -> ride.LocationDto
+	0,  // 2: ride.RideResponseDto.stopPoints:type_name -> ride.LocationDto
+	12, // 3: ride.RideResponseDto.createdAt:type_name -> google.protobuf.Timestamp
+	12, // 4: ride.RideResponseDto.updatedAt:type_name -> google.protobuf.Timestamp
+	1,  // 5: ride.RideResponseDto.cost:type_name -> ride.MoneyDto
+	0,  // 6: ride.ParticipantDto.startPoint:type_name -> ride.LocationDto
+	0,  // 7: ride.ParticipantDto.endPoint:type_name -> ride.LocationDto
+	4,  // 8: ride.ListParticipantsResponseDto.participants:type_name -> ride.ParticipantDto
+	0,  // 9: ride.RideRequestResponseDto.origin:type_name -> ride.LocationDto
+	0,  // 10: ride.RideRequestResponseDto.destination:type_name -> ride.LocationDto
+	12, // 11: ride.RideRequestResponseDto.rideDatetime:type_name -> google.protobuf.Timestamp
+	2,  // 12: ride.ListRidesResponseDto.rides:type_name -> ride.RideResponseDto
+	8,  // 13: ride.ListRidesResponseDto.rideRequests:type_name -> ride.RideRequestResponseDto
+	12, // 14: ride.RideEventDto.occurredAt:type_name -> google.protobuf.Timestamp
+	3,  // 15: ride.RideService.GetRide:input_type -> ride.GetRideRequestDto
+	5,  // 16: ride.RideService.ListParticipants:input_type -> ride.ListParticipantsRequestDto
+	7,  // 17: ride.RideService.ListRidesForUser:input_type -> ride.ListRidesForUserRequestDto
+	10, // 18: ride.RideService.StreamRideEvents:input_type -> ride.StreamRideEventsRequestDto
+	2,  // 19: ride.RideService.GetRide:output_type -> ride.RideResponseDto
+	6,  // 20: ride.RideService.ListParticipants:output_type -> ride.ListParticipantsResponseDto
+	9,  // 21: ride.RideService.ListRidesForUser:output_type -> ride.ListRidesResponseDto
+	11, // 22: ride.RideService.StreamRideEvents:output_type -> ride.RideEventDto
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_proto_ride_proto_init() }
+func file_proto_ride_proto_init() {
+	if File_proto_ride_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ride_proto_rawDesc), len(file_proto_ride_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_ride_proto_goTypes,
+		DependencyIndexes: file_proto_ride_proto_depIdxs,
+		MessageInfos:      file_proto_ride_proto_msgTypes,
+	}.Build()
+	File_proto_ride_proto = out.File
+	file_proto_ride_proto_goTypes = nil
+	file_proto_ride_proto_depIdxs = nil
+}
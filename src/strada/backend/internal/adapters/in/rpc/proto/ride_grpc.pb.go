@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.6
+// source: proto/ride.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RideService_GetRide_FullMethodName          = "/ride.RideService/GetRide"
+	RideService_ListParticipants_FullMethodName = "/ride.RideService/ListParticipants"
+	RideService_ListRidesForUser_FullMethodName = "/ride.RideService/ListRidesForUser"
+	RideService_StreamRideEvents_FullMethodName = "/ride.RideService/StreamRideEvents"
+)
+
+// RideServiceClient is the client API for RideService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RideServiceClient interface {
+	GetRide(ctx context.Context, in *GetRideRequestDto, opts ...grpc.CallOption) (*RideResponseDto, error)
+	ListParticipants(ctx context.Context, in *ListParticipantsRequestDto, opts ...grpc.CallOption) (*ListParticipantsResponseDto, error)
+	ListRidesForUser(ctx context.Context, in *ListRidesForUserRequestDto, opts ...grpc.CallOption) (*ListRidesResponseDto, error)
+	StreamRideEvents(ctx context.Context, in *StreamRideEventsRequestDto, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RideEventDto], error)
+}
+
+type rideServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRideServiceClient(cc grpc.ClientConnInterface) RideServiceClient {
+	return &rideServiceClient{cc}
+}
+
+func (c *rideServiceClient) GetRide(ctx context.Context, in *GetRideRequestDto, opts ...grpc.CallOption) (*RideResponseDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RideResponseDto)
+	err := c.cc.Invoke(ctx, RideService_GetRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) ListParticipants(ctx context.Context, in *ListParticipantsRequestDto, opts ...grpc.CallOption) (*ListParticipantsResponseDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListParticipantsResponseDto)
+	err := c.cc.Invoke(ctx, RideService_ListParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) ListRidesForUser(ctx context.Context, in *ListRidesForUserRequestDto, opts ...grpc.CallOption) (*ListRidesResponseDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRidesResponseDto)
+	err := c.cc.Invoke(ctx, RideService_ListRidesForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rideServiceClient) StreamRideEvents(ctx context.Context, in *StreamRideEventsRequestDto, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RideEventDto], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RideService_ServiceDesc.Streams[0], RideService_StreamRideEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRideEventsRequestDto, RideEventDto]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RideService_StreamRideEventsClient = grpc.ServerStreamingClient[RideEventDto]
+
+// RideServiceServer is the server API for RideService service.
+// All implementations must embed UnimplementedRideServiceServer
+// for forward compatibility.
+type RideServiceServer interface {
+	GetRide(context.Context, *GetRideRequestDto) (*RideResponseDto, error)
+	ListParticipants(context.Context, *ListParticipantsRequestDto) (*ListParticipantsResponseDto, error)
+	ListRidesForUser(context.Context, *ListRidesForUserRequestDto) (*ListRidesResponseDto, error)
+	StreamRideEvents(*StreamRideEventsRequestDto, grpc.ServerStreamingServer[RideEventDto]) error
+	mustEmbedUnimplementedRideServiceServer()
+}
+
+// UnimplementedRideServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRideServiceServer struct{}
+
+func (UnimplementedRideServiceServer) GetRide(context.Context, *GetRideRequestDto) (*RideResponseDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRide not implemented")
+}
+func (UnimplementedRideServiceServer) ListParticipants(context.Context, *ListParticipantsRequestDto) (*ListParticipantsResponseDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParticipants not implemented")
+}
+func (UnimplementedRideServiceServer) ListRidesForUser(context.Context, *ListRidesForUserRequestDto) (*ListRidesResponseDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRidesForUser not implemented")
+}
+func (UnimplementedRideServiceServer) StreamRideEvents(*StreamRideEventsRequestDto, grpc.ServerStreamingServer[RideEventDto]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRideEvents not implemented")
+}
+func (UnimplementedRideServiceServer) mustEmbedUnimplementedRideServiceServer() {}
+func (UnimplementedRideServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeRideServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RideServiceServer will
+// result in compilation errors.
+type UnsafeRideServiceServer interface {
+	mustEmbedUnimplementedRideServiceServer()
+}
+
+func RegisterRideServiceServer(s grpc.ServiceRegistrar, srv RideServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRideServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RideService_ServiceDesc, srv)
+}
+
+func _RideService_GetRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRideRequestDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).GetRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_GetRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).GetRide(ctx, req.(*GetRideRequestDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_ListParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParticipantsRequestDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).ListParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_ListParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).ListParticipants(ctx, req.(*ListParticipantsRequestDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_ListRidesForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRidesForUserRequestDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RideServiceServer).ListRidesForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RideService_ListRidesForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RideServiceServer).ListRidesForUser(ctx, req.(*ListRidesForUserRequestDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RideService_StreamRideEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRideEventsRequestDto)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RideServiceServer).StreamRideEvents(m, &grpc.GenericServerStream[StreamRideEventsRequestDto, RideEventDto]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RideService_StreamRideEventsServer = grpc.ServerStreamingServer[RideEventDto]
+
+// RideService_ServiceDesc is the grpc.ServiceDesc for RideService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RideService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ride.RideService",
+	HandlerType: (*RideServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRide",
+			Handler:    _RideService_GetRide_Handler,
+		},
+		{
+			MethodName: "ListParticipants",
+			Handler:    _RideService_ListParticipants_Handler,
+		},
+		{
+			MethodName: "ListRidesForUser",
+			Handler:    _RideService_ListRidesForUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRideEvents",
+			Handler:       _RideService_StreamRideEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/ride.proto",
+}
@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	"github.com/244Walyson/shared-ride/internal/adapters/dto"
+	ridepb "github.com/244Walyson/shared-ride/internal/adapters/in/rpc/proto"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const driverRole = "driver"
+
+type RideServer struct {
+	ridepb.UnimplementedRideServiceServer
+	rideService        in.RideService
+	rideRequestService in.RideRequestService
+	rideEventService   in.RideEventService
+}
+
+func NewRideServer(rideService in.RideService, rideRequestService in.RideRequestService, rideEventService in.RideEventService) *RideServer {
+	return &RideServer{
+		rideService:        rideService,
+		rideRequestService: rideRequestService,
+		rideEventService:   rideEventService,
+	}
+}
+
+func (s *RideServer) GetRide(ctx context.Context, req *ridepb.GetRideRequestDto) (*ridepb.RideResponseDto, error) {
+	ride, err := s.rideService.FindById(ctx, req.GetRideId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return dto.ToRideProto(ride), nil
+}
+
+func (s *RideServer) ListParticipants(ctx context.Context, req *ridepb.ListParticipantsRequestDto) (*ridepb.ListParticipantsResponseDto, error) {
+	ride, err := s.rideService.FindById(ctx, req.GetRideId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	passengers, err := s.rideService.FindPassengers(ctx, []int32{ride.ID})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	participants := []*ridepb.ParticipantDto{
+		dto.ToParticipantProto(&models.RidePassenger{
+			RideID:     ride.ID,
+			UserID:     ride.DriverID,
+			StartPoint: ride.StartPoint,
+			EndPoint:   ride.EndPoint,
+			Role:       driverRole,
+		}),
+	}
+	for _, passenger := range passengers {
+		if passenger.UserID == ride.DriverID {
+			continue
+		}
+		participants = append(participants, dto.ToParticipantProto(passenger))
+	}
+
+	return &ridepb.ListParticipantsResponseDto{Participants: participants}, nil
+}
+
+// ListRidesForUser returns the rides the user drives or joined and every ride
+// request they made.
+func (s *RideServer) ListRidesForUser(ctx context.Context, req *ridepb.ListRidesForUserRequestDto) (*ridepb.ListRidesResponseDto, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "userId is required")
+	}
+
+	rides, err := s.rideService.FindByUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	var rideRequests []*models.RideRequest
+	filter := models.RideRequestFilter{PassengerID: req.GetUserId(), Limit: models.MaxPageSize}
+	for {
+		page, err := s.rideRequestService.List(ctx, filter)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		rideRequests = append(rideRequests, page.Items...)
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	return &ridepb.ListRidesResponseDto{
+		Rides:        dto.ToRideProtoList(rides),
+		RideRequests: dto.ToRideRequestProtoList(rideRequests),
+	}, nil
+}
+
+func (s *RideServer) StreamRideEvents(req *ridepb.StreamRideEventsRequestDto, stream grpc.ServerStreamingServer[ridepb.RideEventDto]) error {
+	ctx := stream.Context()
+	// Every event of every ride is only for callers trusted with all of
+	// them; others must name the ride or user they follow.
+	if req.GetRideId() == 0 && req.GetUserId() == "" {
+		principal, ok := models.PrincipalFromContext(ctx)
+		if !ok || !principal.HasScope(models.ScopeRideEventsReadAll) {
+			return status.Error(codes.PermissionDenied, "streaming every ride requires scope "+models.ScopeRideEventsReadAll)
+		}
+	}
+	events := s.rideEventService.Subscribe(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if !matchesEventFilter(req, event) {
+				continue
+			}
+			if err := stream.Send(dto.ToRideEventProto(event)); err != nil {
+				logger.Error("error sending ride event", err)
+				return err
+			}
+		}
+	}
+}
+
+func matchesEventFilter(req *ridepb.StreamRideEventsRequestDto, event *models.RideEvent) bool {
+	if req.GetRideId() != 0 && req.GetRideId() != event.RideID {
+		return false
+	}
+	if req.GetUserId() != "" && req.GetUserId() != event.UserID {
+		return false
+	}
+	return true
+}
+
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrRideNotFound), errors.Is(err, models.ErrRideRequestNotFound), errors.Is(err, models.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, models.ErrUserServiceUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"log"
+	"net"
+
+	ridepb "github.com/244Walyson/shared-ride/internal/adapters/in/rpc/proto"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"google.golang.org/grpc"
+)
+
+// Serve starts the gRPC server on addr and blocks until it stops. Only
+// services authenticated by serviceAuthService get through.
+func Serve(addr string, rideServer *RideServer, serviceAuthService in.ServiceAuthService) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	auth := &serviceAuth{service: serviceAuthService}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	)
+	ridepb.RegisterRideServiceServer(server, rideServer)
+
+	log.Printf("gRPC server listening on %s", addr)
+	return server.Serve(listener)
+}
@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+const (
+	FakeProviderName    = "fake"
+	FakeSignatureHeader = "X-Fake-Signature"
+)
+
+// FakeProvider moves no money. It accepts every payment as pending under a
+// reference derived from the payment id, and settles them when a callback
+// for that reference arrives, so local setups can play the provider with
+// curl.
+type FakeProvider struct {
+	secret []byte
+}
+
+// NewFakeProvider checks callbacks against the hex HMAC-SHA256 of the body
+// in FakeSignatureHeader. An empty secret accepts unsigned callbacks.
+func NewFakeProvider(secret string) out.PaymentProvider {
+	return &FakeProvider{
+		secret: []byte(secret),
+	}
+}
+
+type fakeCallback struct {
+	ID            string `json:"id"`
+	Reference     string `json:"reference"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failureReason"`
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) Charge(ctx context.Context, payment *models.Payment) (*models.PaymentUpdate, error) {
+	return p.accept(payment), nil
+}
+
+func (p *FakeProvider) Payout(ctx context.Context, payment *models.Payment) (*models.PaymentUpdate, error) {
+	return p.accept(payment), nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, refund *models.Payment, charge *models.Payment) (*models.PaymentUpdate, error) {
+	return p.accept(refund), nil
+}
+
+func (p *FakeProvider) ParseCallback(header map[string][]string, body []byte) (*models.PaymentEvent, error) {
+	if len(p.secret) > 0 {
+		signature, err := hex.DecodeString(http.Header(header).Get(FakeSignatureHeader))
+		if err != nil || !hmac.Equal(signature, p.Sign(body)) {
+			return nil, models.ErrInvalidSignature
+		}
+	}
+
+	var callback fakeCallback
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return nil, fmt.Errorf("invalid fake callback: %w", err)
+	}
+	if callback.ID == "" || callback.Reference == "" {
+		return nil, errors.New("invalid fake callback: id and reference are required")
+	}
+	switch callback.Status {
+	case models.PaymentStatusPending, models.PaymentStatusSucceeded, models.PaymentStatusFailed:
+	default:
+		return nil, fmt.Errorf("invalid fake callback: unknown status %q", callback.Status)
+	}
+
+	return &models.PaymentEvent{
+		Provider: FakeProviderName,
+		EventID:  callback.ID,
+		PaymentUpdate: models.PaymentUpdate{
+			ProviderRef:   callback.Reference,
+			Status:        callback.Status,
+			FailureReason: callback.FailureReason,
+		},
+	}, nil
+}
+
+// Sign returns the HMAC-SHA256 of body under the provider secret.
+func (p *FakeProvider) Sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func (p *FakeProvider) accept(payment *models.Payment) *models.PaymentUpdate {
+	return &models.PaymentUpdate{
+		ProviderRef: fmt.Sprintf("fake_%s_%d", payment.Kind, payment.ID),
+		Status:      models.PaymentStatusPending,
+	}
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// ApiKeyRepository verifies self-contained API keys of the form
+// base64url(payload).base64url(hmac-sha256(payload)), signed with a secret
+// shared by the services that mint them. Every key expires and carries a key
+// id that can be revoked before then.
+type ApiKeyRepository struct {
+	secret  []byte
+	revoked map[string]bool
+}
+
+type apiKeyPayload struct {
+	KeyID     string   `json:"kid"`
+	ClientID  string   `json:"client_id"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+func NewApiKeyRepository(secret string, revokedKeyIds []string) out.ApiKeyRepository {
+	revoked := make(map[string]bool, len(revokedKeyIds))
+	for _, keyId := range revokedKeyIds {
+		revoked[keyId] = true
+	}
+	return &ApiKeyRepository{
+		secret:  []byte(secret),
+		revoked: revoked,
+	}
+}
+
+func (r *ApiKeyRepository) VerifyApiKey(ctx context.Context, apiKey string) (*models.Principal, error) {
+	if len(r.secret) == 0 {
+		return nil, errors.New("API keys are disabled")
+	}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(apiKey, ".")
+	if !ok {
+		return nil, errors.New("malformed API key")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, errors.New("malformed API key signature")
+	}
+	if !hmac.Equal(signature, sign(r.secret, encodedPayload)) {
+		return nil, errors.New("invalid API key signature")
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errors.New("malformed API key payload")
+	}
+	var payload apiKeyPayload
+	if err := json.Unmarshal(rawPayload, &payload); err != nil {
+		return nil, fmt.Errorf("malformed API key payload: %w", err)
+	}
+
+	if payload.ClientID == "" {
+		return nil, errors.New("API key has no client")
+	}
+	// Keys minted before key ids and mandatory expiry are no longer accepted.
+	if payload.KeyID == "" || payload.ExpiresAt == 0 {
+		return nil, errors.New("API key has no key id or expiry")
+	}
+	if time.Now().Unix() > payload.ExpiresAt {
+		return nil, errors.New("API key expired")
+	}
+	if r.revoked[payload.KeyID] {
+		return nil, errors.New("API key revoked")
+	}
+
+	return &models.Principal{
+		ClientID: payload.ClientID,
+		Scopes:   payload.Scopes,
+	}, nil
+}
+
+// SignApiKey mints an API key for clientId and returns it with its key id,
+// the value to list in the revocation list.
+func SignApiKey(secret string, clientId string, scopes []string, expiresAt time.Time) (string, string, error) {
+	if expiresAt.IsZero() {
+		return "", "", errors.New("API keys must expire")
+	}
+	keyId := make([]byte, 8)
+	if _, err := rand.Read(keyId); err != nil {
+		return "", "", err
+	}
+	payload := apiKeyPayload{
+		KeyID:     hex.EncodeToString(keyId),
+		ClientID:  clientId,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", err
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(rawPayload)
+	signature := base64.RawURLEncoding.EncodeToString(sign([]byte(secret), encodedPayload))
+
+	return encodedPayload + "." + signature, payload.KeyID, nil
+}
+
+func sign(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
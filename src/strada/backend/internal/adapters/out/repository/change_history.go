@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type ChangeHistoryRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewChangeHistoryRepository(db dbsqlc.DBTX) out.ChangeHistoryRepository {
+	return &ChangeHistoryRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+// fieldChangeJson is the shape stored in the changes JSONB column.
+type fieldChangeJson struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+func (r *ChangeHistoryRepository) Create(ctx context.Context, record *models.ChangeRecord) (*models.ChangeRecord, error) {
+	changes := make(map[string]fieldChangeJson, len(record.Changes))
+	for field, change := range record.Changes {
+		changes[field] = fieldChangeJson{From: change.From, To: change.To}
+	}
+	changesJson, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := queries(ctx, r.sqlc).CreateChangeRecord(ctx, dbsqlc.CreateChangeRecordParams{
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		Action:     record.Action,
+		ChangedBy:  record.ChangedBy,
+		Changes:    changesJson,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record.ID = row.ID
+	record.CreatedAt = row.CreatedAt.Time
+	return record, nil
+}
+
+func (r *ChangeHistoryRepository) FindByEntity(ctx context.Context, entityType string, entityId int32) ([]*models.ChangeRecord, error) {
+	rows, err := queries(ctx, r.sqlc).FindChangeRecordsByEntity(ctx, dbsqlc.FindChangeRecordsByEntityParams{
+		EntityType: entityType,
+		EntityID:   entityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.ChangeRecord, len(rows))
+	for i := range rows {
+		var changes map[string]fieldChangeJson
+		if err := json.Unmarshal(rows[i].Changes, &changes); err != nil {
+			return nil, err
+		}
+
+		records[i] = &models.ChangeRecord{
+			ID:         rows[i].ID,
+			EntityType: rows[i].EntityType,
+			EntityID:   rows[i].EntityID,
+			Action:     rows[i].Action,
+			ChangedBy:  rows[i].ChangedBy,
+			Changes:    make(map[string]models.FieldChange, len(changes)),
+			CreatedAt:  rows[i].CreatedAt.Time,
+		}
+		for field, change := range changes {
+			records[i].Changes[field] = models.FieldChange{From: change.From, To: change.To}
+		}
+	}
+	return records, nil
+}
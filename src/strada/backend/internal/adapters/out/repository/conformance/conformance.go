@@ -0,0 +1,106 @@
+// Package conformance checks that implementations of the repository ports
+// behave the same way. Every adapter runs the suites from its own tests:
+//
+//	func TestRideRepository(t *testing.T) {
+//		conformance.RunRideRepository(t, conformance.RideHarness{
+//			New: func(t *testing.T) out.RideRepository { return memory.NewRideRepository() },
+//		})
+//	}
+//
+// memory/memory_test.go runs every suite against the memory adapters and
+// repository/conformance_test.go against PostGIS when DATABASE_URL is set.
+package conformance
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// missingID is never assigned by the suites.
+const missingID int32 = math.MaxInt32 - 1
+
+// origin is the reference point of every fixture, in São Paulo.
+var origin = models.Location{Latitude: -23.5505, Longitude: -46.6333}
+
+const metersPerDegree = 111320.0
+
+// offset moves loc the given meters north and east.
+func offset(loc models.Location, north float64, east float64) models.Location {
+	return models.Location{
+		Latitude:  loc.Latitude + north/metersPerDegree,
+		Longitude: loc.Longitude + east/(metersPerDegree*math.Cos(loc.Latitude*math.Pi/180)),
+	}
+}
+
+// baseTime has no sub-microsecond part so it survives a Postgres timestamp.
+var baseTime = time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// sortedIDs orders ids by key, then id, the keyset every List follows.
+func sortedIDs[T any](items []T, id func(T) int32, compare func(a, b T) int, descending bool) []int32 {
+	items = slices.Clone(items)
+	slices.SortFunc(items, func(a, b T) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = cmp.Compare(id(a), id(b))
+		}
+		if descending {
+			return -c
+		}
+		return c
+	})
+	ids := make([]int32, len(items))
+	for i, item := range items {
+		ids[i] = id(item)
+	}
+	return ids
+}
+
+func idsOf[T any](items []T, id func(T) int32) []int32 {
+	ids := make([]int32, len(items))
+	for i, item := range items {
+		ids[i] = id(item)
+	}
+	return ids
+}
+
+// collectPages follows the cursors until List returns a short page.
+func collectPages[T any](t *testing.T, limit int, list func(after *models.Cursor, limit int) ([]T, error), cursorOf func(T) *models.Cursor, id func(T) int32) []int32 {
+	t.Helper()
+	var ids []int32
+	var after *models.Cursor
+	for range 100 {
+		items, err := list(after, limit)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(items) > limit {
+			t.Fatalf("List returned %d items, limit is %d", len(items), limit)
+		}
+		ids = append(ids, idsOf(items, id)...)
+		if len(items) < limit {
+			return ids
+		}
+		after = cursorOf(items[len(items)-1])
+	}
+	t.Fatal("List did not reach the last page")
+	return nil
+}
+
+func assertIDs(t *testing.T, what string, got []int32, want []int32) {
+	t.Helper()
+	if !slices.Equal(got, want) {
+		t.Errorf("%s ids = %v, want %v", what, got, want)
+	}
+}
+
+func order(descending bool) string {
+	if descending {
+		return "Desc"
+	}
+	return "Asc"
+}
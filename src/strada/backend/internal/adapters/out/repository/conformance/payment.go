@@ -0,0 +1,176 @@
+package conformance
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type PaymentHarness struct {
+	// New returns a repository without payments. It is called for every
+	// subtest.
+	New func(t *testing.T) out.PaymentRepository
+	// RideID is stored on every payment; PostgreSQL needs an existing
+	// tb_rides row.
+	RideID int32
+}
+
+// RunPaymentRepository checks the out.PaymentRepository contract.
+func RunPaymentRepository(t *testing.T, h PaymentHarness) {
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repo := h.New(t)
+		want := h.charge("passenger-1")
+		created, err := repo.Create(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == 0 || created.CreatedAt.IsZero() {
+			t.Fatalf("Create did not assign id and timestamps: %+v", created)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if got.RideID != want.RideID || got.PassengerID != want.PassengerID || got.DriverID != want.DriverID ||
+			got.Kind != want.Kind || got.Amount != want.Amount || got.Status != want.Status || got.Provider != want.Provider {
+			t.Errorf("payment = %+v, want %+v", got, want)
+		}
+
+		if _, err := repo.FindById(t.Context(), missingID); !errors.Is(err, models.ErrPaymentNotFound) {
+			t.Errorf("FindById missing error = %v, want ErrPaymentNotFound", err)
+		}
+	})
+
+	t.Run("OneActiveChargePerPassenger", func(t *testing.T) {
+		repo := h.New(t)
+		first := h.mustCreate(t, repo, h.charge("passenger-1"))
+		if _, err := repo.Create(t.Context(), h.charge("passenger-1")); !errors.Is(err, models.ErrPaymentExists) {
+			t.Fatalf("second charge error = %v, want ErrPaymentExists", err)
+		}
+		h.mustCreate(t, repo, h.charge("passenger-2"))
+
+		first.Status = models.PaymentStatusFailed
+		if _, err := repo.Update(t.Context(), first); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		h.mustCreate(t, repo, h.charge("passenger-1"))
+	})
+
+	t.Run("OnePayoutPerCharge", func(t *testing.T) {
+		repo := h.New(t)
+		charge := h.mustCreate(t, repo, h.charge("passenger-1"))
+		payout := h.charge("passenger-1")
+		payout.Kind = models.PaymentKindPayout
+		payout.ParentID = charge.ID
+		h.mustCreate(t, repo, payout)
+		if _, err := repo.Create(t.Context(), payout); !errors.Is(err, models.ErrPaymentExists) {
+			t.Fatalf("second payout error = %v, want ErrPaymentExists", err)
+		}
+
+		refund := h.charge("passenger-1")
+		refund.Kind = models.PaymentKindRefund
+		refund.ParentID = charge.ID
+		h.mustCreate(t, repo, refund)
+		h.mustCreate(t, repo, refund)
+
+		settlements, err := repo.FindByParent(t.Context(), charge.ID)
+		if err != nil {
+			t.Fatalf("FindByParent: %v", err)
+		}
+		if len(settlements) != 3 || settlements[0].Kind != models.PaymentKindPayout {
+			t.Errorf("settlements = %+v, want the payout then two refunds", settlements)
+		}
+
+		ledger, err := repo.FindByRide(t.Context(), h.RideID)
+		if err != nil {
+			t.Fatalf("FindByRide: %v", err)
+		}
+		if len(ledger) != 4 || ledger[0].ID != charge.ID {
+			t.Errorf("ledger has %d entries starting at %d, want 4 starting at %d", len(ledger), ledger[0].ID, charge.ID)
+		}
+
+		ledger, err = repo.FindByRides(t.Context(), []int32{missingID, h.RideID})
+		if err != nil {
+			t.Fatalf("FindByRides: %v", err)
+		}
+		if len(ledger) != 4 {
+			t.Errorf("FindByRides returned %d entries, want 4", len(ledger))
+		}
+		if ledger, err = repo.FindByRides(t.Context(), []int32{missingID}); err != nil || len(ledger) != 0 {
+			t.Errorf("FindByRides of a ride without payments = %v, %v; want none", ledger, err)
+		}
+	})
+
+	t.Run("UpdateAndFindByProviderRef", func(t *testing.T) {
+		repo := h.New(t)
+		charge := h.mustCreate(t, repo, h.charge("passenger-1"))
+		if _, err := repo.FindByProviderRef(t.Context(), charge.Provider, ""); !errors.Is(err, models.ErrPaymentNotFound) {
+			t.Errorf("FindByProviderRef without reference error = %v, want ErrPaymentNotFound", err)
+		}
+
+		charge.ProviderRef = "ref-1"
+		charge.Status = models.PaymentStatusFailed
+		charge.FailureReason = "card declined"
+		updated, err := repo.Update(t.Context(), charge)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.Status != charge.Status || updated.ProviderRef != "ref-1" || updated.FailureReason != "card declined" {
+			t.Errorf("updated = %+v", updated)
+		}
+
+		got, err := repo.FindByProviderRef(t.Context(), charge.Provider, "ref-1")
+		if err != nil {
+			t.Fatalf("FindByProviderRef: %v", err)
+		}
+		if got.ID != charge.ID {
+			t.Errorf("FindByProviderRef = %d, want %d", got.ID, charge.ID)
+		}
+		if _, err := repo.FindByProviderRef(t.Context(), "other", "ref-1"); !errors.Is(err, models.ErrPaymentNotFound) {
+			t.Errorf("FindByProviderRef other provider error = %v, want ErrPaymentNotFound", err)
+		}
+	})
+
+	t.Run("RecordEventOnce", func(t *testing.T) {
+		repo := h.New(t)
+		charge := h.mustCreate(t, repo, h.charge("passenger-1"))
+		event := &models.PaymentEvent{
+			Provider:      charge.Provider,
+			EventID:       "event-1",
+			PaymentUpdate: models.PaymentUpdate{Status: models.PaymentStatusSucceeded},
+		}
+		for i, want := range []bool{true, false} {
+			recorded, err := repo.RecordEvent(t.Context(), event, charge.ID)
+			if err != nil {
+				t.Fatalf("RecordEvent: %v", err)
+			}
+			if recorded != want {
+				t.Errorf("delivery %d recorded = %v, want %v", i+1, recorded, want)
+			}
+		}
+	})
+}
+
+func (h PaymentHarness) charge(passengerId string) *models.Payment {
+	return &models.Payment{
+		RideID:      h.RideID,
+		PassengerID: passengerId,
+		DriverID:    "driver-1",
+		Kind:        models.PaymentKindCharge,
+		Amount:      models.NewMoney(1250, "BRL"),
+		Status:      models.PaymentStatusPending,
+		Provider:    "fake",
+	}
+}
+
+func (h PaymentHarness) mustCreate(t *testing.T, repo out.PaymentRepository, payment *models.Payment) *models.Payment {
+	t.Helper()
+	created, err := repo.Create(t.Context(), payment)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return created
+}
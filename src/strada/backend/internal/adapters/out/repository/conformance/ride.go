@@ -0,0 +1,615 @@
+package conformance
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type RideHarness struct {
+	// New returns a repository without rides. It is called for every subtest.
+	New func(t *testing.T) out.RideRepository
+	// VehicleID is stored on every ride; PostGIS needs an existing
+	// tb_vehicles row.
+	VehicleID int32
+	// SchoolID is the school rides go to in the FindNearSchool subtest, an
+	// existing tb_schools row for PostGIS. The subtest is skipped when 0.
+	SchoolID int32
+}
+
+// RunRideRepository checks the out.RideRepository contract.
+func RunRideRepository(t *testing.T, h RideHarness) {
+	t.Run("CreateAndFindById", func(t *testing.T) {
+		repo := h.New(t)
+		want := h.ride("driver-1", origin, offset(origin, 3000, 4000))
+		want.StopPoints = []models.Location{offset(origin, 1000, 1000), offset(origin, 2000, 2500)}
+
+		created, err := repo.Create(t.Context(), cloneRide(want))
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == 0 {
+			t.Fatal("Create did not assign an id")
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		assertRide(t, got, want)
+		if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+			t.Errorf("timestamps not set: created %v, updated %v", got.CreatedAt, got.UpdatedAt)
+		}
+	})
+
+	t.Run("FindByIdMissing", func(t *testing.T) {
+		repo := h.New(t)
+		if _, err := repo.FindById(t.Context(), missingID); !errors.Is(err, models.ErrRideNotFound) {
+			t.Fatalf("FindById error = %v, want ErrRideNotFound", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := h.New(t)
+		created := h.mustCreate(t, repo, h.ride("driver-1", origin, offset(origin, 1000, 0)))
+		stored, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+
+		want := h.ride("driver-2", offset(origin, -500, 200), offset(origin, 8000, -3000))
+		want.StopPoints = []models.Location{offset(origin, 4000, -1500)}
+		want.DistanceMeters = 9876.5
+		want.Cost = models.NewMoney(4250, "USD")
+		want.CostCeiling = models.NewMoney(6000, "USD")
+		want.MaxDetourMeters = 1500
+		want.MaxDetourMs = 300000
+		want.Co2SavedKg = 1.25
+		want.CompletedAt = baseTime.Add(90 * time.Minute)
+		want.Description = "updated"
+		want.ImgUrl = "https://example.com/updated.png"
+		want.Version = stored.Version
+
+		updated, err := repo.Update(t.Context(), created.ID, cloneRide(want))
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.ID != created.ID {
+			t.Errorf("Update returned id %d, want %d", updated.ID, created.ID)
+		}
+		assertRide(t, updated, want)
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		assertRide(t, got, want)
+		if !got.CreatedAt.Equal(stored.CreatedAt) {
+			t.Errorf("CreatedAt changed from %v to %v", stored.CreatedAt, got.CreatedAt)
+		}
+		if got.UpdatedAt.Before(stored.UpdatedAt) {
+			t.Errorf("UpdatedAt went back from %v to %v", stored.UpdatedAt, got.UpdatedAt)
+		}
+		if updated.Version != stored.Version+1 || got.Version != stored.Version+1 {
+			t.Errorf("version after Update = %d (returned %d), want %d", got.Version, updated.Version, stored.Version+1)
+		}
+	})
+
+	t.Run("UpdateStaleVersion", func(t *testing.T) {
+		repo := h.New(t)
+		created := h.mustCreate(t, repo, h.ride("driver-1", origin, origin))
+		stored, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+
+		first := cloneRide(stored)
+		first.Description = "first"
+		if _, err := repo.Update(t.Context(), created.ID, first); err != nil {
+			t.Fatalf("first Update: %v", err)
+		}
+		second := cloneRide(stored)
+		second.Description = "second"
+		if _, err := repo.Update(t.Context(), created.ID, second); !errors.Is(err, models.ErrVersionConflict) {
+			t.Fatalf("stale Update error = %v, want ErrVersionConflict", err)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if got.Description != "first" || got.Version != stored.Version+1 {
+			t.Errorf("after stale Update description/version = %q/%d, want %q/%d", got.Description, got.Version, "first", stored.Version+1)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		repo := h.New(t)
+		_, err := repo.Update(t.Context(), missingID, h.ride("driver-1", origin, origin))
+		if !errors.Is(err, models.ErrRideNotFound) {
+			t.Fatalf("Update error = %v, want ErrRideNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := h.New(t)
+		deleted := h.mustCreate(t, repo, h.ride("driver-1", origin, offset(origin, 2000, 0)))
+		kept := h.mustCreate(t, repo, h.ride("driver-1", origin, offset(origin, 2000, 0)))
+		passenger := &models.RidePassenger{RideID: deleted.ID, UserID: "passenger-1", StartPoint: origin, EndPoint: origin, Role: models.RideRolePassenger}
+		if err := repo.AddPassenger(t.Context(), passenger); err != nil {
+			t.Fatalf("AddPassenger: %v", err)
+		}
+		stops := []*models.RideStop{{Kind: models.RideStopStart, Location: origin}, {Kind: models.RideStopEnd, Location: offset(origin, 2000, 0), DistanceMeters: 2000}}
+		if err := repo.ReplaceStops(t.Context(), deleted.ID, stops); err != nil {
+			t.Fatalf("ReplaceStops: %v", err)
+		}
+
+		if err := repo.Delete(t.Context(), deleted.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindById(t.Context(), deleted.ID); !errors.Is(err, models.ErrRideNotFound) {
+			t.Errorf("FindById after Delete error = %v, want ErrRideNotFound", err)
+		}
+		if err := repo.Delete(t.Context(), deleted.ID); !errors.Is(err, models.ErrRideNotFound) {
+			t.Errorf("second Delete error = %v, want ErrRideNotFound", err)
+		}
+		if _, err := repo.Update(t.Context(), deleted.ID, h.ride("driver-1", origin, origin)); !errors.Is(err, models.ErrRideNotFound) {
+			t.Errorf("Update after Delete error = %v, want ErrRideNotFound", err)
+		}
+
+		want := []int32{kept.ID}
+		all, err := repo.FindAll(t.Context())
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		assertIDs(t, "FindAll", idsOf(all, rideID), want)
+
+		near, err := repo.FindNear(t.Context(), []*models.Location{&origin}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear", idsOf(near, rideID), want)
+
+		listed, err := repo.List(t.Context(), models.RideFilter{SortBy: models.RideSortCreatedAt}, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, "List", idsOf(listed, rideID), want)
+
+		byUser, err := repo.FindByUser(t.Context(), "driver-1")
+		if err != nil {
+			t.Fatalf("FindByUser: %v", err)
+		}
+		assertIDs(t, "FindByUser", idsOf(byUser, rideID), want)
+		joined, err := repo.FindByUser(t.Context(), "passenger-1")
+		if err != nil {
+			t.Fatalf("FindByUser passenger: %v", err)
+		}
+		assertIDs(t, "FindByUser passenger", idsOf(joined, rideID), nil)
+
+		// Deletes are soft, the ride's passengers and stops are kept.
+		passengers, err := repo.FindPassengers(t.Context(), []int32{deleted.ID})
+		if err != nil {
+			t.Fatalf("FindPassengers: %v", err)
+		}
+		if len(passengers) != 1 || passengers[0].UserID != "passenger-1" {
+			t.Errorf("FindPassengers after Delete = %+v, want passenger-1", passengers)
+		}
+		keptStops, err := repo.FindStops(t.Context(), deleted.ID)
+		if err != nil {
+			t.Fatalf("FindStops: %v", err)
+		}
+		if len(keptStops) != len(stops) {
+			t.Errorf("FindStops after Delete = %d stops, want %d", len(keptStops), len(stops))
+		}
+	})
+
+	t.Run("FindNear", func(t *testing.T) {
+		repo := h.New(t)
+		far := offset(origin, 20000, 20000)
+		start := h.mustCreate(t, repo, h.ride("driver-1", offset(origin, 300, 0), far))
+		end := h.mustCreate(t, repo, h.ride("driver-1", far, offset(origin, 0, 600)))
+		stopRide := h.ride("driver-1", far, far)
+		stopRide.StopPoints = []models.Location{offset(origin, 0, -700)}
+		stop := h.mustCreate(t, repo, stopRide)
+		h.mustCreate(t, repo, h.ride("driver-1", far, far))
+		h.mustCreate(t, repo, h.ride("driver-1", offset(origin, 1500, 0), far))
+		completed := h.ride("driver-1", offset(origin, 100, 0), far)
+		completed.CompletedAt = baseTime
+		h.mustCreate(t, repo, completed)
+
+		destination := offset(origin, 0, 10000)
+		rides, err := repo.FindNear(t.Context(), []*models.Location{&origin, &destination}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		got := idsOf(rides, rideID)
+		slices.Sort(got)
+		assertIDs(t, "FindNear", got, []int32{start.ID, end.ID, stop.ID})
+		if len(rides) > 0 && rides[0].ID != start.ID {
+			t.Errorf("FindNear first ride = %d, want the nearest start point %d", rides[0].ID, start.ID)
+		}
+
+		rides, err = repo.FindNear(t.Context(), nil, 0)
+		if err != nil {
+			t.Fatalf("FindNear without route: %v", err)
+		}
+		if len(rides) != 0 {
+			t.Errorf("FindNear without route returned %d rides, want none", len(rides))
+		}
+	})
+
+	t.Run("FindNearSchool", func(t *testing.T) {
+		if h.SchoolID == 0 {
+			t.Skip("no SchoolID")
+		}
+		repo := h.New(t)
+		far := offset(origin, 20000, 20000)
+		near := h.mustCreate(t, repo, h.ride("driver-1", offset(origin, 300, 0), far))
+		schoolRide := h.ride("driver-1", far, offset(far, 500, 0))
+		schoolRide.SchoolID = h.SchoolID
+		school := h.mustCreate(t, repo, schoolRide)
+		h.mustCreate(t, repo, h.ride("driver-1", far, offset(far, 500, 0)))
+
+		rides, err := repo.FindNear(t.Context(), []*models.Location{&origin}, h.SchoolID)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear with school", idsOf(rides, rideID), []int32{near.ID, school.ID})
+		if len(rides) == 2 && rides[1].SchoolID != h.SchoolID {
+			t.Errorf("school id = %d, want %d", rides[1].SchoolID, h.SchoolID)
+		}
+
+		rides, err = repo.FindNear(t.Context(), []*models.Location{&origin}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear without school", idsOf(rides, rideID), []int32{near.ID})
+	})
+
+	t.Run("FindByUser", func(t *testing.T) {
+		repo := h.New(t)
+		first := h.mustCreate(t, repo, h.ride("driver-1", origin, origin))
+		h.mustCreate(t, repo, h.ride("driver-2", origin, origin))
+		second := h.mustCreate(t, repo, h.ride("driver-1", origin, origin))
+
+		rides, err := repo.FindByUser(t.Context(), "driver-1")
+		if err != nil {
+			t.Fatalf("FindByUser: %v", err)
+		}
+		got := idsOf(rides, rideID)
+		slices.Sort(got)
+		assertIDs(t, "FindByUser", got, []int32{first.ID, second.ID})
+	})
+
+	t.Run("Passengers", func(t *testing.T) {
+		repo := h.New(t)
+		ride := h.mustCreate(t, repo, h.ride("driver-1", origin, offset(origin, 5000, 0)))
+		other := h.mustCreate(t, repo, h.ride("driver-2", origin, origin))
+
+		for _, userID := range []string{"passenger-1", "passenger-2"} {
+			err := repo.AddPassenger(t.Context(), &models.RidePassenger{
+				RideID:         ride.ID,
+				UserID:         userID,
+				StartPoint:     origin,
+				EndPoint:       offset(origin, 2000, 0),
+				Role:           models.RideRolePassenger,
+				DistanceMeters: 2000,
+			})
+			if err != nil {
+				t.Fatalf("AddPassenger(%s): %v", userID, err)
+			}
+			// Keep the join times apart, FindPassengers orders by them.
+			time.Sleep(2 * time.Millisecond)
+		}
+		duplicate := &models.RidePassenger{RideID: ride.ID, UserID: "passenger-1", StartPoint: origin, EndPoint: origin, Role: models.RideRolePassenger}
+		if err := repo.AddPassenger(t.Context(), duplicate); !errors.Is(err, models.ErrAlreadyJoined) {
+			t.Fatalf("duplicate AddPassenger error = %v, want ErrAlreadyJoined", err)
+		}
+
+		fares := []*models.PassengerFare{
+			{UserID: "passenger-1", DistanceMeters: 2100, Fare: models.NewMoney(126, models.DefaultCurrency)},
+			{UserID: "passenger-2", DistanceMeters: 900.5, Fare: models.NewMoney(54, models.DefaultCurrency)},
+		}
+		if err := repo.UpdatePassengerFares(t.Context(), ride.ID, fares); err != nil {
+			t.Fatalf("UpdatePassengerFares: %v", err)
+		}
+		emissions := []*models.PassengerEmission{{UserID: "passenger-1", SavedKg: 0.312}, {UserID: "passenger-2", SavedKg: 0.134}}
+		if err := repo.UpdatePassengerEmissions(t.Context(), ride.ID, emissions); err != nil {
+			t.Fatalf("UpdatePassengerEmissions: %v", err)
+		}
+		passengers, err := repo.FindPassengers(t.Context(), []int32{ride.ID})
+		if err != nil {
+			t.Fatalf("FindPassengers: %v", err)
+		}
+		if len(passengers) != len(fares) {
+			t.Fatalf("FindPassengers returned %d passengers, want %d", len(passengers), len(fares))
+		}
+		for i, passenger := range passengers {
+			want := fares[i]
+			if passenger.UserID != want.UserID || passenger.DistanceMeters != want.DistanceMeters || passenger.Fare != want.Fare {
+				t.Errorf("passenger %d = %s/%v/%+v, want %s/%v/%+v", i,
+					passenger.UserID, passenger.DistanceMeters, passenger.Fare, want.UserID, want.DistanceMeters, want.Fare)
+			}
+			if passenger.Co2SavedKg != emissions[i].SavedKg {
+				t.Errorf("passenger %d co2 saved = %v, want %v", i, passenger.Co2SavedKg, emissions[i].SavedKg)
+			}
+			if passenger.RideID != ride.ID || passenger.Role != models.RideRolePassenger || passenger.CreatedAt.IsZero() {
+				t.Errorf("passenger %d ride/role/created = %d/%s/%v", i, passenger.RideID, passenger.Role, passenger.CreatedAt)
+			}
+		}
+
+		rides, err := repo.FindByUser(t.Context(), "passenger-2")
+		if err != nil {
+			t.Fatalf("FindByUser: %v", err)
+		}
+		assertIDs(t, "FindByUser passenger", idsOf(rides, rideID), []int32{ride.ID})
+		rides, err = repo.List(t.Context(), models.RideFilter{SortBy: models.RideSortCreatedAt, PassengerID: "passenger-1"}, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, "passenger filter", idsOf(rides, rideID), []int32{ride.ID})
+
+		if err := repo.RemovePassenger(t.Context(), ride.ID, "passenger-1"); err != nil {
+			t.Fatalf("RemovePassenger: %v", err)
+		}
+		if err := repo.RemovePassenger(t.Context(), ride.ID, "passenger-1"); !errors.Is(err, models.ErrPassengerNotFound) {
+			t.Fatalf("second RemovePassenger error = %v, want ErrPassengerNotFound", err)
+		}
+		if err := repo.RemovePassenger(t.Context(), other.ID, "passenger-2"); !errors.Is(err, models.ErrPassengerNotFound) {
+			t.Fatalf("RemovePassenger from another ride error = %v, want ErrPassengerNotFound", err)
+		}
+		passengers, err = repo.FindPassengers(t.Context(), []int32{ride.ID})
+		if err != nil {
+			t.Fatalf("FindPassengers: %v", err)
+		}
+		if len(passengers) != 1 || passengers[0].UserID != "passenger-2" {
+			t.Errorf("passengers after RemovePassenger = %v, want only passenger-2", passengers)
+		}
+	})
+
+	t.Run("Stops", func(t *testing.T) {
+		repo := h.New(t)
+		ride := h.mustCreate(t, repo, h.ride("driver-1", origin, offset(origin, 3000, 0)))
+		stops, err := repo.FindStops(t.Context(), ride.ID)
+		if err != nil {
+			t.Fatalf("FindStops: %v", err)
+		}
+		if len(stops) != 0 {
+			t.Fatalf("stops before ReplaceStops = %d, want none", len(stops))
+		}
+
+		want := []*models.RideStop{
+			{Kind: models.RideStopStart, Location: origin},
+			{Kind: models.RideStopPickup, UserID: "passenger-1", Location: offset(origin, 1000, 0), DistanceMeters: 1000, EtaMs: 120000},
+			{Kind: models.RideStopDropoff, UserID: "passenger-1", Location: offset(origin, 2000, 0), DistanceMeters: 2000, EtaMs: 240000},
+			{Kind: models.RideStopEnd, Location: offset(origin, 3000, 0), DistanceMeters: 3000, EtaMs: 360000},
+		}
+		if err := repo.ReplaceStops(t.Context(), ride.ID, want[1:]); err != nil {
+			t.Fatalf("ReplaceStops: %v", err)
+		}
+		if err := repo.ReplaceStops(t.Context(), ride.ID, want); err != nil {
+			t.Fatalf("second ReplaceStops: %v", err)
+		}
+		got, err := repo.FindStops(t.Context(), ride.ID)
+		if err != nil {
+			t.Fatalf("FindStops: %v", err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("stops = %d, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if *got[i] != *want[i] {
+				t.Errorf("stop %d = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("FindCompleted", func(t *testing.T) {
+		repo := h.New(t)
+		complete := func(driverID string, completedAt time.Time) *models.Ride {
+			ride := h.ride(driverID, origin, origin)
+			ride.CompletedAt = completedAt
+			return h.mustCreate(t, repo, ride)
+		}
+		early := complete("driver-1", baseTime.Add(time.Hour))
+		late := complete("driver-1", baseTime.Add(3*time.Hour))
+		joined := complete("driver-2", baseTime.Add(2*time.Hour))
+		complete("driver-3", baseTime.Add(2*time.Hour))
+		h.mustCreate(t, repo, h.ride("driver-1", origin, origin))
+		err := repo.AddPassenger(t.Context(), &models.RidePassenger{
+			RideID: joined.ID, UserID: "passenger-1", StartPoint: origin, EndPoint: origin, Role: models.RideRolePassenger,
+		})
+		if err != nil {
+			t.Fatalf("AddPassenger: %v", err)
+		}
+
+		find := func(userIds []string, from time.Time, to time.Time) []int32 {
+			rides, err := repo.FindCompleted(t.Context(), userIds, from, to)
+			if err != nil {
+				t.Fatalf("FindCompleted: %v", err)
+			}
+			return idsOf(rides, rideID)
+		}
+		assertIDs(t, "driver and passenger", find([]string{"driver-1", "passenger-1"}, time.Time{}, time.Time{}),
+			[]int32{early.ID, joined.ID, late.ID})
+		assertIDs(t, "period", find([]string{"driver-1", "passenger-1"}, baseTime.Add(time.Hour+time.Minute), baseTime.Add(3*time.Hour)),
+			[]int32{joined.ID})
+		assertIDs(t, "unknown user", find([]string{"nobody"}, time.Time{}, time.Time{}), nil)
+	})
+
+	t.Run("ListPagination", func(t *testing.T) {
+		repo := h.New(t)
+		// Repeated costs and distances check that ties are broken by id.
+		costs := []int64{1500, 900, 1500, 0, 2200, 900, 3100}
+		distances := []float64{3000, 1200.5, 800, 3000, 5400.25, 1200.5, 100}
+		for i := range costs {
+			ride := h.ride("driver-1", origin, origin)
+			ride.Cost = models.NewMoney(costs[i], models.DefaultCurrency)
+			ride.DistanceMeters = distances[i]
+			h.mustCreate(t, repo, ride)
+		}
+		stored, err := repo.FindAll(t.Context())
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+
+		sorts := map[string]func(a, b *models.Ride) int{
+			models.RideSortCreatedAt: func(a, b *models.Ride) int { return a.CreatedAt.Compare(b.CreatedAt) },
+			models.RideSortCost:      func(a, b *models.Ride) int { return cmp.Compare(a.Cost.Amount, b.Cost.Amount) },
+			models.RideSortDistance:  func(a, b *models.Ride) int { return cmp.Compare(a.DistanceMeters, b.DistanceMeters) },
+		}
+		for sortBy, compare := range sorts {
+			for _, descending := range []bool{false, true} {
+				t.Run(sortBy+order(descending), func(t *testing.T) {
+					filter := models.RideFilter{SortBy: sortBy, Descending: descending}
+					got := collectPages(t, 3, func(after *models.Cursor, limit int) ([]*models.Ride, error) {
+						return repo.List(t.Context(), filter, after, limit)
+					}, func(ride *models.Ride) *models.Cursor {
+						return &models.Cursor{SortBy: sortBy, Descending: descending, Value: rideCursorValue(ride, sortBy), ID: ride.ID}
+					}, rideID)
+					assertIDs(t, "List", got, sortedIDs(stored, rideID, compare, descending))
+				})
+			}
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		repo := h.New(t)
+		inside := offset(origin, 100, 100)
+		outside := offset(origin, 30000, 30000)
+		var created []*models.Ride
+		for i, driver := range []string{"driver-1", "driver-2", "driver-1", "driver-1", "driver-2"} {
+			start, end := outside, outside
+			if i%2 == 0 {
+				start = inside
+			}
+			if i == 3 {
+				end = inside
+			}
+			created = append(created, h.mustCreate(t, repo, h.ride(driver, start, end)))
+			// Keep the creation times apart for the date range below.
+			time.Sleep(2 * time.Millisecond)
+		}
+		stored := make([]*models.Ride, len(created))
+		for i, ride := range created {
+			var err error
+			if stored[i], err = repo.FindById(t.Context(), ride.ID); err != nil {
+				t.Fatalf("FindById: %v", err)
+			}
+		}
+
+		list := func(filter models.RideFilter) []int32 {
+			filter.SortBy = models.RideSortCreatedAt
+			rides, err := repo.List(t.Context(), filter, nil, 10)
+			if err != nil {
+				t.Fatalf("List(%+v): %v", filter, err)
+			}
+			return idsOf(rides, rideID)
+		}
+		box := &models.BoundingBox{
+			MinLongitude: origin.Longitude,
+			MinLatitude:  origin.Latitude,
+			MaxLongitude: offset(origin, 0, 1000).Longitude,
+			MaxLatitude:  offset(origin, 1000, 0).Latitude,
+		}
+
+		assertIDs(t, "driver filter", list(models.RideFilter{DriverID: "driver-2"}),
+			[]int32{stored[1].ID, stored[4].ID})
+		assertIDs(t, "date range", list(models.RideFilter{From: stored[1].CreatedAt, To: stored[3].CreatedAt}),
+			[]int32{stored[1].ID, stored[2].ID})
+		assertIDs(t, "bounding box", list(models.RideFilter{BoundingBox: box}),
+			[]int32{stored[0].ID, stored[2].ID, stored[3].ID, stored[4].ID})
+		assertIDs(t, "combined", list(models.RideFilter{DriverID: "driver-1", BoundingBox: box, From: stored[2].CreatedAt}),
+			[]int32{stored[2].ID, stored[3].ID})
+	})
+
+	t.Run("ListInvalidSort", func(t *testing.T) {
+		repo := h.New(t)
+		_, err := repo.List(t.Context(), models.RideFilter{SortBy: "driver"}, nil, 10)
+		if !errors.Is(err, models.ErrInvalidSort) {
+			t.Fatalf("List error = %v, want ErrInvalidSort", err)
+		}
+	})
+}
+
+func (h RideHarness) ride(driverID string, start models.Location, end models.Location) *models.Ride {
+	return &models.Ride{
+		DriverID:        driverID,
+		VehicleID:       h.VehicleID,
+		StartPoint:      start,
+		EndPoint:        end,
+		DistanceMeters:  1234.5,
+		EstimatedTimeMs: 600000,
+		Co2EmissionKg:   0.75,
+		Cost:            models.NewMoney(2500, models.DefaultCurrency),
+		Description:     "ride",
+		ImgUrl:          "https://example.com/ride.png",
+	}
+}
+
+func (h RideHarness) mustCreate(t *testing.T, repo out.RideRepository, ride *models.Ride) *models.Ride {
+	t.Helper()
+	created, err := repo.Create(t.Context(), ride)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return created
+}
+
+// rideCursorValue formats the sort value like services.RideService.List.
+func rideCursorValue(ride *models.Ride, sortBy string) string {
+	switch sortBy {
+	case models.RideSortCost:
+		return ride.Cost.String()
+	case models.RideSortDistance:
+		return strconv.FormatFloat(ride.DistanceMeters, 'f', -1, 64)
+	}
+	return ride.CreatedAt.Format(time.RFC3339Nano)
+}
+
+func rideID(ride *models.Ride) int32 { return ride.ID }
+
+func cloneRide(ride *models.Ride) *models.Ride {
+	c := *ride
+	c.StopPoints = slices.Clone(ride.StopPoints)
+	return &c
+}
+
+func assertRide(t *testing.T, got *models.Ride, want *models.Ride) {
+	t.Helper()
+	if got.DriverID != want.DriverID || got.VehicleID != want.VehicleID {
+		t.Errorf("driver/vehicle = %q/%d, want %q/%d", got.DriverID, got.VehicleID, want.DriverID, want.VehicleID)
+	}
+	if got.StartPoint != want.StartPoint || got.EndPoint != want.EndPoint {
+		t.Errorf("start/end = %v/%v, want %v/%v", got.StartPoint, got.EndPoint, want.StartPoint, want.EndPoint)
+	}
+	if !slices.Equal(got.StopPoints, want.StopPoints) {
+		t.Errorf("stop points = %v, want %v", got.StopPoints, want.StopPoints)
+	}
+	if got.DistanceMeters != want.DistanceMeters || got.EstimatedTimeMs != want.EstimatedTimeMs || got.Co2EmissionKg != want.Co2EmissionKg {
+		t.Errorf("distance/time/co2 = %v/%v/%v, want %v/%v/%v",
+			got.DistanceMeters, got.EstimatedTimeMs, got.Co2EmissionKg, want.DistanceMeters, want.EstimatedTimeMs, want.Co2EmissionKg)
+	}
+	if got.Co2SavedKg != want.Co2SavedKg {
+		t.Errorf("co2 saved = %v, want %v", got.Co2SavedKg, want.Co2SavedKg)
+	}
+	if !got.CompletedAt.Equal(want.CompletedAt) {
+		t.Errorf("completed at = %v, want %v", got.CompletedAt, want.CompletedAt)
+	}
+	if got.Cost != want.Cost || got.CostCeiling != want.CostCeiling {
+		t.Errorf("cost/ceiling = %+v/%+v, want %+v/%+v", got.Cost, got.CostCeiling, want.Cost, want.CostCeiling)
+	}
+	if got.MaxDetourMeters != want.MaxDetourMeters || got.MaxDetourMs != want.MaxDetourMs {
+		t.Errorf("max detour = %v/%v, want %v/%v", got.MaxDetourMeters, got.MaxDetourMs, want.MaxDetourMeters, want.MaxDetourMs)
+	}
+	if got.Description != want.Description || got.ImgUrl != want.ImgUrl {
+		t.Errorf("description/img = %q/%q, want %q/%q", got.Description, got.ImgUrl, want.Description, want.ImgUrl)
+	}
+}
@@ -0,0 +1,350 @@
+package conformance
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type RideRequestHarness struct {
+	// New returns a repository without ride requests. It is called for every
+	// subtest.
+	New func(t *testing.T) out.RideRequestRepository
+	// SchoolID is the school ride requests go to in the FindNearSchool
+	// subtest, an existing tb_schools row for PostGIS. The subtest is skipped
+	// when 0.
+	SchoolID int32
+}
+
+// RunRideRequestRepository checks the out.RideRequestRepository contract.
+func RunRideRequestRepository(t *testing.T, h RideRequestHarness) {
+	t.Run("CreateAndFindById", func(t *testing.T) {
+		repo := h.New(t)
+		want := rideRequest("passenger-1", origin, offset(origin, 3000, -4000), baseTime)
+
+		created, err := repo.Create(t.Context(), cloneRideRequest(want))
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == 0 {
+			t.Fatal("Create did not assign an id")
+		}
+		if created.Status != "pending" {
+			t.Errorf("Create status = %q, want pending", created.Status)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		want.Status = "pending"
+		assertRideRequest(t, got, want)
+	})
+
+	t.Run("FindByIdMissing", func(t *testing.T) {
+		repo := h.New(t)
+		if _, err := repo.FindById(t.Context(), missingID); !errors.Is(err, models.ErrRideRequestNotFound) {
+			t.Fatalf("FindById error = %v, want ErrRideRequestNotFound", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := h.New(t)
+		created := mustCreateRideRequest(t, repo, rideRequest("passenger-1", origin, offset(origin, 1000, 0), baseTime))
+
+		want := rideRequest("passenger-2", offset(origin, -800, 250), offset(origin, 6000, 6000), baseTime.Add(90*time.Minute))
+		want.Status = "cancelled"
+		want.Description = "updated"
+		want.ImgUrl = "https://example.com/updated.png"
+		want.Version = created.Version
+
+		updated, err := repo.Update(t.Context(), created.ID, cloneRideRequest(want))
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.ID != created.ID {
+			t.Errorf("Update returned id %d, want %d", updated.ID, created.ID)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		assertRideRequest(t, got, want)
+		if updated.Version != created.Version+1 || got.Version != created.Version+1 {
+			t.Errorf("version after Update = %d (returned %d), want %d", got.Version, updated.Version, created.Version+1)
+		}
+	})
+
+	t.Run("UpdateStaleVersion", func(t *testing.T) {
+		repo := h.New(t)
+		created := mustCreateRideRequest(t, repo, rideRequest("passenger-1", origin, origin, baseTime))
+		stored, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+
+		first := cloneRideRequest(stored)
+		first.Status = "accepted"
+		if _, err := repo.Update(t.Context(), created.ID, first); err != nil {
+			t.Fatalf("first Update: %v", err)
+		}
+		second := cloneRideRequest(stored)
+		second.Status = "cancelled"
+		if _, err := repo.Update(t.Context(), created.ID, second); !errors.Is(err, models.ErrVersionConflict) {
+			t.Fatalf("stale Update error = %v, want ErrVersionConflict", err)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if got.Status != "accepted" || got.Version != stored.Version+1 {
+			t.Errorf("after stale Update status/version = %q/%d, want %q/%d", got.Status, got.Version, "accepted", stored.Version+1)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		repo := h.New(t)
+		_, err := repo.Update(t.Context(), missingID, rideRequest("passenger-1", origin, origin, baseTime))
+		if !errors.Is(err, models.ErrRideRequestNotFound) {
+			t.Fatalf("Update error = %v, want ErrRideRequestNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := h.New(t)
+		deleted := mustCreateRideRequest(t, repo, rideRequest("passenger-1", origin, origin, baseTime))
+		kept := mustCreateRideRequest(t, repo, rideRequest("passenger-1", origin, origin, baseTime))
+
+		if err := repo.Delete(t.Context(), deleted.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := repo.FindById(t.Context(), deleted.ID); !errors.Is(err, models.ErrRideRequestNotFound) {
+			t.Errorf("FindById after Delete error = %v, want ErrRideRequestNotFound", err)
+		}
+		if err := repo.Delete(t.Context(), deleted.ID); !errors.Is(err, models.ErrRideRequestNotFound) {
+			t.Errorf("second Delete error = %v, want ErrRideRequestNotFound", err)
+		}
+
+		want := []int32{kept.ID}
+		all, err := repo.FindAll(t.Context())
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		assertIDs(t, "FindAll", idsOf(all, rideRequestID), want)
+
+		near, err := repo.FindNear(t.Context(), []*models.Location{&origin}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear", idsOf(near, rideRequestID), want)
+
+		listed, err := repo.List(t.Context(), models.RideRequestFilter{SortBy: models.RideRequestSortID}, nil, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		assertIDs(t, "List", idsOf(listed, rideRequestID), want)
+	})
+
+	t.Run("FindNear", func(t *testing.T) {
+		repo := h.New(t)
+		far := offset(origin, -20000, 20000)
+		byOrigin := mustCreateRideRequest(t, repo, rideRequest("passenger-1", offset(origin, 0, 400), far, baseTime))
+		byDestination := mustCreateRideRequest(t, repo, rideRequest("passenger-1", far, offset(origin, -900, 0), baseTime))
+		mustCreateRideRequest(t, repo, rideRequest("passenger-1", far, far, baseTime))
+		mustCreateRideRequest(t, repo, rideRequest("passenger-1", offset(origin, 0, 1800), far, baseTime))
+
+		rideRequests, err := repo.FindNear(t.Context(), []*models.Location{&origin}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		got := idsOf(rideRequests, rideRequestID)
+		slices.Sort(got)
+		assertIDs(t, "FindNear", got, []int32{byOrigin.ID, byDestination.ID})
+		if len(rideRequests) > 0 && rideRequests[0].ID != byOrigin.ID {
+			t.Errorf("FindNear first ride request = %d, want the nearest origin %d", rideRequests[0].ID, byOrigin.ID)
+		}
+
+		rideRequests, err = repo.FindNear(t.Context(), nil, 0)
+		if err != nil {
+			t.Fatalf("FindNear without route: %v", err)
+		}
+		if len(rideRequests) != 0 {
+			t.Errorf("FindNear without route returned %d ride requests, want none", len(rideRequests))
+		}
+	})
+
+	t.Run("FindNearSchool", func(t *testing.T) {
+		if h.SchoolID == 0 {
+			t.Skip("no SchoolID")
+		}
+		repo := h.New(t)
+		far := offset(origin, -20000, 20000)
+		near := mustCreateRideRequest(t, repo, rideRequest("passenger-1", offset(origin, 0, 400), far, baseTime))
+		schoolRequest := rideRequest("passenger-1", far, offset(far, 0, 500), baseTime)
+		schoolRequest.SchoolID = h.SchoolID
+		school := mustCreateRideRequest(t, repo, schoolRequest)
+		mustCreateRideRequest(t, repo, rideRequest("passenger-1", far, offset(far, 0, 500), baseTime))
+
+		rideRequests, err := repo.FindNear(t.Context(), []*models.Location{&origin}, h.SchoolID)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear with school", idsOf(rideRequests, rideRequestID), []int32{near.ID, school.ID})
+		if len(rideRequests) == 2 && rideRequests[1].SchoolID != h.SchoolID {
+			t.Errorf("school id = %d, want %d", rideRequests[1].SchoolID, h.SchoolID)
+		}
+
+		rideRequests, err = repo.FindNear(t.Context(), []*models.Location{&origin}, 0)
+		if err != nil {
+			t.Fatalf("FindNear: %v", err)
+		}
+		assertIDs(t, "FindNear without school", idsOf(rideRequests, rideRequestID), []int32{near.ID})
+	})
+
+	t.Run("ListPagination", func(t *testing.T) {
+		repo := h.New(t)
+		// Repeated datetimes check that ties are broken by id.
+		for _, minutes := range []int{30, 0, 30, 90, 15, 0, 45} {
+			mustCreateRideRequest(t, repo, rideRequest("passenger-1", origin, origin, baseTime.Add(time.Duration(minutes)*time.Minute)))
+		}
+		stored, err := repo.FindAll(t.Context())
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+
+		sorts := map[string]func(a, b *models.RideRequest) int{
+			models.RideRequestSortRideDatetime: func(a, b *models.RideRequest) int { return a.RideDatetime.Compare(b.RideDatetime) },
+			models.RideRequestSortID:           func(a, b *models.RideRequest) int { return cmp.Compare(a.ID, b.ID) },
+		}
+		for sortBy, compare := range sorts {
+			for _, descending := range []bool{false, true} {
+				t.Run(sortBy+order(descending), func(t *testing.T) {
+					filter := models.RideRequestFilter{SortBy: sortBy, Descending: descending}
+					got := collectPages(t, 3, func(after *models.Cursor, limit int) ([]*models.RideRequest, error) {
+						return repo.List(t.Context(), filter, after, limit)
+					}, func(rideRequest *models.RideRequest) *models.Cursor {
+						return &models.Cursor{SortBy: sortBy, Descending: descending, Value: rideRequestCursorValue(rideRequest, sortBy), ID: rideRequest.ID}
+					}, rideRequestID)
+					assertIDs(t, "List", got, sortedIDs(stored, rideRequestID, compare, descending))
+				})
+			}
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		repo := h.New(t)
+		inside := offset(origin, 100, 100)
+		outside := offset(origin, 30000, 30000)
+		fixtures := []struct {
+			passenger string
+			status    string
+			origin    models.Location
+			dest      models.Location
+		}{
+			{"passenger-1", "pending", inside, outside},
+			{"passenger-2", "pending", outside, outside},
+			{"passenger-1", "cancelled", outside, inside},
+			{"passenger-1", "pending", outside, outside},
+			{"passenger-2", "cancelled", inside, inside},
+		}
+		var ids []int32
+		for i, f := range fixtures {
+			created := mustCreateRideRequest(t, repo, rideRequest(f.passenger, f.origin, f.dest, baseTime.Add(time.Duration(i)*time.Hour)))
+			if f.status != "pending" {
+				created.Status = f.status
+				if _, err := repo.Update(t.Context(), created.ID, created); err != nil {
+					t.Fatalf("Update: %v", err)
+				}
+			}
+			ids = append(ids, created.ID)
+		}
+
+		list := func(filter models.RideRequestFilter) []int32 {
+			filter.SortBy = models.RideRequestSortRideDatetime
+			rideRequests, err := repo.List(t.Context(), filter, nil, 10)
+			if err != nil {
+				t.Fatalf("List(%+v): %v", filter, err)
+			}
+			return idsOf(rideRequests, rideRequestID)
+		}
+		box := &models.BoundingBox{
+			MinLongitude: origin.Longitude,
+			MinLatitude:  origin.Latitude,
+			MaxLongitude: offset(origin, 0, 1000).Longitude,
+			MaxLatitude:  offset(origin, 1000, 0).Latitude,
+		}
+
+		assertIDs(t, "passenger filter", list(models.RideRequestFilter{PassengerID: "passenger-2"}), []int32{ids[1], ids[4]})
+		assertIDs(t, "status filter", list(models.RideRequestFilter{Status: "cancelled"}), []int32{ids[2], ids[4]})
+		assertIDs(t, "date range", list(models.RideRequestFilter{From: baseTime.Add(time.Hour), To: baseTime.Add(3 * time.Hour)}), []int32{ids[1], ids[2]})
+		assertIDs(t, "bounding box", list(models.RideRequestFilter{BoundingBox: box}), []int32{ids[0], ids[2], ids[4]})
+		assertIDs(t, "combined", list(models.RideRequestFilter{PassengerID: "passenger-1", Status: "pending", BoundingBox: box}), []int32{ids[0]})
+	})
+
+	t.Run("ListInvalidSort", func(t *testing.T) {
+		repo := h.New(t)
+		_, err := repo.List(t.Context(), models.RideRequestFilter{SortBy: "status"}, nil, 10)
+		if !errors.Is(err, models.ErrInvalidSort) {
+			t.Fatalf("List error = %v, want ErrInvalidSort", err)
+		}
+	})
+}
+
+func rideRequest(passengerID string, from models.Location, to models.Location, at time.Time) *models.RideRequest {
+	return &models.RideRequest{
+		PassengerID:  passengerID,
+		Origin:       from,
+		Destination:  to,
+		RideDatetime: at,
+		Description:  "ride request",
+		ImgUrl:       "https://example.com/ride-request.png",
+	}
+}
+
+func mustCreateRideRequest(t *testing.T, repo out.RideRequestRepository, rideRequest *models.RideRequest) *models.RideRequest {
+	t.Helper()
+	created, err := repo.Create(t.Context(), rideRequest)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return created
+}
+
+// rideRequestCursorValue formats the sort value like
+// services.RideRequestService.List.
+func rideRequestCursorValue(rideRequest *models.RideRequest, sortBy string) string {
+	if sortBy == models.RideRequestSortRideDatetime {
+		return rideRequest.RideDatetime.Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+func rideRequestID(rideRequest *models.RideRequest) int32 { return rideRequest.ID }
+
+func cloneRideRequest(rideRequest *models.RideRequest) *models.RideRequest {
+	c := *rideRequest
+	return &c
+}
+
+func assertRideRequest(t *testing.T, got *models.RideRequest, want *models.RideRequest) {
+	t.Helper()
+	if got.PassengerID != want.PassengerID || got.Status != want.Status {
+		t.Errorf("passenger/status = %q/%q, want %q/%q", got.PassengerID, got.Status, want.PassengerID, want.Status)
+	}
+	if got.Origin != want.Origin || got.Destination != want.Destination {
+		t.Errorf("origin/destination = %v/%v, want %v/%v", got.Origin, got.Destination, want.Origin, want.Destination)
+	}
+	if !got.RideDatetime.Equal(want.RideDatetime) {
+		t.Errorf("ride datetime = %v, want %v", got.RideDatetime, want.RideDatetime)
+	}
+	if got.Description != want.Description || got.ImgUrl != want.ImgUrl {
+		t.Errorf("description/img = %q/%q, want %q/%q", got.Description, got.ImgUrl, want.Description, want.ImgUrl)
+	}
+}
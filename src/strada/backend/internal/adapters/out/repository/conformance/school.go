@@ -0,0 +1,141 @@
+package conformance
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type SchoolHarness struct {
+	// New returns a repository without schools. It is called for every
+	// subtest.
+	New func(t *testing.T) out.SchoolRepository
+}
+
+// RunSchoolRepository checks the out.SchoolRepository contract.
+func RunSchoolRepository(t *testing.T, h SchoolHarness) {
+	t.Run("CreateAndFindById", func(t *testing.T) {
+		repo := h.New(t)
+		want := school("Colégio Central")
+		created, err := repo.Create(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == 0 || created.CreatedAt.IsZero() {
+			t.Fatalf("Create did not assign id and timestamps: %+v", created)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		assertSchool(t, got, want)
+
+		if _, err := repo.FindById(t.Context(), missingID); !errors.Is(err, models.ErrSchoolNotFound) {
+			t.Errorf("FindById missing error = %v, want ErrSchoolNotFound", err)
+		}
+	})
+
+	t.Run("WithoutGatesOrClasses", func(t *testing.T) {
+		repo := h.New(t)
+		want := school("Escola Sul")
+		want.Gates = nil
+		want.ClassTimes = nil
+		created, err := repo.Create(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if len(got.Gates) != 0 || len(got.ClassTimes) != 0 {
+			t.Errorf("gates = %v, class times = %v, want none", got.Gates, got.ClassTimes)
+		}
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		repo := h.New(t)
+		var ids []int32
+		for _, name := range []string{"Escola Norte", "Colégio Central", "Escola Leste"} {
+			created, err := repo.Create(t.Context(), school(name))
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			ids = append(ids, created.ID)
+		}
+
+		all, err := repo.FindAll(t.Context(), "")
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		assertIDs(t, "FindAll by name", idsOf(all, schoolID), []int32{ids[1], ids[2], ids[0]})
+
+		escolas, err := repo.FindAll(t.Context(), "escola")
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		assertIDs(t, "FindAll ignoring case", idsOf(escolas, schoolID), []int32{ids[2], ids[0]})
+
+		none, err := repo.FindAll(t.Context(), "universidade")
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		if len(none) != 0 {
+			t.Errorf("FindAll without matches returned %d schools, want none", len(none))
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := h.New(t)
+		created, err := repo.Create(t.Context(), school("Escola Norte"))
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		want := school("Escola Norte II")
+		want.Campus = append(want.Campus, offset(origin, -100, 50))
+		want.ClassTimes = []models.ClassTime{{StartMinutes: 13 * 60, EndMinutes: 18 * 60}}
+		if _, err := repo.Update(t.Context(), created.ID, want); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		assertSchool(t, got, want)
+
+		if _, err := repo.Update(t.Context(), missingID, want); !errors.Is(err, models.ErrSchoolNotFound) {
+			t.Errorf("Update missing error = %v, want ErrSchoolNotFound", err)
+		}
+	})
+}
+
+// school returns a school with a square campus of 200 m around origin.
+func school(name string) *models.School {
+	return &models.School{
+		Name:    name,
+		Address: "Rua da Consolação, 100",
+		Campus: []models.Location{
+			offset(origin, -100, -100),
+			offset(origin, -100, 100),
+			offset(origin, 100, 100),
+			offset(origin, 100, -100),
+		},
+		Gates:      []models.Location{offset(origin, -110, 0)},
+		TimeZone:   "America/Sao_Paulo",
+		ClassTimes: []models.ClassTime{{StartMinutes: 7*60 + 30, EndMinutes: 12 * 60}, {StartMinutes: 13 * 60, EndMinutes: 17*60 + 30}},
+	}
+}
+
+func schoolID(school *models.School) int32 { return school.ID }
+
+func assertSchool(t *testing.T, got *models.School, want *models.School) {
+	t.Helper()
+	if got.Name != want.Name || got.Address != want.Address || got.TimeZone != want.TimeZone ||
+		!slices.Equal(got.Campus, want.Campus) || !slices.Equal(got.Gates, want.Gates) || !slices.Equal(got.ClassTimes, want.ClassTimes) {
+		t.Errorf("school = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+package conformance
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type SeriesHarness struct {
+	// NewRideSeries and NewRideRequestSeries return repositories without
+	// series. They are called for every subtest.
+	NewRideSeries        func(t *testing.T) out.RideSeriesRepository
+	NewRideRequestSeries func(t *testing.T) out.RideRequestSeriesRepository
+	// VehicleID, RideID and RideRequestID are stored on series and
+	// occurrences; PostgreSQL needs existing rows.
+	VehicleID     int32
+	RideID        int32
+	RideRequestID int32
+}
+
+// RunSeriesRepositories checks the out.RideSeriesRepository and
+// out.RideRequestSeriesRepository contracts.
+func RunSeriesRepositories(t *testing.T, h SeriesHarness) {
+	t.Run("RideSeriesCreateAndFind", func(t *testing.T) {
+		repo := h.NewRideSeries(t)
+		want := h.rideSeries()
+		created, err := repo.Create(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == 0 || created.CreatedAt.IsZero() {
+			t.Fatalf("Create did not assign id and timestamps: %+v", created)
+		}
+
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if got.DriverID != want.DriverID || got.Ride.VehicleID != want.Ride.VehicleID ||
+			got.Ride.StartPoint != want.Ride.StartPoint || got.Ride.EndPoint != want.Ride.EndPoint ||
+			!slices.Equal(got.Ride.StopPoints, want.Ride.StopPoints) || got.Ride.CostCeiling != want.Ride.CostCeiling ||
+			got.Ride.MaxDetourMeters != want.Ride.MaxDetourMeters || got.Ride.MaxDetourMs != want.Ride.MaxDetourMs {
+			t.Errorf("ride template = %+v, want %+v", got.Ride, want.Ride)
+		}
+		assertRecurrence(t, got.Recurrence, want.Recurrence)
+		if !got.MaterializedUntil.Equal(want.MaterializedUntil) {
+			t.Errorf("materialized until = %v, want %v", got.MaterializedUntil, want.MaterializedUntil)
+		}
+
+		if _, err := repo.FindById(t.Context(), missingID); !errors.Is(err, models.ErrSeriesNotFound) {
+			t.Errorf("FindById missing error = %v, want ErrSeriesNotFound", err)
+		}
+	})
+
+	t.Run("RideSeriesFindDue", func(t *testing.T) {
+		repo := h.NewRideSeries(t)
+		behind := h.mustCreateRideSeries(t, repo, h.rideSeries())
+		ahead := h.rideSeries()
+		ahead.MaterializedUntil = baseTime.Add(48 * time.Hour)
+		h.mustCreateRideSeries(t, repo, ahead)
+		ended := h.rideSeries()
+		ended.Recurrence.EndDate = models.Date(baseTime.Add(-48 * time.Hour))
+		ended.Recurrence.StartDate = ended.Recurrence.EndDate.AddDate(0, 0, -7)
+		h.mustCreateRideSeries(t, repo, ended)
+
+		due, err := repo.FindDue(t.Context(), baseTime.Add(24*time.Hour))
+		if err != nil {
+			t.Fatalf("FindDue: %v", err)
+		}
+		assertIDs(t, "FindDue", idsOf(due, func(s *models.RideSeries) int32 { return s.ID }), []int32{behind.ID})
+
+		if err := repo.SetMaterializedUntil(t.Context(), behind.ID, baseTime.Add(24*time.Hour)); err != nil {
+			t.Fatalf("SetMaterializedUntil: %v", err)
+		}
+		due, err = repo.FindDue(t.Context(), baseTime.Add(24*time.Hour))
+		if err != nil {
+			t.Fatalf("FindDue: %v", err)
+		}
+		if len(due) != 0 {
+			t.Errorf("FindDue after SetMaterializedUntil returned %d series, want none", len(due))
+		}
+	})
+
+	t.Run("RideOccurrences", func(t *testing.T) {
+		repo := h.NewRideSeries(t)
+		series := h.mustCreateRideSeries(t, repo, h.rideSeries())
+		for _, departsAt := range []time.Time{baseTime.Add(24 * time.Hour), baseTime} {
+			claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, departsAt, baseTime)
+			if err != nil || !claimed {
+				t.Fatalf("ClaimOccurrence = %v, %v, want true", claimed, err)
+			}
+		}
+		claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, baseTime.Add(time.Minute))
+		if err != nil || claimed {
+			t.Fatalf("second ClaimOccurrence = %v, %v, want false", claimed, err)
+		}
+		occurrences, err := repo.FindOccurrences(t.Context(), series.ID, baseTime)
+		if err != nil {
+			t.Fatalf("FindOccurrences: %v", err)
+		}
+		if len(occurrences) != 0 {
+			t.Fatalf("occurrences before their rides = %v, want none", occurrences)
+		}
+		for _, departsAt := range []time.Time{baseTime.Add(24 * time.Hour), baseTime} {
+			if err := repo.SetOccurrenceRide(t.Context(), series.ID, departsAt, h.RideID); err != nil {
+				t.Fatalf("SetOccurrenceRide: %v", err)
+			}
+		}
+		stale := baseTime.Add(2 * models.OccurrenceClaimTimeout)
+		if claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, stale); err != nil || claimed {
+			t.Fatalf("ClaimOccurrence of a created occurrence = %v, %v, want false", claimed, err)
+		}
+
+		occurrences, err = repo.FindOccurrences(t.Context(), series.ID, baseTime)
+		if err != nil {
+			t.Fatalf("FindOccurrences: %v", err)
+		}
+		if len(occurrences) != 2 || !occurrences[0].DepartsAt.Equal(baseTime) || occurrences[0].RideID != h.RideID {
+			t.Fatalf("occurrences = %v, want two, the first at %v", occurrences, baseTime)
+		}
+		occurrences, err = repo.FindOccurrences(t.Context(), series.ID, baseTime.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("FindOccurrences: %v", err)
+		}
+		if len(occurrences) != 1 {
+			t.Errorf("occurrences from a minute later = %d, want 1", len(occurrences))
+		}
+	})
+
+	t.Run("RideOccurrenceClaims", func(t *testing.T) {
+		repo := h.NewRideSeries(t)
+		series := h.mustCreateRideSeries(t, repo, h.rideSeries())
+		if claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, baseTime); err != nil || !claimed {
+			t.Fatalf("ClaimOccurrence = %v, %v, want true", claimed, err)
+		}
+		stale := baseTime.Add(models.OccurrenceClaimTimeout + time.Second)
+		if claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, stale); err != nil || !claimed {
+			t.Fatalf("ClaimOccurrence of a stale claim = %v, %v, want true", claimed, err)
+		}
+		if claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, stale.Add(time.Second)); err != nil || claimed {
+			t.Fatalf("ClaimOccurrence of a taken over claim = %v, %v, want false", claimed, err)
+		}
+
+		if err := repo.ReleaseOccurrence(t.Context(), series.ID, baseTime); err != nil {
+			t.Fatalf("ReleaseOccurrence: %v", err)
+		}
+		if claimed, err := repo.ClaimOccurrence(t.Context(), series.ID, baseTime, stale); err != nil || !claimed {
+			t.Fatalf("ClaimOccurrence after ReleaseOccurrence = %v, %v, want true", claimed, err)
+		}
+		if err := repo.SetOccurrenceRide(t.Context(), series.ID, baseTime, h.RideID); err != nil {
+			t.Fatalf("SetOccurrenceRide: %v", err)
+		}
+		// Created occurrences are not released.
+		if err := repo.ReleaseOccurrence(t.Context(), series.ID, baseTime); err != nil {
+			t.Fatalf("ReleaseOccurrence: %v", err)
+		}
+		occurrences, err := repo.FindOccurrences(t.Context(), series.ID, baseTime)
+		if err != nil {
+			t.Fatalf("FindOccurrences: %v", err)
+		}
+		if len(occurrences) != 1 || occurrences[0].RideID != h.RideID {
+			t.Errorf("occurrences = %v, want the one with ride %d", occurrences, h.RideID)
+		}
+		if err := repo.SetOccurrenceRide(t.Context(), series.ID, baseTime.Add(time.Hour), h.RideID); !errors.Is(err, models.ErrSeriesNotFound) {
+			t.Errorf("SetOccurrenceRide without claim error = %v, want ErrSeriesNotFound", err)
+		}
+	})
+
+	t.Run("RideRequestSeries", func(t *testing.T) {
+		rideSeries := h.mustCreateRideSeries(t, h.NewRideSeries(t), h.rideSeries())
+		repo := h.NewRideRequestSeries(t)
+		want := &models.RideRequestSeries{
+			PassengerID: "passenger-1",
+			RideRequest: &models.RideRequest{
+				PassengerID: "passenger-1",
+				Origin:      offset(origin, 500, 0),
+				Destination: offset(origin, 3000, 0),
+				Description: "to school",
+			},
+			Recurrence:        h.rideSeries().Recurrence,
+			MaterializedUntil: baseTime,
+		}
+		created, err := repo.Create(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.SetRideSeries(t.Context(), created.ID, rideSeries.ID); err != nil {
+			t.Fatalf("SetRideSeries: %v", err)
+		}
+		got, err := repo.FindById(t.Context(), created.ID)
+		if err != nil {
+			t.Fatalf("FindById: %v", err)
+		}
+		if got.PassengerID != want.PassengerID || got.RideRequest.Origin != want.RideRequest.Origin ||
+			got.RideRequest.Destination != want.RideRequest.Destination || got.RideSeriesID != rideSeries.ID {
+			t.Errorf("series = %+v, want %+v matched with %d", got, want, rideSeries.ID)
+		}
+		assertRecurrence(t, got.Recurrence, want.Recurrence)
+
+		due, err := repo.FindDue(t.Context(), baseTime.Add(time.Hour))
+		if err != nil || len(due) != 1 {
+			t.Fatalf("FindDue = %d series, %v, want 1", len(due), err)
+		}
+
+		if claimed, err := repo.ClaimOccurrence(t.Context(), created.ID, baseTime, baseTime); err != nil || !claimed {
+			t.Fatalf("ClaimOccurrence = %v, %v, want true", claimed, err)
+		}
+		if claimed, err := repo.ClaimOccurrence(t.Context(), created.ID, baseTime, baseTime); err != nil || claimed {
+			t.Fatalf("second ClaimOccurrence = %v, %v, want false", claimed, err)
+		}
+		if err := repo.SetOccurrenceRideRequest(t.Context(), created.ID, baseTime, h.RideRequestID); err != nil {
+			t.Fatalf("SetOccurrenceRideRequest: %v", err)
+		}
+		if err := repo.SetOccurrenceRide(t.Context(), created.ID, baseTime, h.RideID); err != nil {
+			t.Fatalf("SetOccurrenceRide: %v", err)
+		}
+		occurrences, err := repo.FindOccurrences(t.Context(), created.ID, baseTime)
+		if err != nil {
+			t.Fatalf("FindOccurrences: %v", err)
+		}
+		if len(occurrences) != 1 || occurrences[0].RideRequestID != h.RideRequestID || occurrences[0].RideID != h.RideID {
+			t.Errorf("occurrences = %v, want the ride request %d joined to ride %d", occurrences, h.RideRequestID, h.RideID)
+		}
+	})
+}
+
+func (h SeriesHarness) rideSeries() *models.RideSeries {
+	ride := RideHarness{VehicleID: h.VehicleID}.ride("driver-1", origin, offset(origin, 3000, 0))
+	ride.StopPoints = []models.Location{offset(origin, 1500, 0)}
+	ride.CostCeiling = models.NewMoney(4000, models.DefaultCurrency)
+	ride.MaxDetourMeters = 1000
+	ride.MaxDetourMs = 300000
+	return &models.RideSeries{
+		DriverID: "driver-1",
+		Ride:     ride,
+		Recurrence: models.Recurrence{
+			Weekdays:         []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+			DepartureMinutes: 7*60 + 10,
+			TimeZone:         "America/Sao_Paulo",
+			StartDate:        models.Date(baseTime),
+			EndDate:          models.Date(baseTime.AddDate(0, 3, 0)),
+			Exceptions:       []time.Time{models.Date(baseTime.AddDate(0, 0, 7))},
+		},
+		MaterializedUntil: baseTime,
+	}
+}
+
+func (h SeriesHarness) mustCreateRideSeries(t *testing.T, repo out.RideSeriesRepository, series *models.RideSeries) *models.RideSeries {
+	t.Helper()
+	created, err := repo.Create(t.Context(), series)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return created
+}
+
+func assertRecurrence(t *testing.T, got models.Recurrence, want models.Recurrence) {
+	t.Helper()
+	if !slices.Equal(got.Weekdays, want.Weekdays) || got.DepartureMinutes != want.DepartureMinutes || got.TimeZone != want.TimeZone ||
+		!got.StartDate.Equal(want.StartDate) || !got.EndDate.Equal(want.EndDate) ||
+		!slices.EqualFunc(got.Exceptions, want.Exceptions, time.Time.Equal) {
+		t.Errorf("recurrence = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type UserPreferencesHarness struct {
+	// New returns a repository without preferences. It is called for every
+	// subtest.
+	New func(t *testing.T) out.UserPreferencesRepository
+	// SchoolID and OtherSchoolID are existing schools the preferences can
+	// reference.
+	SchoolID      int32
+	OtherSchoolID int32
+}
+
+// RunUserPreferencesRepository checks the out.UserPreferencesRepository
+// contract.
+func RunUserPreferencesRepository(t *testing.T, h UserPreferencesHarness) {
+	t.Run("FindByUserDefaults", func(t *testing.T) {
+		repo := h.New(t)
+		got, err := repo.FindByUser(t.Context(), "user-1")
+		if err != nil {
+			t.Fatalf("FindByUser: %v", err)
+		}
+		if got.UserID != "user-1" || got.SchoolID != 0 || got.LeaderboardOptOut {
+			t.Errorf("defaults = %+v, want only the user id", got)
+		}
+	})
+
+	t.Run("SaveAndFind", func(t *testing.T) {
+		repo := h.New(t)
+		want := &models.UserPreferences{
+			UserID:            "user-1",
+			SchoolID:          h.SchoolID,
+			SchoolVerifiedAt:  time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC),
+			Minor:             true,
+			LeaderboardOptOut: true,
+			LeaderboardOptIn:  true,
+		}
+		saved, err := repo.Save(t.Context(), want)
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if saved.UpdatedAt.IsZero() {
+			t.Error("Save did not set UpdatedAt")
+		}
+
+		want.LeaderboardOptOut = false
+		want.SchoolVerifiedAt = time.Time{}
+		if _, err := repo.Save(t.Context(), want); err != nil {
+			t.Fatalf("second Save: %v", err)
+		}
+		got, err := repo.FindByUser(t.Context(), "user-1")
+		if err != nil {
+			t.Fatalf("FindByUser: %v", err)
+		}
+		if got.SchoolID != want.SchoolID || !got.SchoolVerifiedAt.Equal(want.SchoolVerifiedAt) || got.Minor != want.Minor ||
+			got.LeaderboardOptOut != want.LeaderboardOptOut || got.LeaderboardOptIn != want.LeaderboardOptIn {
+			t.Errorf("preferences = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("FindBySchool", func(t *testing.T) {
+		repo := h.New(t)
+		for _, preferences := range []*models.UserPreferences{
+			{UserID: "user-2", SchoolID: h.SchoolID},
+			{UserID: "user-1", SchoolID: h.SchoolID, LeaderboardOptOut: true},
+			{UserID: "user-3", SchoolID: h.OtherSchoolID},
+			{UserID: "user-4"},
+		} {
+			if _, err := repo.Save(t.Context(), preferences); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+		}
+
+		members, err := repo.FindBySchool(t.Context(), h.SchoolID)
+		if err != nil {
+			t.Fatalf("FindBySchool: %v", err)
+		}
+		var got []string
+		for _, member := range members {
+			got = append(got, member.UserID)
+		}
+		if len(got) != 2 || got[0] != "user-1" || got[1] != "user-2" {
+			t.Errorf("members = %v, want [user-1 user-2]", got)
+		}
+	})
+}
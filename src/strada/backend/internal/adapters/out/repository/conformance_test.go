@@ -0,0 +1,186 @@
+package repository_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	config "github.com/244Walyson/shared-ride/configs/db"
+	"github.com/244Walyson/shared-ride/db"
+	"github.com/244Walyson/shared-ride/db/migrate"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/conformance"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// fixtureID is the id of every fixture row, inserted first after the tables
+// are truncated with RESTART IDENTITY.
+const fixtureID = 1
+
+// openDatabase migrates the database in DATABASE_URL and skips the test
+// without one. The suites truncate its tables, so never point it at data
+// worth keeping.
+func openDatabase(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	if os.Getenv("DATABASE_URL") == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+
+	conn, err := config.ConnectMigrator(t.Context())
+	if err != nil {
+		t.Fatalf("connect migrator: %v", err)
+	}
+	defer conn.Close(context.Background())
+	if _, err := migrate.New(conn, db.Migrations, "migrations").Up(t.Context()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	pool, err := config.ConnectDB()
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// reset empties every table the suites touch and inserts the vehicle and
+// school fixtures.
+func reset(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+	_, err := pool.Exec(t.Context(), `TRUNCATE tb_rides, tb_ride_requests, tb_ride_series, tb_ride_request_series,
+		tb_payments, tb_user_preferences, tb_change_history, tb_vehicles, tb_schools RESTART IDENTITY CASCADE`)
+	if err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	_, err = pool.Exec(t.Context(), `INSERT INTO tb_vehicles (driver_id, make, model, year, license_plate, fuel_type)
+		VALUES (1, 'Fiat', 'Uno', 2015, 'ABC1D23', 'gasoline')`)
+	if err != nil {
+		t.Fatalf("insert vehicle: %v", err)
+	}
+	insertSchool(t, pool)
+}
+
+// insertSchool inserts a school fixture, numbered after the existing ones.
+func insertSchool(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+	_, err := repository.NewSchoolRepository(pool).Create(t.Context(), &models.School{
+		Name:     "Fixture",
+		Campus:   []models.Location{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 0.01}, {Latitude: 0.01, Longitude: 0.01}},
+		TimeZone: "UTC",
+	})
+	if err != nil {
+		t.Fatalf("insert school: %v", err)
+	}
+}
+
+// resetWithRides also inserts a ride and a ride request fixture for the
+// suites that reference them.
+func resetWithRides(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+	reset(t, pool)
+	_, err := repository.NewRideRepository(pool).Create(t.Context(), &models.Ride{
+		DriverID:  "driver-fixture",
+		VehicleID: fixtureID,
+		EndPoint:  models.Location{Latitude: 0.01, Longitude: 0.01},
+		Cost:      models.NewMoney(0, models.DefaultCurrency),
+	})
+	if err != nil {
+		t.Fatalf("insert ride: %v", err)
+	}
+	_, err = repository.NewRideRequestRepository(pool).Create(t.Context(), &models.RideRequest{
+		PassengerID:  "passenger-fixture",
+		Destination:  models.Location{Latitude: 0.01, Longitude: 0.01},
+		RideDatetime: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("insert ride request: %v", err)
+	}
+}
+
+func TestPostgresRepositories(t *testing.T) {
+	pool := openDatabase(t)
+
+	t.Run("Ride", func(t *testing.T) {
+		conformance.RunRideRepository(t, conformance.RideHarness{
+			New: func(t *testing.T) out.RideRepository {
+				reset(t, pool)
+				return repository.NewRideRepository(pool)
+			},
+			VehicleID: fixtureID,
+			SchoolID:  fixtureID,
+		})
+	})
+
+	t.Run("RideRequest", func(t *testing.T) {
+		conformance.RunRideRequestRepository(t, conformance.RideRequestHarness{
+			New: func(t *testing.T) out.RideRequestRepository {
+				reset(t, pool)
+				return repository.NewRideRequestRepository(pool)
+			},
+			SchoolID: fixtureID,
+		})
+	})
+
+	t.Run("Payment", func(t *testing.T) {
+		conformance.RunPaymentRepository(t, conformance.PaymentHarness{
+			New: func(t *testing.T) out.PaymentRepository {
+				resetWithRides(t, pool)
+				return repository.NewPaymentRepository(pool)
+			},
+			RideID: fixtureID,
+		})
+	})
+
+	t.Run("Series", func(t *testing.T) {
+		// Some subtests call both constructors; only the first call resets so
+		// the second does not wipe what was created in between.
+		var last *testing.T
+		resetOnce := func(t *testing.T) {
+			if t != last {
+				resetWithRides(t, pool)
+				last = t
+			}
+		}
+		conformance.RunSeriesRepositories(t, conformance.SeriesHarness{
+			NewRideSeries: func(t *testing.T) out.RideSeriesRepository {
+				resetOnce(t)
+				return repository.NewRideSeriesRepository(pool)
+			},
+			NewRideRequestSeries: func(t *testing.T) out.RideRequestSeriesRepository {
+				resetOnce(t)
+				return repository.NewRideRequestSeriesRepository(pool)
+			},
+			VehicleID:     fixtureID,
+			RideID:        fixtureID,
+			RideRequestID: fixtureID,
+		})
+	})
+
+	t.Run("UserPreferences", func(t *testing.T) {
+		conformance.RunUserPreferencesRepository(t, conformance.UserPreferencesHarness{
+			New: func(t *testing.T) out.UserPreferencesRepository {
+				reset(t, pool)
+				insertSchool(t, pool)
+				return repository.NewUserPreferencesRepository(pool)
+			},
+			SchoolID:      fixtureID,
+			OtherSchoolID: fixtureID + 1,
+		})
+	})
+
+	t.Run("School", func(t *testing.T) {
+		conformance.RunSchoolRepository(t, conformance.SchoolHarness{
+			New: func(t *testing.T) out.SchoolRepository {
+				reset(t, pool)
+				// The school suite expects an empty registry.
+				if _, err := pool.Exec(t.Context(), `TRUNCATE tb_schools RESTART IDENTITY CASCADE`); err != nil {
+					t.Fatalf("truncate schools: %v", err)
+				}
+				return repository.NewSchoolRepository(pool)
+			},
+		})
+	})
+}
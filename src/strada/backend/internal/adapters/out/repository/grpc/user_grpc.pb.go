@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_FindById_FullMethodName = "/user.UserService/FindById"
+	UserService_FindById_FullMethodName             = "/user.UserService/FindById"
+	UserService_FindByIds_FullMethodName            = "/user.UserService/FindByIds"
+	UserService_FindGuardianRelation_FullMethodName = "/user.UserService/FindGuardianRelation"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
 	FindById(ctx context.Context, in *FindUserRequestDto, opts ...grpc.CallOption) (*FindUserResponseDto, error)
+	FindByIds(ctx context.Context, in *FindUsersRequestDto, opts ...grpc.CallOption) (*FindUsersResponseDto, error)
+	FindGuardianRelation(ctx context.Context, in *FindGuardianRelationRequestDto, opts ...grpc.CallOption) (*GuardianRelationResponseDto, error)
 }
 
 type userServiceClient struct {
@@ -47,11 +51,33 @@ func (c *userServiceClient) FindById(ctx context.Context, in *FindUserRequestDto
 	return out, nil
 }
 
+func (c *userServiceClient) FindByIds(ctx context.Context, in *FindUsersRequestDto, opts ...grpc.CallOption) (*FindUsersResponseDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindUsersResponseDto)
+	err := c.cc.Invoke(ctx, UserService_FindByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FindGuardianRelation(ctx context.Context, in *FindGuardianRelationRequestDto, opts ...grpc.CallOption) (*GuardianRelationResponseDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GuardianRelationResponseDto)
+	err := c.cc.Invoke(ctx, UserService_FindGuardianRelation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 type UserServiceServer interface {
 	FindById(context.Context, *FindUserRequestDto) (*FindUserResponseDto, error)
+	FindByIds(context.Context, *FindUsersRequestDto) (*FindUsersResponseDto, error)
+	FindGuardianRelation(context.Context, *FindGuardianRelationRequestDto) (*GuardianRelationResponseDto, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -65,6 +91,12 @@ type UnimplementedUserServiceServer struct{}
 func (UnimplementedUserServiceServer) FindById(context.Context, *FindUserRequestDto) (*FindUserResponseDto, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindById not implemented")
 }
+func (UnimplementedUserServiceServer) FindByIds(context.Context, *FindUsersRequestDto) (*FindUsersResponseDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindByIds not implemented")
+}
+func (UnimplementedUserServiceServer) FindGuardianRelation(context.Context, *FindGuardianRelationRequestDto) (*GuardianRelationResponseDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindGuardianRelation not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -104,6 +136,42 @@ func _UserService_FindById_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_FindByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUsersRequestDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FindByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FindByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FindByIds(ctx, req.(*FindUsersRequestDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_FindGuardianRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindGuardianRelationRequestDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FindGuardianRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FindGuardianRelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FindGuardianRelation(ctx, req.(*FindGuardianRelationRequestDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +183,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindById",
 			Handler:    _UserService_FindById_Handler,
 		},
+		{
+			MethodName: "FindByIds",
+			Handler:    _UserService_FindByIds_Handler,
+		},
+		{
+			MethodName: "FindGuardianRelation",
+			Handler:    _UserService_FindGuardianRelation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/user.proto",
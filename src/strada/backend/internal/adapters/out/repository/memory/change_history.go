@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type ChangeHistoryRepository struct {
+	mu      sync.RWMutex
+	lastID  int64
+	records []*models.ChangeRecord
+}
+
+func NewChangeHistoryRepository() out.ChangeHistoryRepository {
+	return &ChangeHistoryRepository{}
+}
+
+func (r *ChangeHistoryRepository) Create(ctx context.Context, record *models.ChangeRecord) (*models.ChangeRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	record.ID = r.lastID
+	record.CreatedAt = time.Now().UTC()
+	c := *record
+	c.Changes = maps.Clone(record.Changes)
+	r.records = append(r.records, &c)
+	return record, nil
+}
+
+// FindByEntity returns the records oldest first.
+func (r *ChangeHistoryRepository) FindByEntity(ctx context.Context, entityType string, entityId int32) ([]*models.ChangeRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*models.ChangeRecord
+	for _, record := range r.records {
+		if record.EntityType == entityType && record.EntityID == entityId {
+			c := *record
+			c.Changes = maps.Clone(record.Changes)
+			records = append(records, &c)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,59 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/conformance"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// The memory adapters need no existing rows, so any id does for the
+// references the suites store.
+const fixtureID = 1
+
+func TestRideRepository(t *testing.T) {
+	conformance.RunRideRepository(t, conformance.RideHarness{
+		New:       func(t *testing.T) out.RideRepository { return memory.NewRideRepository() },
+		VehicleID: fixtureID,
+		SchoolID:  fixtureID,
+	})
+}
+
+func TestRideRequestRepository(t *testing.T) {
+	conformance.RunRideRequestRepository(t, conformance.RideRequestHarness{
+		New:      func(t *testing.T) out.RideRequestRepository { return memory.NewRideRequestRepository() },
+		SchoolID: fixtureID,
+	})
+}
+
+func TestPaymentRepository(t *testing.T) {
+	conformance.RunPaymentRepository(t, conformance.PaymentHarness{
+		New:    func(t *testing.T) out.PaymentRepository { return memory.NewPaymentRepository() },
+		RideID: fixtureID,
+	})
+}
+
+func TestSeriesRepositories(t *testing.T) {
+	conformance.RunSeriesRepositories(t, conformance.SeriesHarness{
+		NewRideSeries:        func(t *testing.T) out.RideSeriesRepository { return memory.NewRideSeriesRepository() },
+		NewRideRequestSeries: func(t *testing.T) out.RideRequestSeriesRepository { return memory.NewRideRequestSeriesRepository() },
+		VehicleID:            fixtureID,
+		RideID:               fixtureID,
+		RideRequestID:        fixtureID,
+	})
+}
+
+func TestUserPreferencesRepository(t *testing.T) {
+	conformance.RunUserPreferencesRepository(t, conformance.UserPreferencesHarness{
+		New:           func(t *testing.T) out.UserPreferencesRepository { return memory.NewUserPreferencesRepository() },
+		SchoolID:      fixtureID,
+		OtherSchoolID: fixtureID + 1,
+	})
+}
+
+func TestSchoolRepository(t *testing.T) {
+	conformance.RunSchoolRepository(t, conformance.SchoolHarness{
+		New: func(t *testing.T) out.SchoolRepository { return memory.NewSchoolRepository() },
+	})
+}
@@ -0,0 +1,34 @@
+package memory_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/nearbench"
+)
+
+// BenchmarkFindNear times the haversine scan the memory adapter searches
+// with, on the same synthetic rides as the Postgres benchmark.
+func BenchmarkFindNear(b *testing.B) {
+	routes := nearbench.RandomRoutes(rand.New(rand.NewSource(1)), 200)
+	for _, rides := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("rides=%d", rides), func(b *testing.B) {
+			repo := memory.NewRideRepository()
+			for _, ride := range nearbench.RandomRides(rand.New(rand.NewSource(2)), rides) {
+				if _, err := repo.Create(b.Context(), ride); err != nil {
+					b.Fatalf("Create: %v", err)
+				}
+			}
+
+			i := 0
+			for b.Loop() {
+				if _, err := repo.FindNear(b.Context(), routes[i%len(routes)], 0); err != nil {
+					b.Fatalf("FindNear: %v", err)
+				}
+				i++
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// sortKey is the position of an item in a listing: its sort value followed
+// by its id, the same keyset the SQL list queries compare.
+type sortKey struct {
+	time   time.Time
+	number float64
+	id     int32
+}
+
+func compareKeys(a, b sortKey) int {
+	if c := a.time.Compare(b.time); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.number, b.number); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// cursorKey converts a cursor issued by the services back into a sortKey.
+func cursorKey(after *models.Cursor, sortBy string) (sortKey, error) {
+	key := sortKey{id: after.ID}
+	switch sortBy {
+	case models.RideSortCreatedAt, models.RideRequestSortRideDatetime:
+		t, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return key, models.ErrInvalidCursor
+		}
+		key.time = t
+	case models.RideSortCost:
+		money, err := models.ParseMoney(after.Value, "")
+		if err != nil {
+			return key, models.ErrInvalidCursor
+		}
+		key.number = float64(money.Amount)
+	case models.RideSortDistance:
+		distance, err := strconv.ParseFloat(after.Value, 64)
+		if err != nil {
+			return key, models.ErrInvalidCursor
+		}
+		key.number = distance
+	}
+	return key, nil
+}
+
+// page sorts the items, skips everything up to the cursor and keeps limit
+// items.
+func page[T any](items []T, keyOf func(T) sortKey, descending bool, after *models.Cursor, sortBy string, limit int) ([]T, error) {
+	order := func(a, b T) int {
+		c := compareKeys(keyOf(a), keyOf(b))
+		if descending {
+			return -c
+		}
+		return c
+	}
+	slices.SortFunc(items, order)
+
+	if after != nil {
+		key, err := cursorKey(after, sortBy)
+		if err != nil {
+			return nil, err
+		}
+		items = slices.DeleteFunc(items, func(item T) bool {
+			c := compareKeys(keyOf(item), key)
+			if descending {
+				return c >= 0
+			}
+			return c <= 0
+		})
+	}
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func inBoundingBox(box *models.BoundingBox, locations ...models.Location) bool {
+	for _, loc := range locations {
+		if loc.Longitude >= box.MinLongitude && loc.Longitude <= box.MaxLongitude &&
+			loc.Latitude >= box.MinLatitude && loc.Latitude <= box.MaxLatitude {
+			return true
+		}
+	}
+	return false
+}
+
+func inRange(t time.Time, from time.Time, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
+
+// routeDistance is the distance from loc to the closest point of the route.
+func routeDistance(route []*models.Location, loc models.Location) float64 {
+	closest := -1.0
+	for _, point := range route {
+		if d := models.HaversineMeters(*point, loc); closest < 0 || d < closest {
+			closest = d
+		}
+	}
+	return closest
+}
+
+func nearRoute(route []*models.Location, locations ...models.Location) bool {
+	for _, loc := range locations {
+		if routeDistance(route, loc) <= models.NearRadiusMeters {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSchool mirrors the school_id comparison of the near queries, where 0 is
+// NULL and matches nothing.
+func sameSchool(schoolId int32, searched int32) bool {
+	return searched != 0 && schoolId == searched
+}
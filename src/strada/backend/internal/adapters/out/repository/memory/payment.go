@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type paymentEventKey struct {
+	provider string
+	eventId  string
+}
+
+type PaymentRepository struct {
+	mu       sync.RWMutex
+	lastID   int32
+	payments []*models.Payment
+	events   map[paymentEventKey]bool
+}
+
+func NewPaymentRepository() out.PaymentRepository {
+	return &PaymentRepository{
+		events: make(map[paymentEventKey]bool),
+	}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.payments {
+		activeCharge := payment.Kind == models.PaymentKindCharge && existing.Kind == models.PaymentKindCharge &&
+			existing.RideID == payment.RideID && existing.PassengerID == payment.PassengerID &&
+			existing.Status != models.PaymentStatusFailed
+		payout := payment.Kind == models.PaymentKindPayout && existing.Kind == models.PaymentKindPayout &&
+			existing.ParentID == payment.ParentID
+		if activeCharge || payout {
+			return nil, models.ErrPaymentExists
+		}
+	}
+
+	r.lastID++
+	now := time.Now().UTC()
+	c := *payment
+	c.ID = r.lastID
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	r.payments = append(r.payments, &c)
+	created := c
+	return &created, nil
+}
+
+func (r *PaymentRepository) FindById(ctx context.Context, id int32) (*models.Payment, error) {
+	return r.find(func(payment *models.Payment) bool { return payment.ID == id })
+}
+
+func (r *PaymentRepository) FindByRide(ctx context.Context, rideId int32) ([]*models.Payment, error) {
+	return r.filter(func(payment *models.Payment) bool { return payment.RideID == rideId }), nil
+}
+
+func (r *PaymentRepository) FindByRides(ctx context.Context, rideIds []int32) ([]*models.Payment, error) {
+	payments := r.filter(func(payment *models.Payment) bool { return slices.Contains(rideIds, payment.RideID) })
+	slices.SortStableFunc(payments, func(a, b *models.Payment) int { return cmp.Compare(a.RideID, b.RideID) })
+	return payments, nil
+}
+
+func (r *PaymentRepository) FindByParent(ctx context.Context, parentId int32) ([]*models.Payment, error) {
+	return r.filter(func(payment *models.Payment) bool { return payment.ParentID == parentId }), nil
+}
+
+func (r *PaymentRepository) FindByProviderRef(ctx context.Context, provider string, providerRef string) (*models.Payment, error) {
+	return r.find(func(payment *models.Payment) bool {
+		return payment.Provider == provider && payment.ProviderRef != "" && payment.ProviderRef == providerRef
+	})
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.payments {
+		if existing.ID == payment.ID {
+			existing.Status = payment.Status
+			existing.ProviderRef = payment.ProviderRef
+			existing.FailureReason = payment.FailureReason
+			existing.UpdatedAt = time.Now().UTC()
+			updated := *existing
+			return &updated, nil
+		}
+	}
+	return nil, models.ErrPaymentNotFound
+}
+
+func (r *PaymentRepository) RecordEvent(ctx context.Context, event *models.PaymentEvent, paymentId int32) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := paymentEventKey{provider: event.Provider, eventId: event.EventID}
+	if r.events[key] {
+		return false, nil
+	}
+	r.events[key] = true
+	return true, nil
+}
+
+func (r *PaymentRepository) find(match func(*models.Payment) bool) (*models.Payment, error) {
+	found := r.filter(match)
+	if len(found) == 0 {
+		return nil, models.ErrPaymentNotFound
+	}
+	return found[0], nil
+}
+
+// filter returns copies of the matching payments in id order.
+func (r *PaymentRepository) filter(match func(*models.Payment) bool) []*models.Payment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := []*models.Payment{}
+	for _, payment := range r.payments {
+		if match(payment) {
+			c := *payment
+			found = append(found, &c)
+		}
+	}
+	return found
+}
@@ -0,0 +1,316 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type RideRepository struct {
+	mu         sync.RWMutex
+	lastID     int32
+	rides      map[int32]*models.Ride
+	passengers []*models.RidePassenger
+	stops      map[int32][]*models.RideStop
+	// deleted mirrors tb_rides.deleted_at: deleted rides keep their
+	// passengers and stops but are no longer found.
+	deleted map[int32]bool
+}
+
+func NewRideRepository() out.RideRepository {
+	return &RideRepository{
+		rides:   make(map[int32]*models.Ride),
+		stops:   make(map[int32][]*models.RideStop),
+		deleted: make(map[int32]bool),
+	}
+}
+
+func (r *RideRepository) Create(ctx context.Context, ride *models.Ride) (*models.Ride, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	now := time.Now().UTC()
+	ride.ID = r.lastID
+	ride.CreatedAt = now
+	ride.UpdatedAt = now
+	ride.Version = 1
+	r.rides[ride.ID] = cloneRide(ride)
+	return ride, nil
+}
+
+func (r *RideRepository) FindById(ctx context.Context, id int32) (*models.Ride, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ride, ok := r.rides[id]
+	if !ok || r.deleted[id] {
+		return nil, models.ErrRideNotFound
+	}
+	return cloneRide(ride), nil
+}
+
+func (r *RideRepository) FindAll(ctx context.Context) ([]*models.Ride, error) {
+	rides := r.filter(func(*models.Ride) bool { return true })
+	slices.SortFunc(rides, func(a, b *models.Ride) int { return cmp.Compare(a.ID, b.ID) })
+	return rides, nil
+}
+
+func (r *RideRepository) List(ctx context.Context, filter models.RideFilter, after *models.Cursor, limit int) ([]*models.Ride, error) {
+	var keyOf func(*models.Ride) sortKey
+	switch filter.SortBy {
+	case models.RideSortCreatedAt:
+		keyOf = func(ride *models.Ride) sortKey { return sortKey{time: ride.CreatedAt, id: ride.ID} }
+	case models.RideSortCost:
+		keyOf = func(ride *models.Ride) sortKey { return sortKey{number: float64(ride.Cost.Amount), id: ride.ID} }
+	case models.RideSortDistance:
+		keyOf = func(ride *models.Ride) sortKey { return sortKey{number: ride.DistanceMeters, id: ride.ID} }
+	default:
+		return nil, models.ErrInvalidSort
+	}
+
+	r.mu.RLock()
+	passengerRides := make(map[int32]bool)
+	for _, passenger := range r.passengers {
+		if filter.PassengerID != "" && passenger.UserID == filter.PassengerID && passenger.Role == models.RideRolePassenger {
+			passengerRides[passenger.RideID] = true
+		}
+	}
+	r.mu.RUnlock()
+
+	rides := r.filter(func(ride *models.Ride) bool {
+		return (filter.DriverID == "" || ride.DriverID == filter.DriverID) &&
+			(filter.PassengerID == "" || passengerRides[ride.ID]) &&
+			inRange(ride.CreatedAt, filter.From, filter.To) &&
+			(filter.BoundingBox == nil || inBoundingBox(filter.BoundingBox, ride.StartPoint, ride.EndPoint))
+	})
+	return page(rides, keyOf, filter.Descending, after, filter.SortBy, limit)
+}
+
+func (r *RideRepository) FindByUser(ctx context.Context, userId string) ([]*models.Ride, error) {
+	r.mu.RLock()
+	joined := make(map[int32]bool)
+	for _, passenger := range r.passengers {
+		if passenger.UserID == userId {
+			joined[passenger.RideID] = true
+		}
+	}
+	r.mu.RUnlock()
+
+	rides := r.filter(func(ride *models.Ride) bool {
+		return ride.DriverID == userId || joined[ride.ID]
+	})
+	slices.SortFunc(rides, func(a, b *models.Ride) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return rides, nil
+}
+
+func (r *RideRepository) FindCompleted(ctx context.Context, userIds []string, from time.Time, to time.Time) ([]*models.Ride, error) {
+	r.mu.RLock()
+	joined := make(map[int32]bool)
+	for _, passenger := range r.passengers {
+		if slices.Contains(userIds, passenger.UserID) {
+			joined[passenger.RideID] = true
+		}
+	}
+	r.mu.RUnlock()
+
+	rides := r.filter(func(ride *models.Ride) bool {
+		return !ride.CompletedAt.IsZero() && inRange(ride.CompletedAt, from, to) &&
+			(slices.Contains(userIds, ride.DriverID) || joined[ride.ID])
+	})
+	slices.SortFunc(rides, func(a, b *models.Ride) int {
+		if c := a.CompletedAt.Compare(b.CompletedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return rides, nil
+}
+
+// FindNear matches rides whose start, end or any stop lies within
+// models.NearRadiusMeters of the route or that go to the school, nearest
+// start point first.
+func (r *RideRepository) FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.Ride, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	rides := r.filter(func(ride *models.Ride) bool {
+		if !ride.CompletedAt.IsZero() {
+			return false
+		}
+		return nearRoute(locations, ride.StartPoint, ride.EndPoint) || nearRoute(locations, ride.StopPoints...) || sameSchool(ride.SchoolID, schoolId)
+	})
+	slices.SortFunc(rides, func(a, b *models.Ride) int {
+		if c := cmp.Compare(routeDistance(locations, a.StartPoint), routeDistance(locations, b.StartPoint)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if len(rides) > models.NearMaxResults {
+		rides = rides[:models.NearMaxResults]
+	}
+	return rides, nil
+}
+
+func (r *RideRepository) Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.rides[id]
+	if !ok || r.deleted[id] {
+		return nil, models.ErrRideNotFound
+	}
+	if ride.Version != current.Version {
+		return nil, models.ErrVersionConflict
+	}
+	updated := cloneRide(ride)
+	updated.ID = id
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	updated.Version = current.Version + 1
+	r.rides[id] = updated
+	return cloneRide(updated), nil
+}
+
+func (r *RideRepository) Delete(ctx context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rides[id]; !ok || r.deleted[id] {
+		return models.ErrRideNotFound
+	}
+	r.deleted[id] = true
+	return nil
+}
+
+func (r *RideRepository) FindPassengers(ctx context.Context, rideIds []int32) ([]*models.RidePassenger, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var passengers []*models.RidePassenger
+	for _, passenger := range r.passengers {
+		if slices.Contains(rideIds, passenger.RideID) {
+			p := *passenger
+			passengers = append(passengers, &p)
+		}
+	}
+	slices.SortStableFunc(passengers, func(a, b *models.RidePassenger) int {
+		if c := cmp.Compare(a.RideID, b.RideID); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return passengers, nil
+}
+
+func (r *RideRepository) AddPassenger(ctx context.Context, passenger *models.RidePassenger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ride, ok := r.rides[passenger.RideID]
+	if !ok {
+		return models.ErrRideNotFound
+	}
+	if r.passengerIndex(passenger.RideID, passenger.UserID) >= 0 {
+		return models.ErrAlreadyJoined
+	}
+	passenger.CreatedAt = time.Now().UTC()
+	p := *passenger
+	p.Fare = models.NewMoney(0, ride.Cost.Currency)
+	r.passengers = append(r.passengers, &p)
+	return nil
+}
+
+func (r *RideRepository) RemovePassenger(ctx context.Context, rideId int32, userId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.passengerIndex(rideId, userId)
+	if i < 0 || r.passengers[i].Role != models.RideRolePassenger {
+		return models.ErrPassengerNotFound
+	}
+	r.passengers = slices.Delete(r.passengers, i, i+1)
+	return nil
+}
+
+func (r *RideRepository) UpdatePassengerFares(ctx context.Context, rideId int32, fares []*models.PassengerFare) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, fare := range fares {
+		if i := r.passengerIndex(rideId, fare.UserID); i >= 0 {
+			r.passengers[i].DistanceMeters = fare.DistanceMeters
+			r.passengers[i].Fare = fare.Fare
+		}
+	}
+	return nil
+}
+
+func (r *RideRepository) UpdatePassengerEmissions(ctx context.Context, rideId int32, emissions []*models.PassengerEmission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, emission := range emissions {
+		if i := r.passengerIndex(rideId, emission.UserID); i >= 0 {
+			r.passengers[i].Co2SavedKg = emission.SavedKg
+		}
+	}
+	return nil
+}
+
+func (r *RideRepository) ReplaceStops(ctx context.Context, rideId int32, stops []*models.RideStop) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stops[rideId] = cloneStops(stops)
+	return nil
+}
+
+func (r *RideRepository) FindStops(ctx context.Context, rideId int32) ([]*models.RideStop, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return cloneStops(r.stops[rideId]), nil
+}
+
+func cloneStops(stops []*models.RideStop) []*models.RideStop {
+	c := make([]*models.RideStop, len(stops))
+	for i, stop := range stops {
+		s := *stop
+		c[i] = &s
+	}
+	return c
+}
+
+// passengerIndex must be called with r.mu held.
+func (r *RideRepository) passengerIndex(rideId int32, userId string) int {
+	return slices.IndexFunc(r.passengers, func(p *models.RidePassenger) bool {
+		return p.RideID == rideId && p.UserID == userId
+	})
+}
+
+// filter returns copies of the rides not deleted and accepted by keep.
+func (r *RideRepository) filter(keep func(*models.Ride) bool) []*models.Ride {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rides []*models.Ride
+	for _, ride := range r.rides {
+		if !r.deleted[ride.ID] && keep(ride) {
+			rides = append(rides, cloneRide(ride))
+		}
+	}
+	return rides
+}
+
+func cloneRide(ride *models.Ride) *models.Ride {
+	c := *ride
+	c.StopPoints = slices.Clone(ride.StopPoints)
+	return &c
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+const defaultRideRequestStatus = "pending"
+
+type RideRequestRepository struct {
+	mu           sync.RWMutex
+	lastID       int32
+	rideRequests map[int32]*models.RideRequest
+}
+
+func NewRideRequestRepository() out.RideRequestRepository {
+	return &RideRequestRepository{
+		rideRequests: make(map[int32]*models.RideRequest),
+	}
+}
+
+func (r *RideRequestRepository) Create(ctx context.Context, rideRequest *models.RideRequest) (*models.RideRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	rideRequest.ID = r.lastID
+	rideRequest.Version = 1
+	if rideRequest.Status == "" {
+		rideRequest.Status = defaultRideRequestStatus
+	}
+	c := *rideRequest
+	r.rideRequests[c.ID] = &c
+	return rideRequest, nil
+}
+
+func (r *RideRequestRepository) FindById(ctx context.Context, id int32) (*models.RideRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rideRequest, ok := r.rideRequests[id]
+	if !ok {
+		return nil, models.ErrRideRequestNotFound
+	}
+	c := *rideRequest
+	return &c, nil
+}
+
+func (r *RideRequestRepository) FindAll(ctx context.Context) ([]*models.RideRequest, error) {
+	rideRequests := r.filter(func(*models.RideRequest) bool { return true })
+	slices.SortFunc(rideRequests, func(a, b *models.RideRequest) int { return cmp.Compare(a.ID, b.ID) })
+	return rideRequests, nil
+}
+
+func (r *RideRequestRepository) List(ctx context.Context, filter models.RideRequestFilter, after *models.Cursor, limit int) ([]*models.RideRequest, error) {
+	var keyOf func(*models.RideRequest) sortKey
+	switch filter.SortBy {
+	case models.RideRequestSortRideDatetime:
+		keyOf = func(rideRequest *models.RideRequest) sortKey {
+			return sortKey{time: rideRequest.RideDatetime, id: rideRequest.ID}
+		}
+	case models.RideRequestSortID:
+		keyOf = func(rideRequest *models.RideRequest) sortKey { return sortKey{id: rideRequest.ID} }
+	default:
+		return nil, models.ErrInvalidSort
+	}
+
+	rideRequests := r.filter(func(rideRequest *models.RideRequest) bool {
+		return (filter.PassengerID == "" || rideRequest.PassengerID == filter.PassengerID) &&
+			(filter.Status == "" || rideRequest.Status == filter.Status) &&
+			inRange(rideRequest.RideDatetime, filter.From, filter.To) &&
+			(filter.BoundingBox == nil || inBoundingBox(filter.BoundingBox, rideRequest.Origin, rideRequest.Destination))
+	})
+	return page(rideRequests, keyOf, filter.Descending, after, filter.SortBy, limit)
+}
+
+// FindNear matches ride requests whose origin or destination lies within
+// models.NearRadiusMeters of the route or that go to the school, nearest
+// origin first.
+func (r *RideRequestRepository) FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.RideRequest, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	rideRequests := r.filter(func(rideRequest *models.RideRequest) bool {
+		return nearRoute(locations, rideRequest.Origin, rideRequest.Destination) || sameSchool(rideRequest.SchoolID, schoolId)
+	})
+	slices.SortFunc(rideRequests, func(a, b *models.RideRequest) int {
+		if c := cmp.Compare(routeDistance(locations, a.Origin), routeDistance(locations, b.Origin)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if len(rideRequests) > models.NearMaxResults {
+		rideRequests = rideRequests[:models.NearMaxResults]
+	}
+	return rideRequests, nil
+}
+
+func (r *RideRequestRepository) Update(ctx context.Context, id int32, rideRequest *models.RideRequest) (*models.RideRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.rideRequests[id]
+	if !ok {
+		return nil, models.ErrRideRequestNotFound
+	}
+	if rideRequest.Version != current.Version {
+		return nil, models.ErrVersionConflict
+	}
+	updated := *rideRequest
+	updated.ID = id
+	updated.Version = current.Version + 1
+	r.rideRequests[id] = &updated
+	c := updated
+	return &c, nil
+}
+
+func (r *RideRequestRepository) Delete(ctx context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rideRequests[id]; !ok {
+		return models.ErrRideRequestNotFound
+	}
+	delete(r.rideRequests, id)
+	return nil
+}
+
+// filter returns copies of the stored ride requests accepted by keep.
+func (r *RideRequestRepository) filter(keep func(*models.RideRequest) bool) []*models.RideRequest {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rideRequests []*models.RideRequest
+	for _, rideRequest := range r.rideRequests {
+		if keep(rideRequest) {
+			c := *rideRequest
+			rideRequests = append(rideRequests, &c)
+		}
+	}
+	return rideRequests
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type SchoolRepository struct {
+	mu      sync.RWMutex
+	lastID  int32
+	schools map[int32]*models.School
+}
+
+func NewSchoolRepository() out.SchoolRepository {
+	return &SchoolRepository{
+		schools: make(map[int32]*models.School),
+	}
+}
+
+func (r *SchoolRepository) Create(ctx context.Context, school *models.School) (*models.School, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	now := time.Now().UTC()
+	c := cloneSchool(school)
+	c.ID = r.lastID
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	r.schools[c.ID] = c
+	return cloneSchool(c), nil
+}
+
+func (r *SchoolRepository) FindById(ctx context.Context, id int32) (*models.School, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	school, ok := r.schools[id]
+	if !ok {
+		return nil, models.ErrSchoolNotFound
+	}
+	return cloneSchool(school), nil
+}
+
+func (r *SchoolRepository) FindAll(ctx context.Context, name string) ([]*models.School, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schools := []*models.School{}
+	for _, school := range r.schools {
+		if strings.Contains(strings.ToLower(school.Name), strings.ToLower(name)) {
+			schools = append(schools, cloneSchool(school))
+		}
+	}
+	slices.SortFunc(schools, func(a, b *models.School) int {
+		if c := cmp.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return schools, nil
+}
+
+func (r *SchoolRepository) Update(ctx context.Context, id int32, school *models.School) (*models.School, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.schools[id]
+	if !ok {
+		return nil, models.ErrSchoolNotFound
+	}
+	updated := cloneSchool(school)
+	updated.ID = id
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	r.schools[id] = updated
+	return cloneSchool(updated), nil
+}
+
+func cloneSchool(school *models.School) *models.School {
+	c := *school
+	c.Campus = slices.Clone(school.Campus)
+	c.Gates = slices.Clone(school.Gates)
+	c.ClassTimes = slices.Clone(school.ClassTimes)
+	return &c
+}
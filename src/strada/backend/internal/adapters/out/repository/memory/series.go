@@ -0,0 +1,318 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type RideSeriesRepository struct {
+	mu          sync.RWMutex
+	lastID      int32
+	series      map[int32]*models.RideSeries
+	occurrences occurrences
+}
+
+func NewRideSeriesRepository() out.RideSeriesRepository {
+	return &RideSeriesRepository{
+		series: make(map[int32]*models.RideSeries),
+	}
+}
+
+func (r *RideSeriesRepository) Create(ctx context.Context, series *models.RideSeries) (*models.RideSeries, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	now := time.Now().UTC()
+	series.ID = r.lastID
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	r.series[series.ID] = cloneRideSeries(series)
+	return cloneRideSeries(series), nil
+}
+
+func (r *RideSeriesRepository) FindById(ctx context.Context, id int32) (*models.RideSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return nil, models.ErrSeriesNotFound
+	}
+	return cloneRideSeries(series), nil
+}
+
+func (r *RideSeriesRepository) FindDue(ctx context.Context, until time.Time) ([]*models.RideSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*models.RideSeries
+	for _, series := range r.series {
+		if isDue(&series.Recurrence, series.MaterializedUntil, until) {
+			due = append(due, cloneRideSeries(series))
+		}
+	}
+	slices.SortFunc(due, func(a, b *models.RideSeries) int {
+		if c := a.MaterializedUntil.Compare(b.MaterializedUntil); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return due, nil
+}
+
+func (r *RideSeriesRepository) SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return models.ErrSeriesNotFound
+	}
+	series.MaterializedUntil = until.UTC()
+	series.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (r *RideSeriesRepository) ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.occurrences.claim(seriesId, departsAt, claimedAt), nil
+}
+
+func (r *RideSeriesRepository) SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	occurrence := r.occurrences.get(seriesId, departsAt)
+	if occurrence == nil {
+		return models.ErrSeriesNotFound
+	}
+	occurrence.RideID = rideId
+	occurrence.created = true
+	return nil
+}
+
+func (r *RideSeriesRepository) ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.occurrences.release(seriesId, departsAt)
+	return nil
+}
+
+func (r *RideSeriesRepository) FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.occurrences.find(seriesId, from), nil
+}
+
+func cloneRideSeries(series *models.RideSeries) *models.RideSeries {
+	c := *series
+	c.Ride = cloneRide(series.Ride)
+	c.Recurrence = cloneRecurrence(series.Recurrence)
+	return &c
+}
+
+type RideRequestSeriesRepository struct {
+	mu          sync.RWMutex
+	lastID      int32
+	series      map[int32]*models.RideRequestSeries
+	occurrences occurrences
+}
+
+func NewRideRequestSeriesRepository() out.RideRequestSeriesRepository {
+	return &RideRequestSeriesRepository{
+		series: make(map[int32]*models.RideRequestSeries),
+	}
+}
+
+func (r *RideRequestSeriesRepository) Create(ctx context.Context, series *models.RideRequestSeries) (*models.RideRequestSeries, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	now := time.Now().UTC()
+	series.ID = r.lastID
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	r.series[series.ID] = cloneRideRequestSeries(series)
+	return cloneRideRequestSeries(series), nil
+}
+
+func (r *RideRequestSeriesRepository) FindById(ctx context.Context, id int32) (*models.RideRequestSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return nil, models.ErrSeriesNotFound
+	}
+	return cloneRideRequestSeries(series), nil
+}
+
+func (r *RideRequestSeriesRepository) FindDue(ctx context.Context, until time.Time) ([]*models.RideRequestSeries, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*models.RideRequestSeries
+	for _, series := range r.series {
+		if isDue(&series.Recurrence, series.MaterializedUntil, until) {
+			due = append(due, cloneRideRequestSeries(series))
+		}
+	}
+	slices.SortFunc(due, func(a, b *models.RideRequestSeries) int {
+		if c := a.MaterializedUntil.Compare(b.MaterializedUntil); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return due, nil
+}
+
+func (r *RideRequestSeriesRepository) SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error {
+	return r.update(id, func(series *models.RideRequestSeries) { series.MaterializedUntil = until.UTC() })
+}
+
+func (r *RideRequestSeriesRepository) SetRideSeries(ctx context.Context, id int32, rideSeriesId int32) error {
+	return r.update(id, func(series *models.RideRequestSeries) { series.RideSeriesID = rideSeriesId })
+}
+
+func (r *RideRequestSeriesRepository) update(id int32, fn func(series *models.RideRequestSeries)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	series, ok := r.series[id]
+	if !ok {
+		return models.ErrSeriesNotFound
+	}
+	fn(series)
+	series.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.occurrences.claim(seriesId, departsAt, claimedAt), nil
+}
+
+func (r *RideRequestSeriesRepository) SetOccurrenceRideRequest(ctx context.Context, seriesId int32, departsAt time.Time, rideRequestId int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	occurrence := r.occurrences.get(seriesId, departsAt)
+	if occurrence == nil {
+		return models.ErrSeriesNotFound
+	}
+	occurrence.RideRequestID = rideRequestId
+	occurrence.created = true
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.occurrences.release(seriesId, departsAt)
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	occurrence := r.occurrences.get(seriesId, departsAt)
+	if occurrence == nil {
+		return models.ErrSeriesNotFound
+	}
+	occurrence.RideID = rideId
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.occurrences.find(seriesId, from), nil
+}
+
+func cloneRideRequestSeries(series *models.RideRequestSeries) *models.RideRequestSeries {
+	c := *series
+	rideRequest := *series.RideRequest
+	c.RideRequest = &rideRequest
+	c.Recurrence = cloneRecurrence(series.Recurrence)
+	return &c
+}
+
+func cloneRecurrence(recurrence models.Recurrence) models.Recurrence {
+	recurrence.Weekdays = slices.Clone(recurrence.Weekdays)
+	recurrence.Exceptions = slices.Clone(recurrence.Exceptions)
+	return recurrence
+}
+
+// isDue mirrors FindDue in Postgres: series behind until that did not end
+// before the day they were materialized to.
+func isDue(recurrence *models.Recurrence, materializedUntil time.Time, until time.Time) bool {
+	return materializedUntil.Before(until) &&
+		(recurrence.EndDate.IsZero() || !recurrence.EndDate.Before(models.Date(materializedUntil)))
+}
+
+// occurrences is keyed on the series and departure, like the primary key of
+// the occurrence tables. Callers hold the repository lock.
+type occurrences []*occurrence
+
+// occurrence is a claimed departure, created once its ride or ride request
+// is recorded.
+type occurrence struct {
+	models.Occurrence
+	claimedAt time.Time
+	created   bool
+}
+
+func (o *occurrences) get(seriesId int32, departsAt time.Time) *occurrence {
+	for _, occurrence := range *o {
+		if occurrence.SeriesID == seriesId && occurrence.DepartsAt.Equal(departsAt) {
+			return occurrence
+		}
+	}
+	return nil
+}
+
+// claim mirrors the upsert in Postgres: a new departure, or a claim without
+// ride or ride request older than models.OccurrenceClaimTimeout.
+func (o *occurrences) claim(seriesId int32, departsAt time.Time, claimedAt time.Time) bool {
+	if existing := o.get(seriesId, departsAt); existing != nil {
+		if existing.created || !existing.claimedAt.Before(claimedAt.Add(-models.OccurrenceClaimTimeout)) {
+			return false
+		}
+		existing.claimedAt = claimedAt
+		return true
+	}
+	*o = append(*o, &occurrence{
+		Occurrence: models.Occurrence{SeriesID: seriesId, DepartsAt: departsAt.UTC()},
+		claimedAt:  claimedAt,
+	})
+	return true
+}
+
+func (o *occurrences) release(seriesId int32, departsAt time.Time) {
+	*o = slices.DeleteFunc(*o, func(occurrence *occurrence) bool {
+		return occurrence.SeriesID == seriesId && occurrence.DepartsAt.Equal(departsAt) && !occurrence.created
+	})
+}
+
+func (o *occurrences) find(seriesId int32, from time.Time) []*models.Occurrence {
+	var found []*models.Occurrence
+	for _, occurrence := range *o {
+		if occurrence.SeriesID == seriesId && occurrence.created && !occurrence.DepartsAt.Before(from) {
+			c := occurrence.Occurrence
+			found = append(found, &c)
+		}
+	}
+	slices.SortFunc(found, func(a, b *models.Occurrence) int { return a.DepartsAt.Compare(b.DepartsAt) })
+	return found
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type UserRepository struct {
+	mu        sync.RWMutex
+	users     map[string]*models.User
+	guardians []*models.GuardianRelation
+}
+
+func NewUserRepository(users []*models.User, guardians []*models.GuardianRelation) out.UserRepository {
+	r := &UserRepository{
+		users: make(map[string]*models.User, len(users)),
+	}
+	for _, user := range users {
+		r.AddUser(user)
+	}
+	for _, relation := range guardians {
+		r.AddGuardianRelation(relation)
+	}
+	return r
+}
+
+// NewUserRepositoryFromFile seeds the repository from a JSON file shaped as
+// {"users": [{"id": "1", "name": "..."}], "guardianRelations": [{"guardianId": "1", "minorId": "2", "canRequestRides": true}]}.
+func NewUserRepositoryFromFile(path string) (out.UserRepository, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var seed struct {
+		Users             []*models.User
+		GuardianRelations []*models.GuardianRelation
+	}
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("invalid user seed file %s: %w", path, err)
+	}
+	return NewUserRepository(seed.Users, seed.GuardianRelations), nil
+}
+
+func (r *UserRepository) FindById(ctx context.Context, id string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrUserNotFound, id)
+	}
+	c := *user
+	return &c, nil
+}
+
+// FindByIds skips unknown ids, like the user service.
+func (r *UserRepository) FindByIds(ctx context.Context, ids []string) ([]*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []*models.User
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			c := *user
+			users = append(users, &c)
+		}
+	}
+	return users, nil
+}
+
+func (r *UserRepository) FindGuardianRelation(ctx context.Context, guardianId string, minorId string) (*models.GuardianRelation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, relation := range r.guardians {
+		if relation.GuardianID == guardianId && relation.MinorID == minorId {
+			c := *relation
+			return &c, nil
+		}
+	}
+	return nil, models.ErrGuardianNotFound
+}
+
+func (r *UserRepository) AddUser(user *models.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *user
+	r.users[c.ID] = &c
+}
+
+func (r *UserRepository) AddGuardianRelation(relation *models.GuardianRelation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *relation
+	r.guardians = append(r.guardians, &c)
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type UserPreferencesRepository struct {
+	mu          sync.RWMutex
+	preferences map[string]*models.UserPreferences
+}
+
+func NewUserPreferencesRepository() out.UserPreferencesRepository {
+	return &UserPreferencesRepository{
+		preferences: make(map[string]*models.UserPreferences),
+	}
+}
+
+func (r *UserPreferencesRepository) FindByUser(ctx context.Context, userId string) (*models.UserPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	preferences, ok := r.preferences[userId]
+	if !ok {
+		return &models.UserPreferences{UserID: userId}, nil
+	}
+	c := *preferences
+	return &c, nil
+}
+
+func (r *UserPreferencesRepository) Save(ctx context.Context, preferences *models.UserPreferences) (*models.UserPreferences, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *preferences
+	c.UpdatedAt = time.Now().UTC()
+	r.preferences[c.UserID] = &c
+	saved := c
+	return &saved, nil
+}
+
+func (r *UserPreferencesRepository) FindBySchool(ctx context.Context, schoolId int32) ([]*models.UserPreferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found []*models.UserPreferences
+	for _, preferences := range r.preferences {
+		if preferences.SchoolID == schoolId {
+			c := *preferences
+			found = append(found, &c)
+		}
+	}
+	slices.SortFunc(found, func(a, b *models.UserPreferences) int { return cmp.Compare(a.UserID, b.UserID) })
+	return found, nil
+}
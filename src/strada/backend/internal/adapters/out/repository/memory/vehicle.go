@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type VehicleRepository struct {
+	mu       sync.RWMutex
+	vehicles map[int32]*models.Vehicle
+}
+
+func NewVehicleRepository(vehicles []*models.Vehicle) out.VehicleRepository {
+	r := &VehicleRepository{
+		vehicles: make(map[int32]*models.Vehicle, len(vehicles)),
+	}
+	for _, vehicle := range vehicles {
+		r.AddVehicle(vehicle)
+	}
+	return r
+}
+
+func (r *VehicleRepository) FindById(ctx context.Context, id int32) (*models.Vehicle, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	vehicle, ok := r.vehicles[id]
+	if !ok {
+		return nil, models.ErrVehicleNotFound
+	}
+	c := *vehicle
+	return &c, nil
+}
+
+func (r *VehicleRepository) AddVehicle(vehicle *models.Vehicle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := *vehicle
+	r.vehicles[vehicle.ID] = &c
+}
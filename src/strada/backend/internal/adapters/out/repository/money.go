@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"math/big"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func moneyToNumeric(m models.Money) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(m.Amount), Exp: -models.MoneyScale, Valid: true}
+}
+
+// ceilingToNumeric stores a zero ceiling as NULL, meaning no ceiling.
+func ceilingToNumeric(m models.Money) pgtype.Numeric {
+	if m.IsZero() {
+		return pgtype.Numeric{}
+	}
+	return moneyToNumeric(m)
+}
+
+func numericToCeiling(n pgtype.Numeric, currency string) models.Money {
+	if !n.Valid {
+		return models.Money{}
+	}
+	return numericToMoney(n, currency)
+}
+
+// numericToMoney rescales n to minor units, whatever exponent the driver
+// decoded it with. Extra digits are rounded half away from zero, as Postgres
+// rounds NUMERIC.
+func numericToMoney(n pgtype.Numeric, currency string) models.Money {
+	if !n.Valid || n.Int == nil {
+		return models.NewMoney(0, currency)
+	}
+
+	amount := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + models.MoneyScale
+	switch {
+	case exp > 0:
+		amount.Mul(amount, pow10(exp))
+	case exp < 0:
+		divisor := pow10(-exp)
+		remainder := new(big.Int)
+		amount.QuoRem(amount, divisor, remainder)
+		if remainder.Lsh(remainder.Abs(remainder), 1).Cmp(divisor) >= 0 {
+			amount.Add(amount, big.NewInt(int64(n.Int.Sign())))
+		}
+	}
+	return models.NewMoney(amount.Int64(), currency)
+}
+
+func pow10(exp int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+}
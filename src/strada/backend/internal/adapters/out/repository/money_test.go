@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"math/big"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestNumericToMoney(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		exp   int32
+		want  int64
+	}{
+		{"scale 2", 1550, -2, 1550},
+		{"negative", -1550, -2, -1550},
+		{"integer", 15, 0, 1500},
+		{"positive exponent", 15, 1, 15000},
+		{"scale 1", 155, -1, 1550},
+		{"excess scale exact", 15500, -3, 1550},
+		{"excess scale rounds down", 12344, -3, 1234},
+		{"excess scale rounds half up", 12345, -3, 1235},
+		{"excess scale rounds up", 12346, -3, 1235},
+		{"negative rounds half away from zero", -12345, -3, -1235},
+		{"negative rounds toward zero below half", -12344, -3, -1234},
+		{"much excess scale", 123456789, -7, 1235},
+		{"zero", 0, -2, 0},
+		{"below a cent", 4, -3, 0},
+		{"half a cent", 5, -3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := numericToMoney(pgtype.Numeric{Int: big.NewInt(tt.value), Exp: tt.exp, Valid: true}, "BRL")
+			if got.Amount != tt.want || got.Currency != "BRL" {
+				t.Errorf("numericToMoney(%de%d) = %+v, want %d BRL", tt.value, tt.exp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericToMoneyNull(t *testing.T) {
+	if got := numericToMoney(pgtype.Numeric{}, ""); got != models.NewMoney(0, "") {
+		t.Errorf("numericToMoney(NULL) = %+v, want zero", got)
+	}
+	if got := numericToCeiling(pgtype.Numeric{}, "BRL"); got != (models.Money{}) {
+		t.Errorf("numericToCeiling(NULL) = %+v, want no ceiling", got)
+	}
+}
+
+func TestMoneyNumericRoundTrip(t *testing.T) {
+	for _, amount := range []int64{0, 1, -1, 1550, -1550, 9223372036854775807} {
+		m := models.NewMoney(amount, "USD")
+		if got := numericToMoney(moneyToNumeric(m), "USD"); got != m {
+			t.Errorf("round trip of %+v = %+v", m, got)
+		}
+	}
+	if got := ceilingToNumeric(models.Money{}); got.Valid {
+		t.Errorf("ceilingToNumeric(zero) = %+v, want NULL", got)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// routeParam sends the route as one MultiPoint so the near queries cast it to
+// geography once instead of rebuilding a point per row.
+func routeParam(locations []*models.Location) postgis.MultiPoint {
+	points := make([]models.Location, len(locations))
+	for i, loc := range locations {
+		points[i] = *loc
+	}
+	return postgis.NewMultiPoint(points)
+}
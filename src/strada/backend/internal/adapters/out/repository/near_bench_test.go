@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/nearbench"
+)
+
+// BenchmarkFindNear times the near search over synthetic rides, with the GiST
+// indexes and then without them. The rides are inserted in a transaction that
+// is rolled back, which holds a lock on tb_rides while it runs:
+//
+//	DATABASE_URL=... go test -run '^$' -bench FindNear ./internal/adapters/out/repository
+func BenchmarkFindNear(b *testing.B) {
+	pool := openDatabase(b)
+	routes := nearbench.RandomRoutes(rand.New(rand.NewSource(1)), 200)
+
+	for _, rides := range []int{10000, 100000, 300000} {
+		b.Run(fmt.Sprintf("rides=%d", rides), func(b *testing.B) {
+			tx, err := pool.Begin(b.Context())
+			if err != nil {
+				b.Fatalf("begin: %v", err)
+			}
+			defer tx.Rollback(b.Context())
+			if err := nearbench.SeedRides(b.Context(), tx, rides); err != nil {
+				b.Fatalf("seed: %v", err)
+			}
+			repo := repository.NewRideRepository(tx)
+
+			search := func(b *testing.B) {
+				i := 0
+				for b.Loop() {
+					if _, err := repo.FindNear(b.Context(), routes[i%len(routes)], 0); err != nil {
+						b.Fatalf("FindNear: %v", err)
+					}
+					i++
+				}
+			}
+			b.Run("indexed", search)
+			for _, index := range nearbench.Indexes {
+				if _, err := tx.Exec(b.Context(), "DROP INDEX "+index); err != nil {
+					b.Fatalf("drop %s: %v", index, err)
+				}
+			}
+			b.Run("sequential", search)
+		})
+	}
+}
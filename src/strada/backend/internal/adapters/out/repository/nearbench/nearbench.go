@@ -0,0 +1,103 @@
+// Package nearbench builds the synthetic data the near search is measured
+// with, shared by cmd/nearbench and the repository benchmarks.
+package nearbench
+
+import (
+	"context"
+	"math/rand"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// Rides are spread over a 70 x 70 km square around São Paulo.
+const (
+	minLon, maxLon = -47.0, -46.3
+	minLat, maxLat = -24.0, -23.3
+)
+
+// Indexes are the GiST indexes the ride near search relies on.
+var Indexes = []string{"idx_rides_start_point", "idx_rides_end_point", "idx_rides_stop_points"}
+
+// SeedRides inserts n rides with random start and end points and a stop
+// halfway, plus the vehicle they use, and analyzes tb_rides.
+func SeedRides(ctx context.Context, tx pgx.Tx, n int) error {
+	var vehicleID int32
+	err := tx.QueryRow(ctx, `
+		INSERT INTO tb_vehicles (driver_id, make, model, year, license_plate)
+		VALUES (0, 'bench', 'bench', 2025, 'BENCH-' || left(md5(random()::text), 12))
+		RETURNING id`).Scan(&vehicleID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO tb_rides (driver_id, vehicle_id, start_point, end_point, stop_points, distance, estimated_time_ms, cost, currency)
+		SELECT
+			'bench-' || g,
+			$1,
+			ST_SetSRID (ST_MakePoint (slon, slat), 4326)::geography,
+			ST_SetSRID (ST_MakePoint (elon, elat), 4326)::geography,
+			ST_Multi (ST_SetSRID (ST_MakePoint ((slon + elon) / 2, (slat + elat) / 2), 4326))::geography,
+			0,
+			0,
+			0,
+			'BRL'
+		FROM (
+			SELECT
+				g,
+				$2 + random() * ($3 - $2) AS slon,
+				$4 + random() * ($5 - $4) AS slat,
+				$2 + random() * ($3 - $2) AS elon,
+				$4 + random() * ($5 - $4) AS elat
+			FROM generate_series(1, $6::int) AS g
+		) AS points`,
+		vehicleID, minLon, maxLon, minLat, maxLat, n)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "ANALYZE tb_rides")
+	return err
+}
+
+func randomPoint(rnd *rand.Rand) models.Location {
+	return models.Location{
+		Longitude: minLon + rnd.Float64()*(maxLon-minLon),
+		Latitude:  minLat + rnd.Float64()*(maxLat-minLat),
+	}
+}
+
+func midpoint(a, b models.Location) models.Location {
+	return models.Location{Longitude: (a.Longitude + b.Longitude) / 2, Latitude: (a.Latitude + b.Latitude) / 2}
+}
+
+// RandomRoutes builds routes shaped like a ride request: origin, midpoint and
+// destination.
+func RandomRoutes(rnd *rand.Rand, n int) [][]*models.Location {
+	routes := make([][]*models.Location, n)
+	for i := range routes {
+		origin, destination := randomPoint(rnd), randomPoint(rnd)
+		middle := midpoint(origin, destination)
+		routes[i] = []*models.Location{&origin, &middle, &destination}
+	}
+	return routes
+}
+
+// RandomRides returns n rides like the ones SeedRides inserts, for adapters
+// without a database.
+func RandomRides(rnd *rand.Rand, n int) []*models.Ride {
+	rides := make([]*models.Ride, n)
+	for i := range rides {
+		start, end := randomPoint(rnd), randomPoint(rnd)
+		middle := midpoint(start, end)
+		rides[i] = &models.Ride{
+			DriverID:   "bench",
+			StartPoint: start,
+			EndPoint:   end,
+			StopPoints: []models.Location{middle},
+			Cost:       models.NewMoney(0, models.DefaultCurrency),
+		}
+	}
+	return rides
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Sort fields exposed by the services mapped to the keys understood by the
+// list queries.
+var rideSortColumns = map[string]string{
+	models.RideSortCreatedAt: "created_at",
+	models.RideSortCost:      "cost",
+	models.RideSortDistance:  "distance",
+}
+
+var rideRequestSortColumns = map[string]string{
+	models.RideRequestSortRideDatetime: "ride_datetime",
+	models.RideRequestSortID:           "id",
+}
+
+func textParam(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+// idParam stores 0 as NULL, for optional references.
+func idParam(id int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: id, Valid: id != 0}
+}
+
+func timestampParam(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
+type boundingBoxParams struct {
+	minLon, minLat, maxLon, maxLat pgtype.Float8
+}
+
+func toBoundingBoxParams(box *models.BoundingBox) boundingBoxParams {
+	if box == nil {
+		return boundingBoxParams{}
+	}
+	return boundingBoxParams{
+		minLon: pgtype.Float8{Float64: box.MinLongitude, Valid: true},
+		minLat: pgtype.Float8{Float64: box.MinLatitude, Valid: true},
+		maxLon: pgtype.Float8{Float64: box.MaxLongitude, Valid: true},
+		maxLat: pgtype.Float8{Float64: box.MaxLatitude, Valid: true},
+	}
+}
+
+type cursorParams struct {
+	id     pgtype.Int4
+	time   pgtype.Timestamp
+	number pgtype.Numeric
+}
+
+// toCursorParams converts the cursor value to the type of its sort column.
+func toCursorParams(after *models.Cursor, sortColumn string) (cursorParams, error) {
+	var params cursorParams
+	if after == nil {
+		return params, nil
+	}
+	params.id = pgtype.Int4{Int32: after.ID, Valid: true}
+
+	switch sortColumn {
+	case "created_at", "ride_datetime":
+		t, err := time.Parse(time.RFC3339Nano, after.Value)
+		if err != nil {
+			return params, models.ErrInvalidCursor
+		}
+		params.time = timestampParam(t)
+	case "cost", "distance":
+		if err := params.number.Scan(after.Value); err != nil {
+			return params, models.ErrInvalidCursor
+		}
+	}
+	return params, nil
+}
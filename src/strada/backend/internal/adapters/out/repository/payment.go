@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PaymentRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewPaymentRepository(db dbsqlc.DBTX) out.PaymentRepository {
+	return &PaymentRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	row, err := queries(ctx, r.sqlc).CreatePayment(ctx, dbsqlc.CreatePaymentParams{
+		RideID:      payment.RideID,
+		PassengerID: payment.PassengerID,
+		DriverID:    payment.DriverID,
+		Kind:        payment.Kind,
+		Amount:      moneyToNumeric(payment.Amount),
+		Currency:    payment.Amount.Currency,
+		Status:      payment.Status,
+		ParentID:    pgtype.Int4{Int32: payment.ParentID, Valid: payment.ParentID != 0},
+		Provider:    payment.Provider,
+	})
+	if isUniqueViolation(err) {
+		return nil, models.ErrPaymentExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPayment(row), nil
+}
+
+func (r *PaymentRepository) FindById(ctx context.Context, id int32) (*models.Payment, error) {
+	row, err := queries(ctx, r.sqlc).FindPaymentByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPayment(row), nil
+}
+
+func (r *PaymentRepository) FindByRide(ctx context.Context, rideId int32) ([]*models.Payment, error) {
+	rows, err := queries(ctx, r.sqlc).FindPaymentsByRideID(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	return toPayments(rows), nil
+}
+
+func (r *PaymentRepository) FindByRides(ctx context.Context, rideIds []int32) ([]*models.Payment, error) {
+	rows, err := queries(ctx, r.sqlc).FindPaymentsByRideIDs(ctx, rideIds)
+	if err != nil {
+		return nil, err
+	}
+	return toPayments(rows), nil
+}
+
+func (r *PaymentRepository) FindByParent(ctx context.Context, parentId int32) ([]*models.Payment, error) {
+	rows, err := queries(ctx, r.sqlc).FindPaymentsByParentID(ctx, pgtype.Int4{Int32: parentId, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return toPayments(rows), nil
+}
+
+func (r *PaymentRepository) FindByProviderRef(ctx context.Context, provider string, providerRef string) (*models.Payment, error) {
+	row, err := queries(ctx, r.sqlc).FindPaymentByProviderRef(ctx, dbsqlc.FindPaymentByProviderRefParams{
+		Provider:    provider,
+		ProviderRef: textParam(providerRef),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPayment(row), nil
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	row, err := queries(ctx, r.sqlc).UpdatePayment(ctx, dbsqlc.UpdatePaymentParams{
+		ID:            payment.ID,
+		Status:        payment.Status,
+		ProviderRef:   textParam(payment.ProviderRef),
+		FailureReason: textParam(payment.FailureReason),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toPayment(row), nil
+}
+
+func (r *PaymentRepository) RecordEvent(ctx context.Context, event *models.PaymentEvent, paymentId int32) (bool, error) {
+	rows, err := queries(ctx, r.sqlc).CreatePaymentEvent(ctx, dbsqlc.CreatePaymentEventParams{
+		Provider:  event.Provider,
+		EventID:   event.EventID,
+		PaymentID: paymentId,
+		Status:    event.Status,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
+func toPayments(rows []dbsqlc.Payment) []*models.Payment {
+	payments := make([]*models.Payment, len(rows))
+	for i := range rows {
+		payments[i] = toPayment(rows[i])
+	}
+	return payments
+}
+
+func toPayment(row dbsqlc.Payment) *models.Payment {
+	return &models.Payment{
+		ID:            row.ID,
+		RideID:        row.RideID,
+		PassengerID:   row.PassengerID,
+		DriverID:      row.DriverID,
+		Kind:          row.Kind,
+		Amount:        numericToMoney(row.Amount, row.Currency),
+		Status:        row.Status,
+		ParentID:      row.ParentID.Int32,
+		Provider:      row.Provider,
+		ProviderRef:   row.ProviderRef.String,
+		FailureReason: row.FailureReason.String,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
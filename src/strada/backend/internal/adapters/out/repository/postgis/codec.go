@@ -0,0 +1,145 @@
+package postgis
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Codec encodes and decodes PostGIS geometry and geography values as EWKB
+// into Point, MultiPoint, LineString and Polygon.
+type Codec struct{}
+
+func (Codec) FormatSupported(format int16) bool {
+	return format == pgtype.BinaryFormatCode || format == pgtype.TextFormatCode
+}
+
+func (Codec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(geometry); !ok {
+		return nil
+	}
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return encodePlanBinary{}
+	case pgtype.TextFormatCode:
+		return encodePlanText{}
+	}
+	return nil
+}
+
+type encodePlanBinary struct{}
+
+func (encodePlanBinary) Encode(value any, buf []byte) ([]byte, error) {
+	g := value.(geometry)
+	if g.isNull() {
+		return nil, nil
+	}
+	return g.appendEWKB(buf), nil
+}
+
+type encodePlanText struct{}
+
+func (encodePlanText) Encode(value any, buf []byte) ([]byte, error) {
+	g := value.(geometry)
+	if g.isNull() {
+		return nil, nil
+	}
+	return hex.AppendEncode(buf, g.appendEWKB(nil)), nil
+}
+
+func (Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(geometryScanner); !ok {
+		return nil
+	}
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return scanPlanBinary{}
+	case pgtype.TextFormatCode:
+		return scanPlanText{}
+	}
+	return nil
+}
+
+type scanPlanBinary struct{}
+
+func (scanPlanBinary) Scan(src []byte, target any) error {
+	dst := target.(geometryScanner)
+	if src == nil {
+		dst.setNull()
+		return nil
+	}
+	return dst.scanEWKB(src)
+}
+
+type scanPlanText struct{}
+
+func (scanPlanText) Scan(src []byte, target any) error {
+	dst := target.(geometryScanner)
+	if src == nil {
+		dst.setNull()
+		return nil
+	}
+	return scanText(dst, src)
+}
+
+func (Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if format == pgtype.TextFormatCode {
+		return string(src), nil
+	}
+	return hex.EncodeToString(src), nil
+}
+
+// DecodeValue returns the EWKB bytes; callers wanting coordinates scan into
+// one of the geometry types instead.
+func (Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if format == pgtype.TextFormatCode {
+		raw := make([]byte, hex.DecodedLen(len(src)))
+		if _, err := hex.Decode(raw, src); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	}
+	return append([]byte(nil), src...), nil
+}
+
+// Register looks up the PostGIS type OIDs and registers the codec for them
+// on conn. It is meant to be used as a pgxpool AfterConnect hook.
+func Register(ctx context.Context, conn *pgx.Conn) error {
+	rows, err := conn.Query(ctx, "SELECT typname, oid FROM pg_type WHERE typname IN ('geometry', 'geography')")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	registered := 0
+	for rows.Next() {
+		var name string
+		var oid uint32
+		if err := rows.Scan(&name, &oid); err != nil {
+			return err
+		}
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: name, OID: oid, Codec: Codec{}})
+		registered++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if registered == 0 {
+		return errors.New("postgis: geometry types not found, is the postgis extension installed?")
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package postgis
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+const (
+	wkbPoint      uint32 = 1
+	wkbLineString uint32 = 2
+	wkbPolygon    uint32 = 3
+	wkbMultiPoint uint32 = 4
+
+	ewkbZ    uint32 = 0x80000000
+	ewkbM    uint32 = 0x40000000
+	ewkbSRID uint32 = 0x20000000
+
+	// SRID is the spatial reference every geometry is written with (WGS 84).
+	SRID uint32 = 4326
+
+	pointSize = 1 + 4 + 2*8
+)
+
+var ErrInvalidEWKB = errors.New("postgis: invalid EWKB")
+
+type reader struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+func (r *reader) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidEWKB, fmt.Sprintf(format, args...))
+}
+
+func (r *reader) uint32() (uint32, error) {
+	if len(r.buf) < 4 {
+		return 0, r.errorf("unexpected end of data")
+	}
+	v := r.order.Uint32(r.buf)
+	r.buf = r.buf[4:]
+	return v, nil
+}
+
+func (r *reader) float64() (float64, error) {
+	if len(r.buf) < 8 {
+		return 0, r.errorf("unexpected end of data")
+	}
+	v := math.Float64frombits(r.order.Uint64(r.buf))
+	r.buf = r.buf[8:]
+	return v, nil
+}
+
+// header reads the byte order, geometry type and optional SRID, returning the
+// base geometry type and the number of ordinates per coordinate. Both EWKB
+// flags and ISO WKB type codes are understood for Z and M.
+func (r *reader) header() (uint32, int, error) {
+	if len(r.buf) < 1 {
+		return 0, 0, r.errorf("unexpected end of data")
+	}
+	switch r.buf[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, r.errorf("unknown byte order %d", r.buf[0])
+	}
+	r.buf = r.buf[1:]
+
+	geomType, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dims := 2
+	if geomType&ewkbZ != 0 {
+		dims++
+	}
+	if geomType&ewkbM != 0 {
+		dims++
+	}
+	if geomType&ewkbSRID != 0 {
+		if _, err := r.uint32(); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	base := geomType &^ (ewkbZ | ewkbM | ewkbSRID)
+	switch {
+	case base >= 3000:
+		dims, base = 4, base-3000
+	case base >= 2000:
+		dims, base = 3, base-2000
+	case base >= 1000:
+		dims, base = 3, base-1000
+	}
+	return base, dims, nil
+}
+
+// coord reads one coordinate, dropping any Z and M ordinates.
+func (r *reader) coord(dims int) (models.Location, error) {
+	x, err := r.float64()
+	if err != nil {
+		return models.Location{}, err
+	}
+	y, err := r.float64()
+	if err != nil {
+		return models.Location{}, err
+	}
+	for i := 2; i < dims; i++ {
+		if _, err := r.float64(); err != nil {
+			return models.Location{}, err
+		}
+	}
+	return models.Location{Longitude: x, Latitude: y}, nil
+}
+
+// count reads an element count, rejecting counts the remaining data cannot
+// hold so corrupt input does not trigger huge allocations.
+func (r *reader) count(minSize int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minSize) > uint64(len(r.buf)) {
+		return 0, r.errorf("count %d exceeds data length", n)
+	}
+	return int(n), nil
+}
+
+func (r *reader) done() error {
+	if len(r.buf) != 0 {
+		return r.errorf("%d trailing bytes", len(r.buf))
+	}
+	return nil
+}
+
+func decodePoint(src []byte) (models.Location, bool, error) {
+	r := &reader{buf: src}
+	geomType, dims, err := r.header()
+	if err != nil {
+		return models.Location{}, false, err
+	}
+	if geomType != wkbPoint {
+		return models.Location{}, false, r.errorf("expected Point, got geometry type %d", geomType)
+	}
+	loc, err := r.coord(dims)
+	if err != nil {
+		return models.Location{}, false, err
+	}
+	if err := r.done(); err != nil {
+		return models.Location{}, false, err
+	}
+	// POINT EMPTY is encoded with NaN ordinates.
+	if math.IsNaN(loc.Longitude) && math.IsNaN(loc.Latitude) {
+		return models.Location{}, false, nil
+	}
+	return loc, true, nil
+}
+
+func decodeMultiPoint(src []byte) ([]models.Location, error) {
+	r := &reader{buf: src}
+	geomType, _, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	if geomType != wkbMultiPoint {
+		return nil, r.errorf("expected MultiPoint, got geometry type %d", geomType)
+	}
+	n, err := r.count(pointSize)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]models.Location, n)
+	for i := range locations {
+		pointType, dims, err := r.header()
+		if err != nil {
+			return nil, err
+		}
+		if pointType != wkbPoint {
+			return nil, r.errorf("expected Point in MultiPoint, got geometry type %d", pointType)
+		}
+		if locations[i], err = r.coord(dims); err != nil {
+			return nil, err
+		}
+	}
+	return locations, r.done()
+}
+
+func decodeLineString(src []byte) ([]models.Location, error) {
+	r := &reader{buf: src}
+	geomType, dims, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	if geomType != wkbLineString {
+		return nil, r.errorf("expected LineString, got geometry type %d", geomType)
+	}
+	n, err := r.count(dims * 8)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]models.Location, n)
+	for i := range locations {
+		if locations[i], err = r.coord(dims); err != nil {
+			return nil, err
+		}
+	}
+	return locations, r.done()
+}
+
+// decodePolygon reads a polygon without holes and returns its ring without
+// the closing point.
+func decodePolygon(src []byte) ([]models.Location, error) {
+	r := &reader{buf: src}
+	geomType, dims, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+	if geomType != wkbPolygon {
+		return nil, r.errorf("expected Polygon, got geometry type %d", geomType)
+	}
+	rings, err := r.count(4)
+	if err != nil {
+		return nil, err
+	}
+	if rings != 1 {
+		return nil, r.errorf("expected a Polygon with one ring, got %d", rings)
+	}
+	n, err := r.count(dims * 8)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]models.Location, n)
+	for i := range locations {
+		if locations[i], err = r.coord(dims); err != nil {
+			return nil, err
+		}
+	}
+	if n > 1 && locations[0] == locations[n-1] {
+		locations = locations[:n-1]
+	}
+	return locations, r.done()
+}
+
+func appendHeader(buf []byte, geomType uint32, withSRID bool) []byte {
+	buf = append(buf, 1)
+	if withSRID {
+		buf = binary.LittleEndian.AppendUint32(buf, geomType|ewkbSRID)
+		return binary.LittleEndian.AppendUint32(buf, SRID)
+	}
+	return binary.LittleEndian.AppendUint32(buf, geomType)
+}
+
+func appendCoord(buf []byte, loc models.Location) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(loc.Longitude))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(loc.Latitude))
+}
+
+func appendPoint(buf []byte, loc models.Location) []byte {
+	return appendCoord(appendHeader(buf, wkbPoint, true), loc)
+}
+
+func appendMultiPoint(buf []byte, locations []models.Location) []byte {
+	buf = appendHeader(buf, wkbMultiPoint, true)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(locations)))
+	for _, loc := range locations {
+		buf = appendCoord(appendHeader(buf, wkbPoint, false), loc)
+	}
+	return buf
+}
+
+func appendLineString(buf []byte, locations []models.Location) []byte {
+	buf = appendHeader(buf, wkbLineString, true)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(locations)))
+	for _, loc := range locations {
+		buf = appendCoord(buf, loc)
+	}
+	return buf
+}
+
+// appendPolygon writes locations as the only ring of a polygon, closing it
+// when the last point does not repeat the first.
+func appendPolygon(buf []byte, locations []models.Location) []byte {
+	open := len(locations) > 0 && locations[0] != locations[len(locations)-1]
+	n := len(locations)
+	if open {
+		n++
+	}
+	buf = appendHeader(buf, wkbPolygon, true)
+	buf = binary.LittleEndian.AppendUint32(buf, 1)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(n))
+	for _, loc := range locations {
+		buf = appendCoord(buf, loc)
+	}
+	if open {
+		buf = appendCoord(buf, locations[0])
+	}
+	return buf
+}
@@ -0,0 +1,221 @@
+package postgis
+
+import (
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// The vectors are what PostGIS returns from ST_AsEWKB, or ST_AsBinary for the
+// variants without SRID, for the WKT in each name.
+var (
+	pointSRIDLE     = "0101000020e6100000000000000000f03f0000000000000040"
+	pointLE         = "0101000000000000000000f03f0000000000000040"
+	pointSRIDBE     = "0020000001000010e63ff00000000000004000000000000000"
+	pointBE         = "00000000013ff00000000000004000000000000000"
+	pointEmpty      = "0101000020e6100000000000000000f87f000000000000f87f"
+	pointZISO       = "01e9030000000000000000f03f00000000000000400000000000000840"
+	pointZEWKB      = "0101000080000000000000f03f00000000000000400000000000000840"
+	multiPointSRID  = "0104000020e610000002000000" + pointLE + "010100000000000000000008400000000000001040"
+	multiPointBE    = "000000000400000001" + pointBE
+	multiPointEmpty = "0104000020e610000000000000"
+	lineStringSRID  = "0102000020e610000002000000000000000000f03f000000000000004000000000000008400000000000001040"
+	// POLYGON((0 0, 1 0, 1 1, 0 0))
+	polygonSRID = "0103000020e610000001000000040000000000000000000000000000000000000000000000" +
+		"0000f03f0000000000000000000000000000f03f000000000000f03f0000000000000000" +
+		"0000000000000000"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad test vector %q: %v", s, err)
+	}
+	return b
+}
+
+func TestDecodePoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		ewkb  string
+		want  models.Location
+		valid bool
+	}{
+		{"SRID=4326;POINT(1 2) little endian", pointSRIDLE, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"POINT(1 2) little endian", pointLE, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"SRID=4326;POINT(1 2) big endian", pointSRIDBE, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"POINT(1 2) big endian", pointBE, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"POINT Z (1 2 3) ISO", pointZISO, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"POINT Z (1 2 3) EWKB", pointZEWKB, models.Location{Longitude: 1, Latitude: 2}, true},
+		{"SRID=4326;POINT EMPTY", pointEmpty, models.Location{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid, err := decodePoint(mustHex(t, tt.ewkb))
+			if err != nil {
+				t.Fatalf("decodePoint: %v", err)
+			}
+			if got != tt.want || valid != tt.valid {
+				t.Errorf("decodePoint = %+v, %v, want %+v, %v", got, valid, tt.want, tt.valid)
+			}
+		})
+	}
+}
+
+func TestDecodeMultiPoint(t *testing.T) {
+	tests := []struct {
+		name string
+		ewkb string
+		want []models.Location
+	}{
+		{"SRID=4326;MULTIPOINT((1 2),(3 4))", multiPointSRID, []models.Location{{Longitude: 1, Latitude: 2}, {Longitude: 3, Latitude: 4}}},
+		{"MULTIPOINT((1 2)) big endian", multiPointBE, []models.Location{{Longitude: 1, Latitude: 2}}},
+		{"SRID=4326;MULTIPOINT EMPTY", multiPointEmpty, []models.Location{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeMultiPoint(mustHex(t, tt.ewkb))
+			if err != nil {
+				t.Fatalf("decodeMultiPoint: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("decodeMultiPoint = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePolygonDropsClosingPoint(t *testing.T) {
+	got, err := decodePolygon(mustHex(t, polygonSRID))
+	if err != nil {
+		t.Fatalf("decodePolygon: %v", err)
+	}
+	want := []models.Location{{}, {Longitude: 1}, {Longitude: 1, Latitude: 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("decodePolygon = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeMatchesPostGIS(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"Point", NewPoint(models.Location{Longitude: 1, Latitude: 2}).appendEWKB(nil), pointSRIDLE},
+		{"MultiPoint", NewMultiPoint([]models.Location{{Longitude: 1, Latitude: 2}, {Longitude: 3, Latitude: 4}}).appendEWKB(nil), multiPointSRID},
+		{"empty MultiPoint", NewMultiPoint(nil).appendEWKB(nil), multiPointEmpty},
+		{"LineString", NewLineString([]models.Location{{Longitude: 1, Latitude: 2}, {Longitude: 3, Latitude: 4}}).appendEWKB(nil), lineStringSRID},
+		{"open Polygon", NewPolygon([]models.Location{{}, {Longitude: 1}, {Longitude: 1, Latitude: 1}}).appendEWKB(nil), polygonSRID},
+		{"closed Polygon", NewPolygon([]models.Location{{}, {Longitude: 1}, {Longitude: 1, Latitude: 1}, {}}).appendEWKB(nil), polygonSRID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hex.EncodeToString(tt.got); got != tt.want {
+				t.Errorf("EWKB = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	locations := []models.Location{
+		{Latitude: -23.5505199, Longitude: -46.6333094},
+		{Latitude: -22.9068467, Longitude: -43.1728965},
+		{Latitude: 0.1, Longitude: 179.9999999},
+	}
+
+	var point Point
+	if err := point.scanEWKB(NewPoint(locations[0]).appendEWKB(nil)); err != nil || point.Location != locations[0] || !point.Valid {
+		t.Errorf("Point round trip = %+v, %v", point, err)
+	}
+	var multiPoint MultiPoint
+	if err := multiPoint.scanEWKB(NewMultiPoint(locations).appendEWKB(nil)); err != nil || !slices.Equal(multiPoint.Locations, locations) {
+		t.Errorf("MultiPoint round trip = %+v, %v", multiPoint, err)
+	}
+	var lineString LineString
+	if err := lineString.scanEWKB(NewLineString(locations).appendEWKB(nil)); err != nil || !slices.Equal(lineString.Locations, locations) {
+		t.Errorf("LineString round trip = %+v, %v", lineString, err)
+	}
+	var polygon Polygon
+	if err := polygon.scanEWKB(NewPolygon(locations).appendEWKB(nil)); err != nil || !slices.Equal(polygon.Locations, locations) {
+		t.Errorf("Polygon round trip = %+v, %v", polygon, err)
+	}
+}
+
+func TestScanSQL(t *testing.T) {
+	var point Point
+	if err := point.Scan(strings.ToUpper(pointSRIDLE)); err != nil || point.Location != (models.Location{Longitude: 1, Latitude: 2}) {
+		t.Errorf("Scan hex text = %+v, %v", point, err)
+	}
+	if err := point.Scan(nil); err != nil || point.Valid {
+		t.Errorf("Scan nil = %+v, %v", point, err)
+	}
+	if err := point.Scan(42); err == nil {
+		t.Error("Scan int succeeded")
+	}
+
+	value, err := NewMultiPoint([]models.Location{{Longitude: 1, Latitude: 2}, {Longitude: 3, Latitude: 4}}).Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	var multiPoint MultiPoint
+	if err := multiPoint.Scan(value); err != nil || len(multiPoint.Locations) != 2 {
+		t.Errorf("Scan Value = %+v, %v", multiPoint, err)
+	}
+}
+
+// Every prefix of a valid geometry must fail with ErrInvalidEWKB instead of
+// panicking or returning a partial result.
+func TestTruncatedInput(t *testing.T) {
+	decoders := []struct {
+		name   string
+		ewkb   string
+		decode func([]byte) error
+	}{
+		{"Point", pointSRIDBE, func(b []byte) error { _, _, err := decodePoint(b); return err }},
+		{"MultiPoint", multiPointSRID, func(b []byte) error { _, err := decodeMultiPoint(b); return err }},
+		{"LineString", lineStringSRID, func(b []byte) error { _, err := decodeLineString(b); return err }},
+		{"Polygon", polygonSRID, func(b []byte) error { _, err := decodePolygon(b); return err }},
+	}
+	for _, d := range decoders {
+		t.Run(d.name, func(t *testing.T) {
+			full := mustHex(t, d.ewkb)
+			for n := range len(full) {
+				if err := d.decode(full[:n]); !errors.Is(err, ErrInvalidEWKB) {
+					t.Fatalf("%d of %d bytes: err = %v, want ErrInvalidEWKB", n, len(full), err)
+				}
+			}
+			if err := d.decode(append(full, 0)); !errors.Is(err, ErrInvalidEWKB) {
+				t.Errorf("trailing byte: err = %v, want ErrInvalidEWKB", err)
+			}
+		})
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		ewkb string
+	}{
+		{"unknown byte order", "02" + pointLE[2:]},
+		{"wrong geometry type", lineStringSRID},
+		{"count beyond data", "0104000020e6100000ffffffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeMultiPoint(mustHex(t, tt.ewkb)); !errors.Is(err, ErrInvalidEWKB) {
+				t.Errorf("decodeMultiPoint err = %v, want ErrInvalidEWKB", err)
+			}
+		})
+	}
+
+	if _, err := decodePolygon(mustHex(t, "0103000000020000000000000000000000")); !errors.Is(err, ErrInvalidEWKB) {
+		t.Errorf("decodePolygon with two rings err = %v, want ErrInvalidEWKB", err)
+	}
+}
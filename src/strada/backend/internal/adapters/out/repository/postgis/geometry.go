@@ -0,0 +1,156 @@
+package postgis
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// geometry is implemented by the value types below so the codec can encode
+// them as EWKB.
+type geometry interface {
+	appendEWKB(buf []byte) []byte
+	isNull() bool
+}
+
+// geometryScanner is implemented by pointers to the value types below so the
+// codec can decode EWKB into them.
+type geometryScanner interface {
+	scanEWKB(src []byte) error
+	setNull()
+}
+
+type Point struct {
+	Location models.Location
+	Valid    bool
+}
+
+func NewPoint(loc models.Location) Point {
+	return Point{Location: loc, Valid: true}
+}
+
+func (p Point) appendEWKB(buf []byte) []byte { return appendPoint(buf, p.Location) }
+func (p Point) isNull() bool                 { return !p.Valid }
+func (p *Point) setNull()                    { *p = Point{} }
+
+func (p *Point) scanEWKB(src []byte) error {
+	loc, valid, err := decodePoint(src)
+	if err != nil {
+		return err
+	}
+	*p = Point{Location: loc, Valid: valid}
+	return nil
+}
+
+func (p *Point) Scan(src any) error          { return scanSQL(p, src) }
+func (p Point) Value() (driver.Value, error) { return valueSQL(p) }
+
+type MultiPoint struct {
+	Locations []models.Location
+	Valid     bool
+}
+
+func NewMultiPoint(locations []models.Location) MultiPoint {
+	return MultiPoint{Locations: locations, Valid: true}
+}
+
+func (m MultiPoint) appendEWKB(buf []byte) []byte { return appendMultiPoint(buf, m.Locations) }
+func (m MultiPoint) isNull() bool                 { return !m.Valid }
+func (m *MultiPoint) setNull()                    { *m = MultiPoint{} }
+
+func (m *MultiPoint) scanEWKB(src []byte) error {
+	locations, err := decodeMultiPoint(src)
+	if err != nil {
+		return err
+	}
+	*m = MultiPoint{Locations: locations, Valid: true}
+	return nil
+}
+
+func (m *MultiPoint) Scan(src any) error          { return scanSQL(m, src) }
+func (m MultiPoint) Value() (driver.Value, error) { return valueSQL(m) }
+
+type LineString struct {
+	Locations []models.Location
+	Valid     bool
+}
+
+func NewLineString(locations []models.Location) LineString {
+	return LineString{Locations: locations, Valid: true}
+}
+
+func (l LineString) appendEWKB(buf []byte) []byte { return appendLineString(buf, l.Locations) }
+func (l LineString) isNull() bool                 { return !l.Valid }
+func (l *LineString) setNull()                    { *l = LineString{} }
+
+func (l *LineString) scanEWKB(src []byte) error {
+	locations, err := decodeLineString(src)
+	if err != nil {
+		return err
+	}
+	*l = LineString{Locations: locations, Valid: true}
+	return nil
+}
+
+func (l *LineString) Scan(src any) error          { return scanSQL(l, src) }
+func (l LineString) Value() (driver.Value, error) { return valueSQL(l) }
+
+// Polygon holds a polygon without holes. Locations is its ring without the
+// closing point, which is added when encoding.
+type Polygon struct {
+	Locations []models.Location
+	Valid     bool
+}
+
+func NewPolygon(locations []models.Location) Polygon {
+	return Polygon{Locations: locations, Valid: true}
+}
+
+func (p Polygon) appendEWKB(buf []byte) []byte { return appendPolygon(buf, p.Locations) }
+func (p Polygon) isNull() bool                 { return !p.Valid }
+func (p *Polygon) setNull()                    { *p = Polygon{} }
+
+func (p *Polygon) scanEWKB(src []byte) error {
+	locations, err := decodePolygon(src)
+	if err != nil {
+		return err
+	}
+	*p = Polygon{Locations: locations, Valid: true}
+	return nil
+}
+
+func (p *Polygon) Scan(src any) error          { return scanSQL(p, src) }
+func (p Polygon) Value() (driver.Value, error) { return valueSQL(p) }
+
+// scanSQL backs the sql.Scanner implementations, used when the codec is not
+// registered on the connection and PostGIS sends hex-encoded EWKB as text.
+func scanSQL(dst geometryScanner, src any) error {
+	switch src := src.(type) {
+	case nil:
+		dst.setNull()
+		return nil
+	case string:
+		return scanText(dst, []byte(src))
+	case []byte:
+		return dst.scanEWKB(src)
+	default:
+		return fmt.Errorf("postgis: cannot scan %T", src)
+	}
+}
+
+func scanText(dst geometryScanner, src []byte) error {
+	raw := make([]byte, hex.DecodedLen(len(src)))
+	if _, err := hex.Decode(raw, src); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEWKB, err)
+	}
+	return dst.scanEWKB(raw)
+}
+
+func valueSQL(g geometry) (driver.Value, error) {
+	if g.isNull() {
+		return nil, nil
+	}
+	return hex.EncodeToString(g.appendEWKB(nil)), nil
+}
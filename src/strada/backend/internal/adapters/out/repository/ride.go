@@ -2,16 +2,14 @@ package repository
 
 import (
 	"context"
-	"fmt"
-	"math/big"
-	"regexp"
-	"strconv"
-	"strings"
+	"errors"
+	"time"
 
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
 	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
-	"github.com/244Walyson/shared-ride/internal/adapters/out/utils"
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -26,50 +24,46 @@ func NewRideRepository(db dbsqlc.DBTX) out.RideRepository {
 }
 
 func (r *RideRepository) Create(ctx context.Context, ride *models.Ride) (*models.Ride, error) {
-	var stopPoints []string
-
-	for _, loc := range ride.StopPoints {
-		stopPoints = append(stopPoints, fmt.Sprintf("(%f %f)", loc.Longitude, loc.Latitude))
-	}
-
-	multiPoint := fmt.Sprintf("MULTIPOINT(%s)", strings.Join(stopPoints, ", "))
-
-	rideRow, err := r.sqlc.CreateRide(ctx, dbsqlc.CreateRideParams{
+	rideRow, err := queries(ctx, r.sqlc).CreateRide(ctx, dbsqlc.CreateRideParams{
 		DriverID:        ride.DriverID,
 		VehicleID:       int32(ride.VehicleID),
 		StMakepoint:     ride.StartPoint.Longitude,
 		StMakepoint_2:   ride.StartPoint.Latitude,
 		StMakepoint_3:   ride.EndPoint.Longitude,
 		StMakepoint_4:   ride.EndPoint.Latitude,
-		StopPoints:      multiPoint,
+		StopPoints:      postgis.NewMultiPoint(ride.StopPoints),
 		Description:     pgtype.Text{String: ride.Description, Valid: true},
-		Distance:        pgtype.Numeric{Int: big.NewInt(int64(ride.Distance)), Valid: true},
+		Distance:        ride.DistanceMeters,
 		EstimatedTimeMs: int32(ride.EstimatedTimeMs),
-		Co2Emission:     pgtype.Numeric{Int: big.NewInt(int64(ride.Co2Emission)), Valid: true},
-		Cost:            pgtype.Numeric{Int: big.NewInt(int64(ride.Cost * 100)), Valid: true},
+		Co2Emission:     pgtype.Float8{Float64: ride.Co2EmissionKg, Valid: true},
+		Cost:            moneyToNumeric(ride.Cost),
+		CostCeiling:     ceilingToNumeric(ride.CostCeiling),
+		MaxDetourMeters: ride.MaxDetourMeters,
+		MaxDetourMs:     ride.MaxDetourMs,
+		Co2SavedKg:      ride.Co2SavedKg,
+		CompletedAt:     timestampParam(ride.CompletedAt),
+		SchoolID:        idParam(ride.SchoolID),
+		Currency:        ride.Cost.Currency,
 		ImgUrl:          pgtype.Text{String: ride.ImgUrl, Valid: true},
 	})
 	if err != nil {
 		return nil, err
 	}
 	ride.ID = rideRow.ID
+	ride.Version = rideRow.Version
 	return ride, nil
 }
 
-func (r *RideRepository) FindNear(ctx context.Context, locations []*models.Location) ([]*models.Ride, error) {
-
-	var points []pgtype.Point
-
-	for _, loc := range locations {
-		points = append(points, pgtype.Point{
-			P:     pgtype.Vec2{X: loc.Longitude, Y: loc.Latitude},
-			Valid: true,
-		})
+func (r *RideRepository) FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.Ride, error) {
+	if len(locations) == 0 {
+		return nil, nil
 	}
 
-	rides, err := r.sqlc.FindNearRides(ctx, dbsqlc.FindNearRidesParams{
-		Column1:   points,
-		StDwithin: 1000,
+	rides, err := queries(ctx, r.sqlc).FindNearRides(ctx, dbsqlc.FindNearRidesParams{
+		Route:        routeParam(locations),
+		RadiusMeters: models.NearRadiusMeters,
+		SchoolID:     schoolId,
+		MaxResults:   models.NearMaxResults,
 	})
 
 	if err != nil {
@@ -83,16 +77,24 @@ func (r *RideRepository) FindNear(ctx context.Context, locations []*models.Locat
 			ID:              rides[i].ID,
 			DriverID:        rides[i].DriverID,
 			VehicleID:       rides[i].VehicleID,
-			StartPoint:      *utils.ParsePointToLocation(rides[i].StartPoint.(string)),
-			EndPoint:        *utils.ParsePointToLocation(rides[i].EndPoint.(string)),
-			Distance:        int32(rides[i].Distance.Int.Int64()),
+			StartPoint:      rides[i].StartPoint.Location,
+			EndPoint:        rides[i].EndPoint.Location,
+			DistanceMeters:  rides[i].Distance,
 			EstimatedTimeMs: int32(rides[i].EstimatedTimeMs),
-			Co2Emission:     int32(rides[i].Co2Emission.Int.Int64()),
-			Cost:            int32(rides[i].Cost.Int.Int64()),
+			Co2EmissionKg:   rides[i].Co2Emission.Float64,
+			Cost:            numericToMoney(rides[i].Cost, rides[i].Currency),
+			CostCeiling:     numericToCeiling(rides[i].CostCeiling, rides[i].Currency),
+			MaxDetourMeters: rides[i].MaxDetourMeters,
+			MaxDetourMs:     rides[i].MaxDetourMs,
+			SchoolID:        rides[i].SchoolID.Int32,
+			Co2SavedKg:      rides[i].Co2SavedKg,
+			CompletedAt:     rides[i].CompletedAt.Time,
+			StopPoints:      rides[i].StopPoints.Locations,
 			Description:     rides[i].Description.String,
 			CreatedAt:       rides[i].CreatedAt.Time,
 			UpdatedAt:       rides[i].UpdatedAt.Time,
 			ImgUrl:          rides[i].ImgUrl.String,
+			Version:         rides[i].Version,
 		})
 	}
 
@@ -100,12 +102,10 @@ func (r *RideRepository) FindNear(ctx context.Context, locations []*models.Locat
 }
 
 func (r *RideRepository) FindById(ctx context.Context, id int32) (*models.Ride, error) {
-	ride, err := r.sqlc.FindRideByID(ctx, id)
-	if err != nil {
-		return nil, err
+	ride, err := queries(ctx, r.sqlc).FindRideByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrRideNotFound
 	}
-
-	locations, err := ParseMultiPointToLocations(ride.StopPoints.(string))
 	if err != nil {
 		return nil, err
 	}
@@ -114,14 +114,21 @@ func (r *RideRepository) FindById(ctx context.Context, id int32) (*models.Ride,
 		ID:              ride.ID,
 		DriverID:        ride.DriverID,
 		VehicleID:       ride.VehicleID,
-		StartPoint:      *utils.ParsePointToLocation(ride.StartPoint.(string)),
-		EndPoint:        *utils.ParsePointToLocation(ride.EndPoint.(string)),
-		Distance:        int32(ride.Distance.Int.Int64()),
+		StartPoint:      ride.StartPoint.Location,
+		EndPoint:        ride.EndPoint.Location,
+		DistanceMeters:  ride.Distance,
 		EstimatedTimeMs: int32(ride.EstimatedTimeMs),
-		Co2Emission:     int32(ride.Co2Emission.Int.Int64()),
-		Cost:            int32(ride.Cost.Int.Int64()),
-		StopPoints:      locations,
+		Co2EmissionKg:   ride.Co2Emission.Float64,
+		Cost:            numericToMoney(ride.Cost, ride.Currency),
+		CostCeiling:     numericToCeiling(ride.CostCeiling, ride.Currency),
+		MaxDetourMeters: ride.MaxDetourMeters,
+		MaxDetourMs:     ride.MaxDetourMs,
+		SchoolID:        ride.SchoolID.Int32,
+		Co2SavedKg:      ride.Co2SavedKg,
+		CompletedAt:     ride.CompletedAt.Time,
+		StopPoints:      ride.StopPoints.Locations,
 		ImgUrl:          ride.ImgUrl.String,
+		Version:         ride.Version,
 		Description:     ride.Description.String,
 		CreatedAt:       ride.CreatedAt.Time,
 		UpdatedAt:       ride.UpdatedAt.Time,
@@ -129,7 +136,7 @@ func (r *RideRepository) FindById(ctx context.Context, id int32) (*models.Ride,
 }
 
 func (r *RideRepository) FindAll(ctx context.Context) ([]*models.Ride, error) {
-	rides, err := r.sqlc.FindAllRides(ctx)
+	rides, err := queries(ctx, r.sqlc).FindAllRides(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -139,71 +146,364 @@ func (r *RideRepository) FindAll(ctx context.Context) ([]*models.Ride, error) {
 			ID:              rides[i].ID,
 			DriverID:        rides[i].DriverID,
 			VehicleID:       rides[i].VehicleID,
-			StartPoint:      *utils.ParsePointToLocation(rides[i].StartPoint.(string)),
-			EndPoint:        *utils.ParsePointToLocation(rides[i].EndPoint.(string)),
-			Distance:        int32(rides[i].Distance.Int.Int64()),
+			StartPoint:      rides[i].StartPoint.Location,
+			EndPoint:        rides[i].EndPoint.Location,
+			DistanceMeters:  rides[i].Distance,
 			EstimatedTimeMs: int32(rides[i].EstimatedTimeMs),
-			Co2Emission:     int32(rides[i].Co2Emission.Int.Int64()),
-			Cost:            int32(rides[i].Cost.Int.Int64()),
+			Co2EmissionKg:   rides[i].Co2Emission.Float64,
+			Cost:            numericToMoney(rides[i].Cost, rides[i].Currency),
+			CostCeiling:     numericToCeiling(rides[i].CostCeiling, rides[i].Currency),
+			MaxDetourMeters: rides[i].MaxDetourMeters,
+			MaxDetourMs:     rides[i].MaxDetourMs,
+			SchoolID:        rides[i].SchoolID.Int32,
+			Co2SavedKg:      rides[i].Co2SavedKg,
+			CompletedAt:     rides[i].CompletedAt.Time,
 			Description:     rides[i].Description.String,
 			CreatedAt:       rides[i].CreatedAt.Time,
 			UpdatedAt:       rides[i].UpdatedAt.Time,
 			ImgUrl:          rides[i].ImgUrl.String,
+			Version:         rides[i].Version,
 		}
 	}
 	return ridePtrs, nil
 }
 
-func (r *RideRepository) Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error) {
-	return nil, nil
+func (r *RideRepository) List(ctx context.Context, filter models.RideFilter, after *models.Cursor, limit int) ([]*models.Ride, error) {
+	sortColumn, ok := rideSortColumns[filter.SortBy]
+	if !ok {
+		return nil, models.ErrInvalidSort
+	}
+	cursor, err := toCursorParams(after, sortColumn)
+	if err != nil {
+		return nil, err
+	}
+	box := toBoundingBoxParams(filter.BoundingBox)
+
+	rides, err := queries(ctx, r.sqlc).ListRides(ctx, dbsqlc.ListRidesParams{
+		DriverID:     textParam(filter.DriverID),
+		PassengerID:  textParam(filter.PassengerID),
+		CreatedFrom:  timestampParam(filter.From),
+		CreatedTo:    timestampParam(filter.To),
+		MinLon:       box.minLon,
+		MinLat:       box.minLat,
+		MaxLon:       box.maxLon,
+		MaxLat:       box.maxLat,
+		CursorID:     cursor.id,
+		SortBy:       sortColumn,
+		Descending:   filter.Descending,
+		CursorTime:   cursor.time,
+		CursorNumber: cursor.number,
+		PageSize:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ridePtrs := make([]*models.Ride, len(rides))
+	for i := range rides {
+		ridePtrs[i] = &models.Ride{
+			ID:              rides[i].ID,
+			DriverID:        rides[i].DriverID,
+			VehicleID:       rides[i].VehicleID,
+			StartPoint:      rides[i].StartPoint.Location,
+			EndPoint:        rides[i].EndPoint.Location,
+			DistanceMeters:  rides[i].Distance,
+			EstimatedTimeMs: rides[i].EstimatedTimeMs,
+			Co2EmissionKg:   rides[i].Co2Emission.Float64,
+			Cost:            numericToMoney(rides[i].Cost, rides[i].Currency),
+			CostCeiling:     numericToCeiling(rides[i].CostCeiling, rides[i].Currency),
+			MaxDetourMeters: rides[i].MaxDetourMeters,
+			MaxDetourMs:     rides[i].MaxDetourMs,
+			SchoolID:        rides[i].SchoolID.Int32,
+			Co2SavedKg:      rides[i].Co2SavedKg,
+			CompletedAt:     rides[i].CompletedAt.Time,
+			StopPoints:      rides[i].StopPoints.Locations,
+			Description:     rides[i].Description.String,
+			CreatedAt:       rides[i].CreatedAt.Time,
+			UpdatedAt:       rides[i].UpdatedAt.Time,
+			ImgUrl:          rides[i].ImgUrl.String,
+			Version:         rides[i].Version,
+		}
+	}
+	return ridePtrs, nil
 }
 
-func (r *RideRepository) Delete(ctx context.Context, id int32) error {
-	return nil
+func (r *RideRepository) FindByUser(ctx context.Context, userId string) ([]*models.Ride, error) {
+	rides, err := queries(ctx, r.sqlc).FindRidesByUserID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	ridePtrs := make([]*models.Ride, len(rides))
+	for i := range rides {
+		ridePtrs[i] = &models.Ride{
+			ID:              rides[i].ID,
+			DriverID:        rides[i].DriverID,
+			VehicleID:       rides[i].VehicleID,
+			StartPoint:      rides[i].StartPoint.Location,
+			EndPoint:        rides[i].EndPoint.Location,
+			DistanceMeters:  rides[i].Distance,
+			EstimatedTimeMs: int32(rides[i].EstimatedTimeMs),
+			Co2EmissionKg:   rides[i].Co2Emission.Float64,
+			Cost:            numericToMoney(rides[i].Cost, rides[i].Currency),
+			CostCeiling:     numericToCeiling(rides[i].CostCeiling, rides[i].Currency),
+			MaxDetourMeters: rides[i].MaxDetourMeters,
+			MaxDetourMs:     rides[i].MaxDetourMs,
+			SchoolID:        rides[i].SchoolID.Int32,
+			Co2SavedKg:      rides[i].Co2SavedKg,
+			CompletedAt:     rides[i].CompletedAt.Time,
+			StopPoints:      rides[i].StopPoints.Locations,
+			Description:     rides[i].Description.String,
+			CreatedAt:       rides[i].CreatedAt.Time,
+			UpdatedAt:       rides[i].UpdatedAt.Time,
+			ImgUrl:          rides[i].ImgUrl.String,
+			Version:         rides[i].Version,
+		}
+	}
+	return ridePtrs, nil
+}
+
+func (r *RideRepository) FindCompleted(ctx context.Context, userIds []string, from time.Time, to time.Time) ([]*models.Ride, error) {
+	rides, err := queries(ctx, r.sqlc).FindCompletedRidesByUserIDs(ctx, dbsqlc.FindCompletedRidesByUserIDsParams{
+		CompletedFrom: timestampParam(from),
+		CompletedTo:   timestampParam(to),
+		UserIds:       userIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ridePtrs := make([]*models.Ride, len(rides))
+	for i := range rides {
+		ridePtrs[i] = &models.Ride{
+			ID:              rides[i].ID,
+			DriverID:        rides[i].DriverID,
+			VehicleID:       rides[i].VehicleID,
+			StartPoint:      rides[i].StartPoint.Location,
+			EndPoint:        rides[i].EndPoint.Location,
+			DistanceMeters:  rides[i].Distance,
+			EstimatedTimeMs: rides[i].EstimatedTimeMs,
+			Co2EmissionKg:   rides[i].Co2Emission.Float64,
+			Cost:            numericToMoney(rides[i].Cost, rides[i].Currency),
+			CostCeiling:     numericToCeiling(rides[i].CostCeiling, rides[i].Currency),
+			MaxDetourMeters: rides[i].MaxDetourMeters,
+			MaxDetourMs:     rides[i].MaxDetourMs,
+			SchoolID:        rides[i].SchoolID.Int32,
+			Co2SavedKg:      rides[i].Co2SavedKg,
+			CompletedAt:     rides[i].CompletedAt.Time,
+			StopPoints:      rides[i].StopPoints.Locations,
+			Description:     rides[i].Description.String,
+			CreatedAt:       rides[i].CreatedAt.Time,
+			UpdatedAt:       rides[i].UpdatedAt.Time,
+			ImgUrl:          rides[i].ImgUrl.String,
+			Version:         rides[i].Version,
+		}
+	}
+	return ridePtrs, nil
 }
 
-func ParseMultiPointToLocations(multiPoint string) ([]models.Location, error) {
-	if !strings.HasPrefix(multiPoint, "MULTIPOINT(") || !strings.HasSuffix(multiPoint, ")") {
-		return nil, fmt.Errorf("formato MULTIPOINT inválido: %s", multiPoint)
+func (r *RideRepository) Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error) {
+	row, err := queries(ctx, r.sqlc).UpdateRide(ctx, dbsqlc.UpdateRideParams{
+		ID:              id,
+		DriverID:        ride.DriverID,
+		VehicleID:       ride.VehicleID,
+		StMakepoint:     ride.StartPoint.Longitude,
+		StMakepoint_2:   ride.StartPoint.Latitude,
+		StMakepoint_3:   ride.EndPoint.Longitude,
+		StMakepoint_4:   ride.EndPoint.Latitude,
+		Distance:        ride.DistanceMeters,
+		EstimatedTimeMs: ride.EstimatedTimeMs,
+		Co2Emission:     pgtype.Float8{Float64: ride.Co2EmissionKg, Valid: true},
+		Cost:            moneyToNumeric(ride.Cost),
+		CostCeiling:     ceilingToNumeric(ride.CostCeiling),
+		MaxDetourMeters: ride.MaxDetourMeters,
+		MaxDetourMs:     ride.MaxDetourMs,
+		Co2SavedKg:      ride.Co2SavedKg,
+		CompletedAt:     timestampParam(ride.CompletedAt),
+		SchoolID:        idParam(ride.SchoolID),
+		Currency:        ride.Cost.Currency,
+		StopPoints:      postgis.NewMultiPoint(ride.StopPoints),
+		Description:     pgtype.Text{String: ride.Description, Valid: true},
+		ImgUrl:          pgtype.Text{String: ride.ImgUrl, Valid: true},
+		Version:         ride.Version,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, r.updateMissError(ctx, id)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Ride{
+		ID:              row.ID,
+		DriverID:        row.DriverID,
+		VehicleID:       row.VehicleID,
+		StartPoint:      row.StartPoint.Location,
+		EndPoint:        row.EndPoint.Location,
+		DistanceMeters:  row.Distance,
+		EstimatedTimeMs: row.EstimatedTimeMs,
+		Co2EmissionKg:   row.Co2Emission.Float64,
+		Cost:            numericToMoney(row.Cost, row.Currency),
+		CostCeiling:     numericToCeiling(row.CostCeiling, row.Currency),
+		MaxDetourMeters: row.MaxDetourMeters,
+		MaxDetourMs:     row.MaxDetourMs,
+		SchoolID:        row.SchoolID.Int32,
+		Co2SavedKg:      row.Co2SavedKg,
+		CompletedAt:     row.CompletedAt.Time,
+		StopPoints:      row.StopPoints.Locations,
+		ImgUrl:          row.ImgUrl.String,
+		Version:         row.Version,
+		Description:     row.Description.String,
+		CreatedAt:       row.CreatedAt.Time,
+		UpdatedAt:       row.UpdatedAt.Time,
+	}, nil
+}
 
-	multiPoint = strings.TrimPrefix(multiPoint, "MULTIPOINT(")
-	multiPoint = strings.TrimSuffix(multiPoint, ")")
+// updateMissError tells a missing ride from one whose version moved on.
+func (r *RideRepository) updateMissError(ctx context.Context, id int32) error {
+	_, err := queries(ctx, r.sqlc).FindRideByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrRideNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return models.ErrVersionConflict
+}
 
-	rawPoints := strings.Split(multiPoint, ",")
-	if len(rawPoints) == 0 {
-		return nil, fmt.Errorf("nenhum ponto encontrado em: %s", multiPoint)
+// Delete soft-deletes the ride; it stays in the database for the history.
+func (r *RideRepository) Delete(ctx context.Context, id int32) error {
+	deleted, err := queries(ctx, r.sqlc).DeleteRide(ctx, id)
+	if err != nil {
+		return err
 	}
+	if deleted == 0 {
+		return models.ErrRideNotFound
+	}
+	return nil
+}
 
-	locations := make([]models.Location, 0, len(rawPoints))
-	re := regexp.MustCompile(`^-?\d+\.\d+\s+-?\d+\.\d+$`)
+func (r *RideRepository) FindPassengers(ctx context.Context, rideIds []int32) ([]*models.RidePassenger, error) {
+	rows, err := queries(ctx, r.sqlc).FindRidePassengersByRideIDs(ctx, rideIds)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, point := range rawPoints {
-		point = strings.TrimSpace(strings.Trim(point, "()"))
-		if !re.MatchString(point) {
-			return nil, fmt.Errorf("ponto inválido: %s", point)
+	passengers := make([]*models.RidePassenger, len(rows))
+	for i := range rows {
+		passengers[i] = &models.RidePassenger{
+			RideID:         rows[i].RideID,
+			UserID:         rows[i].UserID,
+			StartPoint:     rows[i].StartPoint.Location,
+			EndPoint:       rows[i].EndPoint.Location,
+			Role:           rows[i].Role,
+			DistanceMeters: rows[i].DistanceMeters,
+			Fare:           numericToMoney(rows[i].Fare, rows[i].Currency),
+			Co2SavedKg:     rows[i].Co2SavedKg,
+			CreatedAt:      rows[i].CreatedAt.Time,
 		}
+	}
+	return passengers, nil
+}
 
-		coords := strings.Fields(point)
+func (r *RideRepository) AddPassenger(ctx context.Context, passenger *models.RidePassenger) error {
+	createdAt, err := queries(ctx, r.sqlc).AddRidePassenger(ctx, dbsqlc.AddRidePassengerParams{
+		RideID:         passenger.RideID,
+		UserID:         passenger.UserID,
+		StMakepoint:    passenger.StartPoint.Longitude,
+		StMakepoint_2:  passenger.StartPoint.Latitude,
+		StMakepoint_3:  passenger.EndPoint.Longitude,
+		StMakepoint_4:  passenger.EndPoint.Latitude,
+		Role:           passenger.Role,
+		DistanceMeters: passenger.DistanceMeters,
+	})
+	if isUniqueViolation(err) {
+		return models.ErrAlreadyJoined
+	}
+	if err != nil {
+		return err
+	}
+	passenger.CreatedAt = createdAt.Time
+	return nil
+}
 
-		if len(coords) != 2 {
-			return nil, fmt.Errorf("coordenadas inválidas: %s", point)
-		}
+func (r *RideRepository) RemovePassenger(ctx context.Context, rideId int32, userId string) error {
+	removed, err := queries(ctx, r.sqlc).RemoveRidePassenger(ctx, dbsqlc.RemoveRidePassengerParams{
+		RideID: rideId,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return models.ErrPassengerNotFound
+	}
+	return nil
+}
 
-		longitude, err := strconv.ParseFloat(coords[0], 64)
+func (r *RideRepository) UpdatePassengerFares(ctx context.Context, rideId int32, fares []*models.PassengerFare) error {
+	for _, fare := range fares {
+		err := queries(ctx, r.sqlc).UpdateRidePassengerFare(ctx, dbsqlc.UpdateRidePassengerFareParams{
+			RideID:         rideId,
+			UserID:         fare.UserID,
+			DistanceMeters: fare.DistanceMeters,
+			Fare:           moneyToNumeric(fare.Fare),
+		})
 		if err != nil {
-			return nil, fmt.Errorf("erro ao converter longitude: %v", err)
+			return err
 		}
-		latitude, err := strconv.ParseFloat(coords[1], 64)
+	}
+	return nil
+}
+
+func (r *RideRepository) ReplaceStops(ctx context.Context, rideId int32, stops []*models.RideStop) error {
+	if err := queries(ctx, r.sqlc).DeleteRideStops(ctx, rideId); err != nil {
+		return err
+	}
+	for i, stop := range stops {
+		err := queries(ctx, r.sqlc).CreateRideStop(ctx, dbsqlc.CreateRideStopParams{
+			RideID:         rideId,
+			Position:       int32(i),
+			Kind:           stop.Kind,
+			UserID:         textParam(stop.UserID),
+			StMakepoint:    stop.Location.Longitude,
+			StMakepoint_2:  stop.Location.Latitude,
+			DistanceMeters: stop.DistanceMeters,
+			EtaMs:          stop.EtaMs,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("erro ao converter latitude: %v", err)
+			return err
 		}
+	}
+	return nil
+}
 
-		locations = append(locations, models.Location{
-			Longitude: longitude,
-			Latitude:  latitude,
-		})
+func (r *RideRepository) FindStops(ctx context.Context, rideId int32) ([]*models.RideStop, error) {
+	rows, err := queries(ctx, r.sqlc).FindRideStops(ctx, rideId)
+	if err != nil {
+		return nil, err
 	}
+	stops := make([]*models.RideStop, len(rows))
+	for i, row := range rows {
+		stops[i] = &models.RideStop{
+			Kind:           row.Kind,
+			UserID:         row.UserID.String,
+			Location:       row.Location.Location,
+			DistanceMeters: row.DistanceMeters,
+			EtaMs:          row.EtaMs,
+		}
+	}
+	return stops, nil
+}
 
-	return locations, nil
+func (r *RideRepository) UpdatePassengerEmissions(ctx context.Context, rideId int32, emissions []*models.PassengerEmission) error {
+	for _, emission := range emissions {
+		err := queries(ctx, r.sqlc).UpdateRidePassengerCo2Saved(ctx, dbsqlc.UpdateRidePassengerCo2SavedParams{
+			RideID:     rideId,
+			UserID:     emission.UserID,
+			Co2SavedKg: emission.SavedKg,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
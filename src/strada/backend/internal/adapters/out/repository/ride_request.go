@@ -2,12 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
-	"github.com/244Walyson/shared-ride/internal/adapters/out/utils"
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -22,7 +23,7 @@ func NewRideRequestRepository(db dbsqlc.DBTX) out.RideRequestRepository {
 }
 
 func (r *RideRequestRepository) Create(ctx context.Context, rideRequest *models.RideRequest) (*models.RideRequest, error) {
-	rideRequestRow, err := r.sqlc.CreateRideRequest(ctx, dbsqlc.CreateRideRequestParams{
+	rideRequestRow, err := queries(ctx, r.sqlc).CreateRideRequest(ctx, dbsqlc.CreateRideRequestParams{
 		PassengerID:   rideRequest.PassengerID,
 		StMakepoint:   rideRequest.Origin.Longitude,
 		StMakepoint_2: rideRequest.Origin.Latitude,
@@ -33,6 +34,8 @@ func (r *RideRequestRepository) Create(ctx context.Context, rideRequest *models.
 			Valid: true,
 		},
 		Description: pgtype.Text{String: rideRequest.Description, Valid: true},
+		ImgUrl:      pgtype.Text{String: rideRequest.ImgUrl, Valid: rideRequest.ImgUrl != ""},
+		SchoolID:    idParam(rideRequest.SchoolID),
 	})
 
 	if err != nil {
@@ -43,16 +46,22 @@ func (r *RideRequestRepository) Create(ctx context.Context, rideRequest *models.
 	return &models.RideRequest{
 		ID:           rideRequestRow.ID,
 		PassengerID:  rideRequestRow.PassengerID,
-		Origin:       rideRequest.Origin,
-		Destination:  rideRequest.Destination,
-		RideDatetime: rideRequest.RideDatetime,
-		Description:  rideRequest.Description,
-		Status:       rideRequest.Status,
+		Origin:       rideRequestRow.Origin.Location,
+		Destination:  rideRequestRow.Destination.Location,
+		RideDatetime: rideRequestRow.RideDatetime.Time,
+		Description:  rideRequestRow.Description.String,
+		ImgUrl:       rideRequestRow.ImgUrl.String,
+		Version:      rideRequestRow.Version,
+		Status:       rideRequestRow.Status.String,
+		SchoolID:     rideRequestRow.SchoolID.Int32,
 	}, nil
 }
 
 func (r *RideRequestRepository) FindById(ctx context.Context, id int32) (*models.RideRequest, error) {
-	rideRequest, err := r.sqlc.FindRideRequestByID(ctx, id)
+	rideRequest, err := queries(ctx, r.sqlc).FindRideRequestByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrRideRequestNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -60,17 +69,19 @@ func (r *RideRequestRepository) FindById(ctx context.Context, id int32) (*models
 	return &models.RideRequest{
 		ID:           rideRequest.ID,
 		PassengerID:  rideRequest.PassengerID,
-		Origin:       *utils.ParsePointToLocation(rideRequest.Origin.(string)),
-		Destination:  *utils.ParsePointToLocation(rideRequest.Destination.(string)),
+		Origin:       rideRequest.Origin.Location,
+		Destination:  rideRequest.Destination.Location,
 		ImgUrl:       rideRequest.ImgUrl.String,
+		Version:      rideRequest.Version,
 		RideDatetime: rideRequest.RideDatetime.Time,
 		Description:  rideRequest.Description.String,
 		Status:       rideRequest.Status.String,
+		SchoolID:     rideRequest.SchoolID.Int32,
 	}, nil
 }
 
 func (r *RideRequestRepository) FindAll(ctx context.Context) ([]*models.RideRequest, error) {
-	rideRequests, err := r.sqlc.FindAllRideRequests(ctx)
+	rideRequests, err := queries(ctx, r.sqlc).FindAllRideRequests(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -80,31 +91,79 @@ func (r *RideRequestRepository) FindAll(ctx context.Context) ([]*models.RideRequ
 		rideRequestPtrs[i] = &models.RideRequest{
 			ID:           rideRequests[i].ID,
 			PassengerID:  rideRequests[i].PassengerID,
-			Origin:       *utils.ParsePointToLocation(rideRequests[i].Origin.(string)),
-			Destination:  *utils.ParsePointToLocation(rideRequests[i].Destination.(string)),
+			Origin:       rideRequests[i].Origin.Location,
+			Destination:  rideRequests[i].Destination.Location,
 			ImgUrl:       rideRequests[i].ImgUrl.String,
+			Version:      rideRequests[i].Version,
 			RideDatetime: rideRequests[i].RideDatetime.Time,
 			Description:  rideRequests[i].Description.String,
 			Status:       rideRequests[i].Status.String,
+			SchoolID:     rideRequests[i].SchoolID.Int32,
 		}
 	}
 
 	return rideRequestPtrs, nil
 }
 
-func (r *RideRequestRepository) FindNear(ctx context.Context, locations []*models.Location) ([]*models.RideRequest, error) {
-	var points []pgtype.Point
+func (r *RideRequestRepository) List(ctx context.Context, filter models.RideRequestFilter, after *models.Cursor, limit int) ([]*models.RideRequest, error) {
+	sortColumn, ok := rideRequestSortColumns[filter.SortBy]
+	if !ok {
+		return nil, models.ErrInvalidSort
+	}
+	cursor, err := toCursorParams(after, sortColumn)
+	if err != nil {
+		return nil, err
+	}
+	box := toBoundingBoxParams(filter.BoundingBox)
+
+	rideRequests, err := queries(ctx, r.sqlc).ListRideRequests(ctx, dbsqlc.ListRideRequestsParams{
+		PassengerID: textParam(filter.PassengerID),
+		Status:      textParam(filter.Status),
+		RideFrom:    timestampParam(filter.From),
+		RideTo:      timestampParam(filter.To),
+		MinLon:      box.minLon,
+		MinLat:      box.minLat,
+		MaxLon:      box.maxLon,
+		MaxLat:      box.maxLat,
+		CursorID:    cursor.id,
+		SortBy:      sortColumn,
+		Descending:  filter.Descending,
+		CursorTime:  cursor.time,
+		PageSize:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	for _, loc := range locations {
-		points = append(points, pgtype.Point{
-			P:     pgtype.Vec2{X: loc.Longitude, Y: loc.Latitude},
-			Valid: true,
-		})
+	rideRequestPtrs := make([]*models.RideRequest, len(rideRequests))
+	for i := range rideRequests {
+		rideRequestPtrs[i] = &models.RideRequest{
+			ID:           rideRequests[i].ID,
+			PassengerID:  rideRequests[i].PassengerID,
+			Origin:       rideRequests[i].Origin.Location,
+			Destination:  rideRequests[i].Destination.Location,
+			ImgUrl:       rideRequests[i].ImgUrl.String,
+			Version:      rideRequests[i].Version,
+			RideDatetime: rideRequests[i].RideDatetime.Time,
+			Description:  rideRequests[i].Description.String,
+			Status:       rideRequests[i].Status.String,
+			SchoolID:     rideRequests[i].SchoolID.Int32,
+		}
 	}
 
-	rideRequests, err := r.sqlc.FindNearRideRequests(ctx, dbsqlc.FindNearRideRequestsParams{
-		Column1:   points,
-		StDwithin: 1000,
+	return rideRequestPtrs, nil
+}
+
+func (r *RideRequestRepository) FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.RideRequest, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	rideRequests, err := queries(ctx, r.sqlc).FindNearRideRequests(ctx, dbsqlc.FindNearRideRequestsParams{
+		Route:        routeParam(locations),
+		RadiusMeters: models.NearRadiusMeters,
+		SchoolID:     schoolId,
+		MaxResults:   models.NearMaxResults,
 	})
 
 	if err != nil {
@@ -116,12 +175,14 @@ func (r *RideRequestRepository) FindNear(ctx context.Context, locations []*model
 		rideRequestPtrs[i] = &models.RideRequest{
 			ID:           rideRequests[i].ID,
 			PassengerID:  rideRequests[i].PassengerID,
-			Origin:       *utils.ParsePointToLocation(rideRequests[i].Origin.(string)),
-			Destination:  *utils.ParsePointToLocation(rideRequests[i].Destination.(string)),
+			Origin:       rideRequests[i].Origin.Location,
+			Destination:  rideRequests[i].Destination.Location,
 			ImgUrl:       rideRequests[i].ImgUrl.String,
+			Version:      rideRequests[i].Version,
 			RideDatetime: rideRequests[i].RideDatetime.Time,
 			Description:  rideRequests[i].Description.String,
 			Status:       rideRequests[i].Status.String,
+			SchoolID:     rideRequests[i].SchoolID.Int32,
 		}
 	}
 
@@ -129,13 +190,13 @@ func (r *RideRequestRepository) FindNear(ctx context.Context, locations []*model
 }
 
 func (r *RideRequestRepository) Update(ctx context.Context, id int32, rideRequest *models.RideRequest) (*models.RideRequest, error) {
-	_, err := r.sqlc.UpdateRideRequest(ctx, dbsqlc.UpdateRideRequestParams{
+	row, err := queries(ctx, r.sqlc).UpdateRideRequest(ctx, dbsqlc.UpdateRideRequestParams{
 		ID:            id,
 		PassengerID:   rideRequest.PassengerID,
-		StMakepoint:   rideRequest.Origin.Latitude,
-		StMakepoint_2: rideRequest.Origin.Longitude,
-		StMakepoint_3: rideRequest.Destination.Latitude,
-		StMakepoint_4: rideRequest.Destination.Longitude,
+		StMakepoint:   rideRequest.Origin.Longitude,
+		StMakepoint_2: rideRequest.Origin.Latitude,
+		StMakepoint_3: rideRequest.Destination.Longitude,
+		StMakepoint_4: rideRequest.Destination.Latitude,
 		ImgUrl:        pgtype.Text{String: rideRequest.ImgUrl, Valid: true},
 		RideDatetime: pgtype.Timestamp{
 			Time:  rideRequest.RideDatetime,
@@ -143,19 +204,44 @@ func (r *RideRequestRepository) Update(ctx context.Context, id int32, rideReques
 		},
 		Status:      pgtype.Text{String: rideRequest.Status, Valid: true},
 		Description: pgtype.Text{String: rideRequest.Description, Valid: true},
+		Version:     rideRequest.Version,
+		SchoolID:    idParam(rideRequest.SchoolID),
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, r.updateMissError(ctx, id)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	rideRequest.ID = id
+	rideRequest.Version = row.Version
 	return rideRequest, nil
 }
 
+// updateMissError tells a missing ride request from one whose version moved
+// on.
+func (r *RideRequestRepository) updateMissError(ctx context.Context, id int32) error {
+	_, err := queries(ctx, r.sqlc).FindRideRequestByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrRideRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return models.ErrVersionConflict
+}
+
+// Delete soft-deletes the ride request; it stays in the database for the
+// history.
 func (r *RideRequestRepository) Delete(ctx context.Context, id int32) error {
-	_, err := r.sqlc.DeleteRideRequest(ctx, id)
+	deleted, err := queries(ctx, r.sqlc).DeleteRideRequest(ctx, id)
 	if err != nil {
 		return err
 	}
+	if deleted == 0 {
+		return models.ErrRideRequestNotFound
+	}
 
 	return nil
 }
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+)
+
+type SchoolRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewSchoolRepository(db dbsqlc.DBTX) out.SchoolRepository {
+	return &SchoolRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *SchoolRepository) Create(ctx context.Context, school *models.School) (*models.School, error) {
+	starts, ends := classTimeParams(school.ClassTimes)
+	row, err := queries(ctx, r.sqlc).CreateSchool(ctx, dbsqlc.CreateSchoolParams{
+		Name:              school.Name,
+		Address:           school.Address,
+		Campus:            postgis.NewPolygon(school.Campus),
+		Gates:             postgis.NewMultiPoint(school.Gates),
+		TimeZone:          school.TimeZone,
+		ClassStartMinutes: starts,
+		ClassEndMinutes:   ends,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSchool(row), nil
+}
+
+func (r *SchoolRepository) FindById(ctx context.Context, id int32) (*models.School, error) {
+	row, err := queries(ctx, r.sqlc).FindSchoolByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSchoolNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toSchool(row), nil
+}
+
+func (r *SchoolRepository) FindAll(ctx context.Context, name string) ([]*models.School, error) {
+	rows, err := queries(ctx, r.sqlc).FindSchools(ctx, textParam(name))
+	if err != nil {
+		return nil, err
+	}
+
+	schools := make([]*models.School, len(rows))
+	for i := range rows {
+		schools[i] = toSchool(rows[i])
+	}
+	return schools, nil
+}
+
+func (r *SchoolRepository) Update(ctx context.Context, id int32, school *models.School) (*models.School, error) {
+	starts, ends := classTimeParams(school.ClassTimes)
+	row, err := queries(ctx, r.sqlc).UpdateSchool(ctx, dbsqlc.UpdateSchoolParams{
+		ID:                id,
+		Name:              school.Name,
+		Address:           school.Address,
+		Campus:            postgis.NewPolygon(school.Campus),
+		Gates:             postgis.NewMultiPoint(school.Gates),
+		TimeZone:          school.TimeZone,
+		ClassStartMinutes: starts,
+		ClassEndMinutes:   ends,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSchoolNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toSchool(row), nil
+}
+
+func toSchool(row dbsqlc.School) *models.School {
+	classTimes := make([]models.ClassTime, min(len(row.ClassStartMinutes), len(row.ClassEndMinutes)))
+	for i := range classTimes {
+		classTimes[i] = models.ClassTime{StartMinutes: int(row.ClassStartMinutes[i]), EndMinutes: int(row.ClassEndMinutes[i])}
+	}
+	return &models.School{
+		ID:         row.ID,
+		Name:       row.Name,
+		Address:    row.Address,
+		Campus:     row.Campus.Locations,
+		Gates:      row.Gates.Locations,
+		TimeZone:   row.TimeZone,
+		ClassTimes: classTimes,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
+
+// classTimeParams splits the class times into the two array columns, never
+// nil since they are NOT NULL.
+func classTimeParams(classTimes []models.ClassTime) ([]int32, []int32) {
+	starts := make([]int32, len(classTimes))
+	ends := make([]int32, len(classTimes))
+	for i, class := range classTimes {
+		starts[i] = int32(class.StartMinutes)
+		ends[i] = int32(class.EndMinutes)
+	}
+	return starts, ends
+}
@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type RideSeriesRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewRideSeriesRepository(db dbsqlc.DBTX) out.RideSeriesRepository {
+	return &RideSeriesRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *RideSeriesRepository) Create(ctx context.Context, series *models.RideSeries) (*models.RideSeries, error) {
+	recurrence := toRecurrenceParams(series.Recurrence)
+	row, err := queries(ctx, r.sqlc).CreateRideSeries(ctx, dbsqlc.CreateRideSeriesParams{
+		DriverID:          series.DriverID,
+		VehicleID:         series.Ride.VehicleID,
+		StartPoint:        postgis.NewPoint(series.Ride.StartPoint),
+		EndPoint:          postgis.NewPoint(series.Ride.EndPoint),
+		StopPoints:        postgis.NewMultiPoint(series.Ride.StopPoints),
+		Description:       series.Ride.Description,
+		ImgUrl:            series.Ride.ImgUrl,
+		Currency:          series.Ride.Cost.Currency,
+		CostCeiling:       ceilingToNumeric(series.Ride.CostCeiling),
+		MaxDetourMeters:   series.Ride.MaxDetourMeters,
+		MaxDetourMs:       series.Ride.MaxDetourMs,
+		Weekdays:          recurrence.weekdays,
+		DepartureMinutes:  int32(series.Recurrence.DepartureMinutes),
+		TimeZone:          series.Recurrence.TimeZone,
+		StartDate:         recurrence.startDate,
+		EndDate:           recurrence.endDate,
+		Exceptions:        recurrence.exceptions,
+		MaterializedUntil: timestampParam(series.MaterializedUntil),
+		SchoolID:          idParam(series.Ride.SchoolID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRideSeries(row), nil
+}
+
+func (r *RideSeriesRepository) FindById(ctx context.Context, id int32) (*models.RideSeries, error) {
+	row, err := queries(ctx, r.sqlc).FindRideSeriesByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRideSeries(row), nil
+}
+
+func (r *RideSeriesRepository) FindDue(ctx context.Context, until time.Time) ([]*models.RideSeries, error) {
+	rows, err := queries(ctx, r.sqlc).FindDueRideSeries(ctx, timestampParam(until))
+	if err != nil {
+		return nil, err
+	}
+	series := make([]*models.RideSeries, len(rows))
+	for i, row := range rows {
+		series[i] = toRideSeries(row)
+	}
+	return series, nil
+}
+
+func (r *RideSeriesRepository) SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideSeriesMaterializedUntil(ctx, dbsqlc.UpdateRideSeriesMaterializedUntilParams{
+		ID:                id,
+		MaterializedUntil: timestampParam(until),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideSeriesRepository) ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error) {
+	rows, err := queries(ctx, r.sqlc).ClaimRideOccurrence(ctx, dbsqlc.ClaimRideOccurrenceParams{
+		SeriesID:    seriesId,
+		DepartsAt:   timestampParam(departsAt),
+		ClaimedAt:   timestampParam(claimedAt),
+		StaleBefore: timestampParam(claimedAt.Add(-models.OccurrenceClaimTimeout)),
+	})
+	return rows > 0, err
+}
+
+func (r *RideSeriesRepository) SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideOccurrenceRide(ctx, dbsqlc.UpdateRideOccurrenceRideParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(departsAt),
+		RideID:    pgtype.Int4{Int32: rideId, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideSeriesRepository) ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error {
+	return queries(ctx, r.sqlc).DeleteRideOccurrenceClaim(ctx, dbsqlc.DeleteRideOccurrenceClaimParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(departsAt),
+	})
+}
+
+func (r *RideSeriesRepository) FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error) {
+	rows, err := queries(ctx, r.sqlc).FindRideOccurrences(ctx, dbsqlc.FindRideOccurrencesParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(from),
+	})
+	if err != nil {
+		return nil, err
+	}
+	occurrences := make([]*models.Occurrence, len(rows))
+	for i, row := range rows {
+		occurrences[i] = &models.Occurrence{
+			SeriesID:  row.SeriesID,
+			DepartsAt: row.DepartsAt.Time.UTC(),
+			RideID:    row.RideID.Int32,
+		}
+	}
+	return occurrences, nil
+}
+
+func toRideSeries(row dbsqlc.RideSeries) *models.RideSeries {
+	return &models.RideSeries{
+		ID:       row.ID,
+		DriverID: row.DriverID,
+		Ride: &models.Ride{
+			DriverID:        row.DriverID,
+			VehicleID:       row.VehicleID,
+			StartPoint:      row.StartPoint.Location,
+			EndPoint:        row.EndPoint.Location,
+			StopPoints:      row.StopPoints.Locations,
+			Description:     row.Description,
+			ImgUrl:          row.ImgUrl,
+			Cost:            models.NewMoney(0, row.Currency),
+			CostCeiling:     numericToCeiling(row.CostCeiling, row.Currency),
+			MaxDetourMeters: row.MaxDetourMeters,
+			MaxDetourMs:     row.MaxDetourMs,
+			SchoolID:        row.SchoolID.Int32,
+		},
+		Recurrence:        toRecurrence(row.Weekdays, row.DepartureMinutes, row.TimeZone, row.StartDate, row.EndDate, row.Exceptions),
+		MaterializedUntil: row.MaterializedUntil.Time.UTC(),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+}
+
+type RideRequestSeriesRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewRideRequestSeriesRepository(db dbsqlc.DBTX) out.RideRequestSeriesRepository {
+	return &RideRequestSeriesRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *RideRequestSeriesRepository) Create(ctx context.Context, series *models.RideRequestSeries) (*models.RideRequestSeries, error) {
+	recurrence := toRecurrenceParams(series.Recurrence)
+	row, err := queries(ctx, r.sqlc).CreateRideRequestSeries(ctx, dbsqlc.CreateRideRequestSeriesParams{
+		PassengerID:       series.PassengerID,
+		Origin:            postgis.NewPoint(series.RideRequest.Origin),
+		Destination:       postgis.NewPoint(series.RideRequest.Destination),
+		Description:       series.RideRequest.Description,
+		ImgUrl:            series.RideRequest.ImgUrl,
+		Weekdays:          recurrence.weekdays,
+		DepartureMinutes:  int32(series.Recurrence.DepartureMinutes),
+		TimeZone:          series.Recurrence.TimeZone,
+		StartDate:         recurrence.startDate,
+		EndDate:           recurrence.endDate,
+		Exceptions:        recurrence.exceptions,
+		MaterializedUntil: timestampParam(series.MaterializedUntil),
+		SchoolID:          idParam(series.RideRequest.SchoolID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRideRequestSeries(row), nil
+}
+
+func (r *RideRequestSeriesRepository) FindById(ctx context.Context, id int32) (*models.RideRequestSeries, error) {
+	row, err := queries(ctx, r.sqlc).FindRideRequestSeriesByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRideRequestSeries(row), nil
+}
+
+func (r *RideRequestSeriesRepository) FindDue(ctx context.Context, until time.Time) ([]*models.RideRequestSeries, error) {
+	rows, err := queries(ctx, r.sqlc).FindDueRideRequestSeries(ctx, timestampParam(until))
+	if err != nil {
+		return nil, err
+	}
+	series := make([]*models.RideRequestSeries, len(rows))
+	for i, row := range rows {
+		series[i] = toRideRequestSeries(row)
+	}
+	return series, nil
+}
+
+func (r *RideRequestSeriesRepository) SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideRequestSeriesMaterializedUntil(ctx, dbsqlc.UpdateRideRequestSeriesMaterializedUntilParams{
+		ID:                id,
+		MaterializedUntil: timestampParam(until),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) SetRideSeries(ctx context.Context, id int32, rideSeriesId int32) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideRequestSeriesRideSeries(ctx, dbsqlc.UpdateRideRequestSeriesRideSeriesParams{
+		ID:           id,
+		RideSeriesID: pgtype.Int4{Int32: rideSeriesId, Valid: rideSeriesId != 0},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error) {
+	rows, err := queries(ctx, r.sqlc).ClaimRideRequestOccurrence(ctx, dbsqlc.ClaimRideRequestOccurrenceParams{
+		SeriesID:    seriesId,
+		DepartsAt:   timestampParam(departsAt),
+		ClaimedAt:   timestampParam(claimedAt),
+		StaleBefore: timestampParam(claimedAt.Add(-models.OccurrenceClaimTimeout)),
+	})
+	return rows > 0, err
+}
+
+func (r *RideRequestSeriesRepository) SetOccurrenceRideRequest(ctx context.Context, seriesId int32, departsAt time.Time, rideRequestId int32) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideRequestOccurrenceRideRequest(ctx, dbsqlc.UpdateRideRequestOccurrenceRideRequestParams{
+		SeriesID:      seriesId,
+		DepartsAt:     timestampParam(departsAt),
+		RideRequestID: pgtype.Int4{Int32: rideRequestId, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error {
+	return queries(ctx, r.sqlc).DeleteRideRequestOccurrenceClaim(ctx, dbsqlc.DeleteRideRequestOccurrenceClaimParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(departsAt),
+	})
+}
+
+func (r *RideRequestSeriesRepository) SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error {
+	rows, err := queries(ctx, r.sqlc).UpdateRideRequestOccurrenceRide(ctx, dbsqlc.UpdateRideRequestOccurrenceRideParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(departsAt),
+		RideID:    pgtype.Int4{Int32: rideId, Valid: rideId != 0},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *RideRequestSeriesRepository) FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error) {
+	rows, err := queries(ctx, r.sqlc).FindRideRequestOccurrences(ctx, dbsqlc.FindRideRequestOccurrencesParams{
+		SeriesID:  seriesId,
+		DepartsAt: timestampParam(from),
+	})
+	if err != nil {
+		return nil, err
+	}
+	occurrences := make([]*models.Occurrence, len(rows))
+	for i, row := range rows {
+		occurrences[i] = &models.Occurrence{
+			SeriesID:      row.SeriesID,
+			DepartsAt:     row.DepartsAt.Time.UTC(),
+			RideID:        row.RideID.Int32,
+			RideRequestID: row.RideRequestID.Int32,
+		}
+	}
+	return occurrences, nil
+}
+
+func toRideRequestSeries(row dbsqlc.RideRequestSeries) *models.RideRequestSeries {
+	return &models.RideRequestSeries{
+		ID:          row.ID,
+		PassengerID: row.PassengerID,
+		RideRequest: &models.RideRequest{
+			PassengerID: row.PassengerID,
+			Origin:      row.Origin.Location,
+			Destination: row.Destination.Location,
+			Description: row.Description,
+			ImgUrl:      row.ImgUrl,
+			SchoolID:    row.SchoolID.Int32,
+		},
+		Recurrence:        toRecurrence(row.Weekdays, row.DepartureMinutes, row.TimeZone, row.StartDate, row.EndDate, row.Exceptions),
+		RideSeriesID:      row.RideSeriesID.Int32,
+		MaterializedUntil: row.MaterializedUntil.Time.UTC(),
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+}
+
+type recurrenceParams struct {
+	weekdays   []int32
+	startDate  pgtype.Date
+	endDate    pgtype.Date
+	exceptions []pgtype.Date
+}
+
+// toRecurrenceParams never returns nil slices, the array columns are NOT
+// NULL.
+func toRecurrenceParams(recurrence models.Recurrence) recurrenceParams {
+	params := recurrenceParams{
+		weekdays:   make([]int32, len(recurrence.Weekdays)),
+		startDate:  dateParam(recurrence.StartDate),
+		endDate:    dateParam(recurrence.EndDate),
+		exceptions: make([]pgtype.Date, len(recurrence.Exceptions)),
+	}
+	for i, weekday := range recurrence.Weekdays {
+		params.weekdays[i] = int32(weekday)
+	}
+	for i, exception := range recurrence.Exceptions {
+		params.exceptions[i] = dateParam(exception)
+	}
+	return params
+}
+
+func toRecurrence(weekdays []int32, departureMinutes int32, timeZone string, startDate pgtype.Date, endDate pgtype.Date, exceptions []pgtype.Date) models.Recurrence {
+	recurrence := models.Recurrence{
+		Weekdays:         make([]time.Weekday, len(weekdays)),
+		DepartureMinutes: int(departureMinutes),
+		TimeZone:         timeZone,
+		StartDate:        models.Date(startDate.Time),
+		Exceptions:       make([]time.Time, len(exceptions)),
+	}
+	if endDate.Valid {
+		recurrence.EndDate = models.Date(endDate.Time)
+	}
+	for i, weekday := range weekdays {
+		recurrence.Weekdays[i] = time.Weekday(weekday)
+	}
+	for i, exception := range exceptions {
+		recurrence.Exceptions[i] = models.Date(exception.Time)
+	}
+	return recurrence
+}
+
+func dateParam(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: models.Date(t), Valid: !t.IsZero()}
+}
@@ -0,0 +1,102 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: change_history_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createChangeRecord = `-- name: CreateChangeRecord :one
+INSERT INTO
+    tb_change_history (
+        entity_type,
+        entity_id,
+        action,
+        changed_by,
+        changes
+    )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING
+    id,
+    created_at
+`
+
+type CreateChangeRecordParams struct {
+	EntityType string
+	EntityID   int32
+	Action     string
+	ChangedBy  string
+	Changes    []byte
+}
+
+type CreateChangeRecordRow struct {
+	ID        int64
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) CreateChangeRecord(ctx context.Context, arg CreateChangeRecordParams) (CreateChangeRecordRow, error) {
+	row := q.db.QueryRow(ctx, createChangeRecord,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Action,
+		arg.ChangedBy,
+		arg.Changes,
+	)
+	var i CreateChangeRecordRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const findChangeRecordsByEntity = `-- name: FindChangeRecordsByEntity :many
+SELECT
+    id,
+    entity_type,
+    entity_id,
+    action,
+    changed_by,
+    changes,
+    created_at
+FROM tb_change_history
+WHERE
+    entity_type = $1
+    AND entity_id = $2
+ORDER BY created_at, id
+`
+
+type FindChangeRecordsByEntityParams struct {
+	EntityType string
+	EntityID   int32
+}
+
+func (q *Queries) FindChangeRecordsByEntity(ctx context.Context, arg FindChangeRecordsByEntityParams) ([]ChangeHistory, error) {
+	rows, err := q.db.Query(ctx, findChangeRecordsByEntity, arg.EntityType, arg.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChangeHistory
+	for rows.Next() {
+		var i ChangeHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.Action,
+			&i.ChangedBy,
+			&i.Changes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -5,55 +5,252 @@
 package dbsqlc
 
 import (
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ChangeHistory struct {
+	ID         int64
+	EntityType string
+	EntityID   int32
+	Action     string
+	ChangedBy  string
+	Changes    []byte
+	CreatedAt  pgtype.Timestamp
+}
+
+type Payment struct {
+	ID          int32
+	RideID      int32
+	PassengerID string
+	DriverID    string
+	// charge collects the passenger fare, payout passes it on to the driver, refund returns part of a charge
+	Kind string
+	// Amount in currency units, two decimal places
+	Amount   pgtype.Numeric
+	Currency string
+	Status   string
+	// Charge settled by a payout or refund
+	ParentID pgtype.Int4
+	Provider string
+	// Identifier of the payment at the provider
+	ProviderRef   pgtype.Text
+	FailureReason pgtype.Text
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+}
+
+type PaymentEvent struct {
+	Provider string
+	// Provider event identifier, unique per provider
+	EventID    string
+	PaymentID  int32
+	Status     string
+	ReceivedAt pgtype.Timestamp
+}
+
 type Ride struct {
-	ID              int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	ID         int32
+	StartPoint postgis.Point
+	EndPoint   postgis.Point
+	// Distance in meters
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
-	Cost            pgtype.Numeric
-	CreatedAt       pgtype.Timestamp
-	UpdatedAt       pgtype.Timestamp
-	StopPoints      interface{}
-	DriverID        string
-	VehicleID       int32
-	Description     pgtype.Text
-	ImgUrl          pgtype.Text
+	// CO2 emitted by the vehicle over the ride in kilograms, computed by the server
+	Co2Emission pgtype.Float8
+	// Sum of the passenger fares in currency units, two decimal places
+	Cost        pgtype.Numeric
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	StopPoints  postgis.MultiPoint
+	DriverID    string
+	VehicleID   int32
+	Description pgtype.Text
+	ImgUrl      pgtype.Text
+	// ISO 4217 currency code
+	Currency    string
+	DeletedAt   pgtype.Timestamp
+	Version     int32
+	CostCeiling pgtype.Numeric
+	// Sum of the CO2 saved by the passengers in kilograms
+	Co2SavedKg float64
+	// When the driver completed the ride, NULL until then
+	CompletedAt pgtype.Timestamp
+	// Extra distance in meters the driver accepts per passenger, 0 for no limit
+	MaxDetourMeters float64
+	// Extra driving time in milliseconds the driver accepts per passenger, 0 for no limit
+	MaxDetourMs int64
+	// School the ride goes to, NULL for none
+	SchoolID pgtype.Int4
+}
+
+type RideOccurrence struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+	// Ride created for the occurrence, NULL while it is being created
+	RideID pgtype.Int4
+	// When a materialization reserved the occurrence, in UTC; a reservation without ride is taken over once stale
+	ClaimedAt pgtype.Timestamp
 }
 
 type RidePassenger struct {
 	RideID     int32
 	UserID     string
 	CreatedAt  pgtype.Timestamp
-	StartPoint interface{}
-	EndPoint   interface{}
+	StartPoint postgis.Point
+	EndPoint   postgis.Point
 	Role       string
+	// Distance travelled by the passenger in meters
+	DistanceMeters float64
+	// Passenger share of the cost in the ride currency
+	Fare pgtype.Numeric
+	// CO2 saved by the passenger versus driving alone in kilograms
+	Co2SavedKg float64
 }
 
 type RideRequest struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	Status       pgtype.Text
 	Description  pgtype.Text
 	ImgUrl       pgtype.Text
+	DeletedAt    pgtype.Timestamp
+	Version      int32
+	// School the passenger goes to, NULL for none
+	SchoolID pgtype.Int4
+}
+
+type RideRequestOccurrence struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+	// Ride request created for the occurrence, NULL while it is being created
+	RideRequestID pgtype.Int4
+	// Ride the occurrence joined, NULL while unmatched
+	RideID pgtype.Int4
+	// When a materialization reserved the occurrence, in UTC; a reservation without ride request is taken over once stale
+	ClaimedAt pgtype.Timestamp
+}
+
+type RideRequestSeries struct {
+	ID          int32
+	PassengerID string
+	Origin      postgis.Point
+	Destination postgis.Point
+	Description string
+	ImgUrl      string
+	// Ride series the occurrences are matched with
+	RideSeriesID      pgtype.Int4
+	Weekdays          []int32
+	DepartureMinutes  int32
+	TimeZone          string
+	StartDate         pgtype.Date
+	EndDate           pgtype.Date
+	Exceptions        []pgtype.Date
+	MaterializedUntil pgtype.Timestamp
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	SchoolID          pgtype.Int4
+}
+
+type RideSeries struct {
+	ID              int32
+	DriverID        string
+	VehicleID       int32
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	StopPoints      postgis.MultiPoint
+	Description     string
+	ImgUrl          string
+	Currency        string
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	// Days the ride repeats on, 0 for Sunday through 6 for Saturday
+	Weekdays []int32
+	// Local departure time in minutes after midnight, in time_zone
+	DepartureMinutes int32
+	TimeZone         string
+	StartDate        pgtype.Date
+	// Last day of the series, NULL to repeat without end
+	EndDate pgtype.Date
+	// Days skipped, such as holidays
+	Exceptions []pgtype.Date
+	// Occurrences departing before this instant, in UTC, were already created
+	MaterializedUntil pgtype.Timestamp
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	SchoolID          pgtype.Int4
+}
+
+type RideStop struct {
+	RideID   int32
+	Position int32
+	Kind     string
+	// Passenger picked up or dropped off, NULL for the driver stops
+	UserID   pgtype.Text
+	Location postgis.Point
+	// Distance driven from the start to the stop
+	DistanceMeters float64
+	// Estimated time from departure to the stop in milliseconds
+	EtaMs int64
+}
+
+// Schools and institutions rides and ride requests can target
+type School struct {
+	ID      int32
+	Name    string
+	Address string
+	Campus  postgis.Polygon
+	// Entry gates, drop-offs within 100 meters of one are accepted
+	Gates             postgis.MultiPoint
+	TimeZone          string
+	ClassStartMinutes []int32
+	ClassEndMinutes   []int32
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
 }
 
 type TbDriverOffer struct {
 	ID                int32
 	DriverID          string
 	AvailableSeats    int32
-	Origin            interface{}
-	Destination       interface{}
+	Origin            postgis.Point
+	Destination       postgis.Point
 	AvailableDatetime pgtype.Timestamp
 	RideDatetime      pgtype.Timestamp
 	RideID            pgtype.Int4
 	Status            pgtype.Text
 }
+
+type UserPreference struct {
+	UserID string
+	// School whose leaderboard the user takes part in, NULL for none
+	SchoolID pgtype.Int4
+	// Hides an adult from the school leaderboard
+	LeaderboardOptOut bool
+	UpdatedAt         pgtype.Timestamp
+	// When an admin confirmed the user belongs to the school, NULL until then
+	SchoolVerifiedAt pgtype.Timestamp
+	// Set by the admin confirming the membership; minors are left off the leaderboard unless they opt in
+	Minor bool
+	// Shows a minor on the school leaderboard
+	LeaderboardOptIn bool
+}
+
+type Vehicle struct {
+	ID           int32
+	DriverID     int32
+	Make         string
+	Model        string
+	Year         int32
+	LicensePlate string
+	FuelType     pgtype.Text
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	// Liters (kWh for electric vehicles) per 100 km
+	FuelConsumption pgtype.Float8
+}
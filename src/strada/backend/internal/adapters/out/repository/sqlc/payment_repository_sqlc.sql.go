@@ -0,0 +1,343 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: payment_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPayment = `-- name: CreatePayment :one
+INSERT INTO
+    tb_payments (
+        ride_id,
+        passenger_id,
+        driver_id,
+        kind,
+        amount,
+        currency,
+        status,
+        parent_id,
+        provider,
+        created_at,
+        updated_at
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        NOW(),
+        NOW()
+    )
+RETURNING id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at
+`
+
+type CreatePaymentParams struct {
+	RideID      int32
+	PassengerID string
+	DriverID    string
+	Kind        string
+	Amount      pgtype.Numeric
+	Currency    string
+	Status      string
+	ParentID    pgtype.Int4
+	Provider    string
+}
+
+func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error) {
+	row := q.db.QueryRow(ctx, createPayment,
+		arg.RideID,
+		arg.PassengerID,
+		arg.DriverID,
+		arg.Kind,
+		arg.Amount,
+		arg.Currency,
+		arg.Status,
+		arg.ParentID,
+		arg.Provider,
+	)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.RideID,
+		&i.PassengerID,
+		&i.DriverID,
+		&i.Kind,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.FailureReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createPaymentEvent = `-- name: CreatePaymentEvent :execrows
+INSERT INTO
+    tb_payment_events (
+        provider,
+        event_id,
+        payment_id,
+        status
+    )
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (provider, event_id) DO NOTHING
+`
+
+type CreatePaymentEventParams struct {
+	Provider  string
+	EventID   string
+	PaymentID int32
+	Status    string
+}
+
+func (q *Queries) CreatePaymentEvent(ctx context.Context, arg CreatePaymentEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createPaymentEvent,
+		arg.Provider,
+		arg.EventID,
+		arg.PaymentID,
+		arg.Status,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const findPaymentByID = `-- name: FindPaymentByID :one
+SELECT id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at FROM tb_payments WHERE id = $1
+`
+
+func (q *Queries) FindPaymentByID(ctx context.Context, id int32) (Payment, error) {
+	row := q.db.QueryRow(ctx, findPaymentByID, id)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.RideID,
+		&i.PassengerID,
+		&i.DriverID,
+		&i.Kind,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.FailureReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findPaymentByProviderRef = `-- name: FindPaymentByProviderRef :one
+SELECT id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at
+FROM tb_payments
+WHERE
+    provider = $1
+    AND provider_ref = $2
+`
+
+type FindPaymentByProviderRefParams struct {
+	Provider    string
+	ProviderRef pgtype.Text
+}
+
+func (q *Queries) FindPaymentByProviderRef(ctx context.Context, arg FindPaymentByProviderRefParams) (Payment, error) {
+	row := q.db.QueryRow(ctx, findPaymentByProviderRef, arg.Provider, arg.ProviderRef)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.RideID,
+		&i.PassengerID,
+		&i.DriverID,
+		&i.Kind,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.FailureReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findPaymentsByParentID = `-- name: FindPaymentsByParentID :many
+SELECT id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at FROM tb_payments WHERE parent_id = $1 ORDER BY id
+`
+
+func (q *Queries) FindPaymentsByParentID(ctx context.Context, parentID pgtype.Int4) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, findPaymentsByParentID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Payment
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.RideID,
+			&i.PassengerID,
+			&i.DriverID,
+			&i.Kind,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ParentID,
+			&i.Provider,
+			&i.ProviderRef,
+			&i.FailureReason,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findPaymentsByRideID = `-- name: FindPaymentsByRideID :many
+SELECT id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at FROM tb_payments WHERE ride_id = $1 ORDER BY id
+`
+
+func (q *Queries) FindPaymentsByRideID(ctx context.Context, rideID int32) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, findPaymentsByRideID, rideID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Payment
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.RideID,
+			&i.PassengerID,
+			&i.DriverID,
+			&i.Kind,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ParentID,
+			&i.Provider,
+			&i.ProviderRef,
+			&i.FailureReason,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findPaymentsByRideIDs = `-- name: FindPaymentsByRideIDs :many
+SELECT id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at FROM tb_payments WHERE ride_id = ANY ($1::int []) ORDER BY ride_id, id
+`
+
+func (q *Queries) FindPaymentsByRideIDs(ctx context.Context, dollar_1 []int32) ([]Payment, error) {
+	rows, err := q.db.Query(ctx, findPaymentsByRideIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Payment
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.RideID,
+			&i.PassengerID,
+			&i.DriverID,
+			&i.Kind,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ParentID,
+			&i.Provider,
+			&i.ProviderRef,
+			&i.FailureReason,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePayment = `-- name: UpdatePayment :one
+UPDATE tb_payments
+SET
+    status = $2,
+    provider_ref = $3,
+    failure_reason = $4,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, ride_id, passenger_id, driver_id, kind, amount, currency, status, parent_id, provider, provider_ref, failure_reason, created_at, updated_at
+`
+
+type UpdatePaymentParams struct {
+	ID            int32
+	Status        string
+	ProviderRef   pgtype.Text
+	FailureReason pgtype.Text
+}
+
+func (q *Queries) UpdatePayment(ctx context.Context, arg UpdatePaymentParams) (Payment, error) {
+	row := q.db.QueryRow(ctx, updatePayment,
+		arg.ID,
+		arg.Status,
+		arg.ProviderRef,
+		arg.FailureReason,
+	)
+	var i Payment
+	err := row.Scan(
+		&i.ID,
+		&i.RideID,
+		&i.PassengerID,
+		&i.DriverID,
+		&i.Kind,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ParentID,
+		&i.Provider,
+		&i.ProviderRef,
+		&i.FailureReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
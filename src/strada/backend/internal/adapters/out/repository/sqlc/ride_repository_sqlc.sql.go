@@ -8,9 +8,59 @@ package dbsqlc
 import (
 	"context"
 
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addRidePassenger = `-- name: AddRidePassenger :one
+INSERT INTO
+    tb_ride_passengers (
+        ride_id,
+        user_id,
+        start_point,
+        end_point,
+        role,
+        distance_meters
+    )
+VALUES (
+        $1,
+        $2,
+        ST_SetSRID (ST_MakePoint ($3, $4), 4326),
+        ST_SetSRID (ST_MakePoint ($5, $6), 4326),
+        $7,
+        $8
+    )
+RETURNING
+    created_at
+`
+
+type AddRidePassengerParams struct {
+	RideID         int32
+	UserID         string
+	StMakepoint    interface{}
+	StMakepoint_2  interface{}
+	StMakepoint_3  interface{}
+	StMakepoint_4  interface{}
+	Role           string
+	DistanceMeters float64
+}
+
+func (q *Queries) AddRidePassenger(ctx context.Context, arg AddRidePassengerParams) (pgtype.Timestamp, error) {
+	row := q.db.QueryRow(ctx, addRidePassenger,
+		arg.RideID,
+		arg.UserID,
+		arg.StMakepoint,
+		arg.StMakepoint_2,
+		arg.StMakepoint_3,
+		arg.StMakepoint_4,
+		arg.Role,
+		arg.DistanceMeters,
+	)
+	var created_at pgtype.Timestamp
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
 const createRide = `-- name: CreateRide :one
 INSERT INTO
     tb_rides (
@@ -25,6 +75,13 @@ INSERT INTO
         cost,
         description,
         img_url,
+        currency,
+        cost_ceiling,
+        max_detour_meters,
+        max_detour_ms,
+        co2_saved_kg,
+        completed_at,
+        school_id,
         created_at,
         updated_at
     )
@@ -40,6 +97,13 @@ VALUES (
         $11,
         $12,
         $13,
+        $14,
+        $15,
+        $16,
+        $17,
+        $18,
+        $19,
+        $20,
         NOW(),
         NOW()
     )
@@ -47,13 +111,21 @@ RETURNING
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
@@ -67,26 +139,41 @@ type CreateRideParams struct {
 	StMakepoint_2   interface{}
 	StMakepoint_3   interface{}
 	StMakepoint_4   interface{}
-	Distance        pgtype.Numeric
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
-	StopPoints      interface{}
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
 	Cost            pgtype.Numeric
 	Description     pgtype.Text
 	ImgUrl          pgtype.Text
+	Currency        string
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	SchoolID        pgtype.Int4
 }
 
 type CreateRideRow struct {
 	ID              int32
 	DriverID        string
 	VehicleID       int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
-	StopPoints      interface{}
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
 	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
 	ImgUrl          pgtype.Text
 	Description     pgtype.Text
 	CreatedAt       pgtype.Timestamp
@@ -108,6 +195,13 @@ func (q *Queries) CreateRide(ctx context.Context, arg CreateRideParams) (CreateR
 		arg.Cost,
 		arg.Description,
 		arg.ImgUrl,
+		arg.Currency,
+		arg.CostCeiling,
+		arg.MaxDetourMeters,
+		arg.MaxDetourMs,
+		arg.Co2SavedKg,
+		arg.CompletedAt,
+		arg.SchoolID,
 	)
 	var i CreateRideRow
 	err := row.Scan(
@@ -121,6 +215,14 @@ func (q *Queries) CreateRide(ctx context.Context, arg CreateRideParams) (CreateR
 		&i.Co2Emission,
 		&i.StopPoints,
 		&i.Cost,
+		&i.Currency,
+		&i.Version,
+		&i.CostCeiling,
+		&i.MaxDetourMeters,
+		&i.MaxDetourMs,
+		&i.SchoolID,
+		&i.Co2SavedKg,
+		&i.CompletedAt,
 		&i.ImgUrl,
 		&i.Description,
 		&i.CreatedAt,
@@ -129,12 +231,76 @@ func (q *Queries) CreateRide(ctx context.Context, arg CreateRideParams) (CreateR
 	return i, err
 }
 
-const deleteRide = `-- name: DeleteRide :exec
-DELETE FROM tb_rides WHERE id = $1
+const createRideStop = `-- name: CreateRideStop :exec
+INSERT INTO
+    tb_ride_stops (
+        ride_id,
+        position,
+        kind,
+        user_id,
+        location,
+        distance_meters,
+        eta_ms
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        ST_SetSRID (ST_MakePoint ($5, $6), 4326),
+        $7,
+        $8
+    )
 `
 
-func (q *Queries) DeleteRide(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteRide, id)
+type CreateRideStopParams struct {
+	RideID         int32
+	Position       int32
+	Kind           string
+	UserID         pgtype.Text
+	StMakepoint    interface{}
+	StMakepoint_2  interface{}
+	DistanceMeters float64
+	EtaMs          int64
+}
+
+func (q *Queries) CreateRideStop(ctx context.Context, arg CreateRideStopParams) error {
+	_, err := q.db.Exec(ctx, createRideStop,
+		arg.RideID,
+		arg.Position,
+		arg.Kind,
+		arg.UserID,
+		arg.StMakepoint,
+		arg.StMakepoint_2,
+		arg.DistanceMeters,
+		arg.EtaMs,
+	)
+	return err
+}
+
+const deleteRide = `-- name: DeleteRide :execrows
+UPDATE tb_rides
+SET
+    deleted_at = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteRide(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRide, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRideStops = `-- name: DeleteRideStops :exec
+DELETE FROM tb_ride_stops WHERE ride_id = $1
+`
+
+func (q *Queries) DeleteRideStops(ctx context.Context, rideID int32) error {
+	_, err := q.db.Exec(ctx, deleteRideStops, rideID)
 	return err
 }
 
@@ -143,31 +309,49 @@ SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
     updated_at
 FROM tb_rides
+WHERE
+    deleted_at IS NULL
 `
 
 type FindAllRidesRow struct {
 	ID              int32
 	DriverID        string
 	VehicleID       int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
-	StopPoints      interface{}
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
 	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
 	ImgUrl          pgtype.Text
 	Description     pgtype.Text
 	CreatedAt       pgtype.Timestamp
@@ -194,6 +378,14 @@ func (q *Queries) FindAllRides(ctx context.Context) ([]FindAllRidesRow, error) {
 			&i.Co2Emission,
 			&i.StopPoints,
 			&i.Cost,
+			&i.Currency,
+			&i.Version,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.SchoolID,
+			&i.Co2SavedKg,
+			&i.CompletedAt,
 			&i.ImgUrl,
 			&i.Description,
 			&i.CreatedAt,
@@ -209,82 +401,224 @@ func (q *Queries) FindAllRides(ctx context.Context) ([]FindAllRidesRow, error) {
 	return items, nil
 }
 
-const findNearRides = `-- name: FindNearRides :many
-WITH
-    trajeto AS (
-        SELECT unnest($1::point []) AS ponto
+const findCompletedRidesByUserIDs = `-- name: FindCompletedRidesByUserIDs :many
+SELECT
+    id,
+    driver_id,
+    vehicle_id,
+    start_point,
+    end_point,
+    distance,
+    estimated_time_ms,
+    co2_emission,
+    stop_points,
+    cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    img_url,
+    description,
+    created_at,
+    updated_at
+FROM tb_rides
+WHERE
+    deleted_at IS NULL
+    AND completed_at IS NOT NULL
+    AND (
+        $1::timestamp IS NULL
+        OR completed_at >= $1::timestamp
+    )
+    AND (
+        $2::timestamp IS NULL
+        OR completed_at < $2::timestamp
+    )
+    AND (
+        driver_id = ANY ($3::varchar [])
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = ANY ($3::varchar [])
+        )
     )
+ORDER BY completed_at, id
+`
+
+type FindCompletedRidesByUserIDsParams struct {
+	CompletedFrom pgtype.Timestamp
+	CompletedTo   pgtype.Timestamp
+	UserIds       []string
+}
+
+type FindCompletedRidesByUserIDsRow struct {
+	ID              int32
+	DriverID        string
+	VehicleID       int32
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
+	EstimatedTimeMs int32
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
+	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	ImgUrl          pgtype.Text
+	Description     pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+// Rides completed in the period where any of the users was the driver or a
+// passenger.
+func (q *Queries) FindCompletedRidesByUserIDs(ctx context.Context, arg FindCompletedRidesByUserIDsParams) ([]FindCompletedRidesByUserIDsRow, error) {
+	rows, err := q.db.Query(ctx, findCompletedRidesByUserIDs, arg.CompletedFrom, arg.CompletedTo, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindCompletedRidesByUserIDsRow
+	for rows.Next() {
+		var i FindCompletedRidesByUserIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DriverID,
+			&i.VehicleID,
+			&i.StartPoint,
+			&i.EndPoint,
+			&i.Distance,
+			&i.EstimatedTimeMs,
+			&i.Co2Emission,
+			&i.StopPoints,
+			&i.Cost,
+			&i.Currency,
+			&i.Version,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.SchoolID,
+			&i.Co2SavedKg,
+			&i.CompletedAt,
+			&i.ImgUrl,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findNearRides = `-- name: FindNearRides :many
 SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
     cost,
-    ST_AsText (stop_points) AS stop_points,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    stop_points,
     description,
     img_url,
     created_at,
     updated_at
 FROM tb_rides
 WHERE
-    EXISTS (
-        SELECT 1
-        FROM trajeto
-        WHERE (
-                ST_DWithin (
-                    ST_SetSRID (
-                        ST_MakePoint (
-                            trajeto.ponto[0],
-                            trajeto.ponto[1]
-                        ),
-                        4326
-                    )::geography,
-                    start_point::geography,
-                    $2
-                )
-                OR ST_DWithin (
-                    ST_SetSRID (
-                        ST_MakePoint (
-                            trajeto.ponto[0],
-                            trajeto.ponto[1]
-                        ),
-                        4326
-                    )::geography,
-                    end_point::geography,
-                    $2
-                )
-            )
+    deleted_at IS NULL
+    -- Completed rides cannot be joined any more.
+    AND completed_at IS NULL
+    AND (
+        ST_DWithin (
+            start_point,
+            $1::geography,
+            $2::float8
+        )
+        OR ST_DWithin (
+            end_point,
+            $1::geography,
+            $2::float8
+        )
+        OR ST_DWithin (
+            stop_points,
+            $1::geography,
+            $2::float8
+        )
+        -- Everyone going to the same school, wherever they start.
+        OR school_id = $3::int
     )
+ORDER BY start_point <-> $1::geography, id
+LIMIT $4::int
 `
 
 type FindNearRidesParams struct {
-	Column1   []pgtype.Point
-	StDwithin interface{}
+	Route        interface{}
+	RadiusMeters float64
+	SchoolID     int32
+	MaxResults   int32
 }
 
 type FindNearRidesRow struct {
 	ID              int32
 	DriverID        string
 	VehicleID       int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
+	Co2Emission     pgtype.Float8
 	Cost            pgtype.Numeric
-	StopPoints      interface{}
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	StopPoints      postgis.MultiPoint
 	Description     pgtype.Text
 	ImgUrl          pgtype.Text
 	CreatedAt       pgtype.Timestamp
 	UpdatedAt       pgtype.Timestamp
 }
 
+// The route arrives as a single MultiPoint geography, so every ST_DWithin
+// below is answered by the GiST indexes on start_point, end_point and
+// stop_points, and the results are ordered nearest first with KNN.
 func (q *Queries) FindNearRides(ctx context.Context, arg FindNearRidesParams) ([]FindNearRidesRow, error) {
-	rows, err := q.db.Query(ctx, findNearRides, arg.Column1, arg.StDwithin)
+	rows, err := q.db.Query(ctx, findNearRides,
+		arg.Route,
+		arg.RadiusMeters,
+		arg.SchoolID,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -302,6 +636,14 @@ func (q *Queries) FindNearRides(ctx context.Context, arg FindNearRidesParams) ([
 			&i.EstimatedTimeMs,
 			&i.Co2Emission,
 			&i.Cost,
+			&i.Currency,
+			&i.Version,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.SchoolID,
+			&i.Co2SavedKg,
+			&i.CompletedAt,
 			&i.StopPoints,
 			&i.Description,
 			&i.ImgUrl,
@@ -323,13 +665,21 @@ SELECT
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
     description,
     created_at,
@@ -337,19 +687,28 @@ SELECT
 FROM tb_rides
 WHERE
     id = $1
+    AND deleted_at IS NULL
 `
 
 type FindRideByIDRow struct {
 	ID              int32
 	DriverID        string
 	VehicleID       int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
-	StopPoints      interface{}
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
 	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
 	ImgUrl          pgtype.Text
 	Description     pgtype.Text
 	CreatedAt       pgtype.Timestamp
@@ -370,6 +729,14 @@ func (q *Queries) FindRideByID(ctx context.Context, id int32) (FindRideByIDRow,
 		&i.Co2Emission,
 		&i.StopPoints,
 		&i.Cost,
+		&i.Currency,
+		&i.Version,
+		&i.CostCeiling,
+		&i.MaxDetourMeters,
+		&i.MaxDetourMs,
+		&i.SchoolID,
+		&i.Co2SavedKg,
+		&i.CompletedAt,
 		&i.ImgUrl,
 		&i.Description,
 		&i.CreatedAt,
@@ -378,6 +745,458 @@ func (q *Queries) FindRideByID(ctx context.Context, id int32) (FindRideByIDRow,
 	return i, err
 }
 
+const findRidePassengersByRideIDs = `-- name: FindRidePassengersByRideIDs :many
+SELECT
+    rp.ride_id,
+    rp.user_id,
+    rp.start_point,
+    rp.end_point,
+    rp.role,
+    rp.created_at,
+    rp.distance_meters,
+    rp.fare,
+    rp.co2_saved_kg,
+    r.currency
+FROM tb_ride_passengers rp
+    JOIN tb_rides r ON r.id = rp.ride_id
+WHERE
+    rp.ride_id = ANY ($1::int [])
+ORDER BY rp.ride_id, rp.created_at, rp.user_id
+`
+
+type FindRidePassengersByRideIDsRow struct {
+	RideID         int32
+	UserID         string
+	StartPoint     postgis.Point
+	EndPoint       postgis.Point
+	Role           string
+	CreatedAt      pgtype.Timestamp
+	DistanceMeters float64
+	Fare           pgtype.Numeric
+	Co2SavedKg     float64
+	Currency       string
+}
+
+func (q *Queries) FindRidePassengersByRideIDs(ctx context.Context, dollar_1 []int32) ([]FindRidePassengersByRideIDsRow, error) {
+	rows, err := q.db.Query(ctx, findRidePassengersByRideIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindRidePassengersByRideIDsRow
+	for rows.Next() {
+		var i FindRidePassengersByRideIDsRow
+		if err := rows.Scan(
+			&i.RideID,
+			&i.UserID,
+			&i.StartPoint,
+			&i.EndPoint,
+			&i.Role,
+			&i.CreatedAt,
+			&i.DistanceMeters,
+			&i.Fare,
+			&i.Co2SavedKg,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRideStops = `-- name: FindRideStops :many
+SELECT
+    ride_id,
+    position,
+    kind,
+    user_id,
+    location,
+    distance_meters,
+    eta_ms
+FROM tb_ride_stops
+WHERE
+    ride_id = $1
+ORDER BY position
+`
+
+func (q *Queries) FindRideStops(ctx context.Context, rideID int32) ([]RideStop, error) {
+	rows, err := q.db.Query(ctx, findRideStops, rideID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RideStop
+	for rows.Next() {
+		var i RideStop
+		if err := rows.Scan(
+			&i.RideID,
+			&i.Position,
+			&i.Kind,
+			&i.UserID,
+			&i.Location,
+			&i.DistanceMeters,
+			&i.EtaMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRidesByUserID = `-- name: FindRidesByUserID :many
+SELECT
+    id,
+    driver_id,
+    vehicle_id,
+    start_point,
+    end_point,
+    distance,
+    estimated_time_ms,
+    co2_emission,
+    stop_points,
+    cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    img_url,
+    description,
+    created_at,
+    updated_at
+FROM tb_rides
+WHERE
+    deleted_at IS NULL
+    AND (
+        driver_id = $1
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = $1
+        )
+    )
+ORDER BY created_at DESC
+`
+
+type FindRidesByUserIDRow struct {
+	ID              int32
+	DriverID        string
+	VehicleID       int32
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
+	EstimatedTimeMs int32
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
+	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	ImgUrl          pgtype.Text
+	Description     pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+func (q *Queries) FindRidesByUserID(ctx context.Context, driverID string) ([]FindRidesByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, findRidesByUserID, driverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindRidesByUserIDRow
+	for rows.Next() {
+		var i FindRidesByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DriverID,
+			&i.VehicleID,
+			&i.StartPoint,
+			&i.EndPoint,
+			&i.Distance,
+			&i.EstimatedTimeMs,
+			&i.Co2Emission,
+			&i.StopPoints,
+			&i.Cost,
+			&i.Currency,
+			&i.Version,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.SchoolID,
+			&i.Co2SavedKg,
+			&i.CompletedAt,
+			&i.ImgUrl,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRides = `-- name: ListRides :many
+SELECT
+    id,
+    driver_id,
+    vehicle_id,
+    start_point,
+    end_point,
+    distance,
+    estimated_time_ms,
+    co2_emission,
+    stop_points,
+    cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
+    img_url,
+    description,
+    created_at,
+    updated_at
+FROM tb_rides
+WHERE
+    deleted_at IS NULL
+    AND (
+        $1::varchar IS NULL
+        OR driver_id = $1::varchar
+    )
+    AND (
+        $2::varchar IS NULL
+        OR id IN (
+            SELECT ride_id
+            FROM tb_ride_passengers
+            WHERE
+                user_id = $2::varchar
+                AND role = 'passenger'
+        )
+    )
+    AND (
+        $3::timestamp IS NULL
+        OR created_at >= $3::timestamp
+    )
+    AND (
+        $4::timestamp IS NULL
+        OR created_at < $4::timestamp
+    )
+    AND (
+        $5::float8 IS NULL
+        OR ST_Intersects (
+            start_point::geometry,
+            ST_MakeEnvelope (
+                $5::float8,
+                $6::float8,
+                $7::float8,
+                $8::float8,
+                4326
+            )
+        )
+        OR ST_Intersects (
+            end_point::geometry,
+            ST_MakeEnvelope (
+                $5::float8,
+                $6::float8,
+                $7::float8,
+                $8::float8,
+                4326
+            )
+        )
+    )
+    -- Keyset pagination: rows strictly after the cursor in the chosen order.
+    AND (
+        $9::int IS NULL
+        OR (
+            $10::text = 'created_at'
+            AND NOT $11::bool
+            AND (created_at, id) > ($12::timestamp, $9::int)
+        )
+        OR (
+            $10::text = 'created_at'
+            AND $11::bool
+            AND (created_at, id) < ($12::timestamp, $9::int)
+        )
+        OR (
+            $10::text = 'cost'
+            AND NOT $11::bool
+            AND (COALESCE(cost, 0), id) > ($13::numeric, $9::int)
+        )
+        OR (
+            $10::text = 'cost'
+            AND $11::bool
+            AND (COALESCE(cost, 0), id) < ($13::numeric, $9::int)
+        )
+        OR (
+            $10::text = 'distance'
+            AND NOT $11::bool
+            AND (distance, id) > ($13::numeric, $9::int)
+        )
+        OR (
+            $10::text = 'distance'
+            AND $11::bool
+            AND (distance, id) < ($13::numeric, $9::int)
+        )
+    )
+ORDER BY
+    CASE WHEN $10::text = 'created_at' AND NOT $11::bool THEN created_at END ASC,
+    CASE WHEN $10::text = 'created_at' AND $11::bool THEN created_at END DESC,
+    CASE WHEN $10::text = 'cost' AND NOT $11::bool THEN COALESCE(cost, 0) END ASC,
+    CASE WHEN $10::text = 'cost' AND $11::bool THEN COALESCE(cost, 0) END DESC,
+    CASE WHEN $10::text = 'distance' AND NOT $11::bool THEN distance END ASC,
+    CASE WHEN $10::text = 'distance' AND $11::bool THEN distance END DESC,
+    CASE WHEN NOT $11::bool THEN id END ASC,
+    CASE WHEN $11::bool THEN id END DESC
+LIMIT $14::int
+`
+
+type ListRidesParams struct {
+	DriverID     pgtype.Text
+	PassengerID  pgtype.Text
+	CreatedFrom  pgtype.Timestamp
+	CreatedTo    pgtype.Timestamp
+	MinLon       pgtype.Float8
+	MinLat       pgtype.Float8
+	MaxLon       pgtype.Float8
+	MaxLat       pgtype.Float8
+	CursorID     pgtype.Int4
+	SortBy       string
+	Descending   bool
+	CursorTime   pgtype.Timestamp
+	CursorNumber pgtype.Numeric
+	PageSize     int32
+}
+
+type ListRidesRow struct {
+	ID              int32
+	DriverID        string
+	VehicleID       int32
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
+	EstimatedTimeMs int32
+	Co2Emission     pgtype.Float8
+	StopPoints      postgis.MultiPoint
+	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	ImgUrl          pgtype.Text
+	Description     pgtype.Text
+	CreatedAt       pgtype.Timestamp
+	UpdatedAt       pgtype.Timestamp
+}
+
+func (q *Queries) ListRides(ctx context.Context, arg ListRidesParams) ([]ListRidesRow, error) {
+	rows, err := q.db.Query(ctx, listRides,
+		arg.DriverID,
+		arg.PassengerID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinLon,
+		arg.MinLat,
+		arg.MaxLon,
+		arg.MaxLat,
+		arg.CursorID,
+		arg.SortBy,
+		arg.Descending,
+		arg.CursorTime,
+		arg.CursorNumber,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRidesRow
+	for rows.Next() {
+		var i ListRidesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DriverID,
+			&i.VehicleID,
+			&i.StartPoint,
+			&i.EndPoint,
+			&i.Distance,
+			&i.EstimatedTimeMs,
+			&i.Co2Emission,
+			&i.StopPoints,
+			&i.Cost,
+			&i.Currency,
+			&i.Version,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.SchoolID,
+			&i.Co2SavedKg,
+			&i.CompletedAt,
+			&i.ImgUrl,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeRidePassenger = `-- name: RemoveRidePassenger :execrows
+DELETE FROM tb_ride_passengers
+WHERE
+    ride_id = $1
+    AND user_id = $2
+    AND role = 'passenger'
+`
+
+type RemoveRidePassengerParams struct {
+	RideID int32
+	UserID string
+}
+
+func (q *Queries) RemoveRidePassenger(ctx context.Context, arg RemoveRidePassengerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeRidePassenger, arg.RideID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateRide = `-- name: UpdateRide :one
 UPDATE tb_rides
 SET
@@ -389,24 +1208,42 @@ SET
     estimated_time_ms = $9,
     co2_emission = $10,
     cost = $11,
+    currency = $15,
+    cost_ceiling = $17,
+    max_detour_meters = $20,
+    max_detour_ms = $21,
+    school_id = $22,
+    co2_saved_kg = $18,
+    completed_at = $19,
     stop_points = $12,
     description = $13,
     img_url = $14,
-    updated_at = NOW()
+    updated_at = NOW(),
+    version = version + 1
 WHERE
     id = $1
+    AND deleted_at IS NULL
+    AND version = $16
 RETURNING
     id,
     driver_id,
     vehicle_id,
-    ST_AsText (start_point) AS start_point,
-    ST_AsText (end_point) AS end_point,
+    start_point,
+    end_point,
     distance,
     estimated_time_ms,
     co2_emission,
     cost,
+    currency,
+    version,
+    cost_ceiling,
+    max_detour_meters,
+    max_detour_ms,
+    school_id,
+    co2_saved_kg,
+    completed_at,
     img_url,
-    ST_AsText (stop_points) AS stop_points,
+    stop_points,
     description,
     created_at,
     updated_at
@@ -420,27 +1257,43 @@ type UpdateRideParams struct {
 	StMakepoint_2   interface{}
 	StMakepoint_3   interface{}
 	StMakepoint_4   interface{}
-	Distance        pgtype.Numeric
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
+	Co2Emission     pgtype.Float8
 	Cost            pgtype.Numeric
-	StopPoints      interface{}
+	StopPoints      postgis.MultiPoint
 	Description     pgtype.Text
 	ImgUrl          pgtype.Text
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
 }
 
 type UpdateRideRow struct {
 	ID              int32
 	DriverID        string
 	VehicleID       int32
-	StartPoint      interface{}
-	EndPoint        interface{}
-	Distance        pgtype.Numeric
+	StartPoint      postgis.Point
+	EndPoint        postgis.Point
+	Distance        float64
 	EstimatedTimeMs int32
-	Co2Emission     pgtype.Numeric
+	Co2Emission     pgtype.Float8
 	Cost            pgtype.Numeric
+	Currency        string
+	Version         int32
+	CostCeiling     pgtype.Numeric
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	SchoolID        pgtype.Int4
+	Co2SavedKg      float64
+	CompletedAt     pgtype.Timestamp
 	ImgUrl          pgtype.Text
-	StopPoints      interface{}
+	StopPoints      postgis.MultiPoint
 	Description     pgtype.Text
 	CreatedAt       pgtype.Timestamp
 	UpdatedAt       pgtype.Timestamp
@@ -462,6 +1315,14 @@ func (q *Queries) UpdateRide(ctx context.Context, arg UpdateRideParams) (UpdateR
 		arg.StopPoints,
 		arg.Description,
 		arg.ImgUrl,
+		arg.Currency,
+		arg.Version,
+		arg.CostCeiling,
+		arg.Co2SavedKg,
+		arg.CompletedAt,
+		arg.MaxDetourMeters,
+		arg.MaxDetourMs,
+		arg.SchoolID,
 	)
 	var i UpdateRideRow
 	err := row.Scan(
@@ -474,6 +1335,14 @@ func (q *Queries) UpdateRide(ctx context.Context, arg UpdateRideParams) (UpdateR
 		&i.EstimatedTimeMs,
 		&i.Co2Emission,
 		&i.Cost,
+		&i.Currency,
+		&i.Version,
+		&i.CostCeiling,
+		&i.MaxDetourMeters,
+		&i.MaxDetourMs,
+		&i.SchoolID,
+		&i.Co2SavedKg,
+		&i.CompletedAt,
 		&i.ImgUrl,
 		&i.StopPoints,
 		&i.Description,
@@ -482,3 +1351,50 @@ func (q *Queries) UpdateRide(ctx context.Context, arg UpdateRideParams) (UpdateR
 	)
 	return i, err
 }
+
+const updateRidePassengerCo2Saved = `-- name: UpdateRidePassengerCo2Saved :exec
+UPDATE tb_ride_passengers
+SET
+    co2_saved_kg = $3
+WHERE
+    ride_id = $1
+    AND user_id = $2
+`
+
+type UpdateRidePassengerCo2SavedParams struct {
+	RideID     int32
+	UserID     string
+	Co2SavedKg float64
+}
+
+func (q *Queries) UpdateRidePassengerCo2Saved(ctx context.Context, arg UpdateRidePassengerCo2SavedParams) error {
+	_, err := q.db.Exec(ctx, updateRidePassengerCo2Saved, arg.RideID, arg.UserID, arg.Co2SavedKg)
+	return err
+}
+
+const updateRidePassengerFare = `-- name: UpdateRidePassengerFare :exec
+UPDATE tb_ride_passengers
+SET
+    distance_meters = $3,
+    fare = $4
+WHERE
+    ride_id = $1
+    AND user_id = $2
+`
+
+type UpdateRidePassengerFareParams struct {
+	RideID         int32
+	UserID         string
+	DistanceMeters float64
+	Fare           pgtype.Numeric
+}
+
+func (q *Queries) UpdateRidePassengerFare(ctx context.Context, arg UpdateRidePassengerFareParams) error {
+	_, err := q.db.Exec(ctx, updateRidePassengerFare,
+		arg.RideID,
+		arg.UserID,
+		arg.DistanceMeters,
+		arg.Fare,
+	)
+	return err
+}
@@ -8,6 +8,7 @@ package dbsqlc
 import (
 	"context"
 
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -19,7 +20,8 @@ INSERT INTO
         destination,
         ride_datetime,
         description,
-        img_url
+        img_url,
+        school_id
     )
 VALUES (
         $1,
@@ -27,18 +29,21 @@ VALUES (
         ST_SetSRID (ST_MakePoint ($4, $5), 4326),
         $6,
         $7,
-        $8
+        $8,
+        $9
     )
 RETURNING
     id,
     passenger_id,
-    ST_AsText (origin) AS origin,
-    ST_AsText (destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     description,
     img_url,
-    status
+    status,
+    version,
+    school_id
 `
 
 type CreateRideRequestParams struct {
@@ -50,18 +55,21 @@ type CreateRideRequestParams struct {
 	RideDatetime  pgtype.Timestamp
 	Description   pgtype.Text
 	ImgUrl        pgtype.Text
+	SchoolID      pgtype.Int4
 }
 
 type CreateRideRequestRow struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	Description  pgtype.Text
 	ImgUrl       pgtype.Text
 	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
 }
 
 func (q *Queries) CreateRideRequest(ctx context.Context, arg CreateRideRequestParams) (CreateRideRequestRow, error) {
@@ -74,6 +82,7 @@ func (q *Queries) CreateRideRequest(ctx context.Context, arg CreateRideRequestPa
 		arg.RideDatetime,
 		arg.Description,
 		arg.ImgUrl,
+		arg.SchoolID,
 	)
 	var i CreateRideRequestRow
 	err := row.Scan(
@@ -86,54 +95,52 @@ func (q *Queries) CreateRideRequest(ctx context.Context, arg CreateRideRequestPa
 		&i.Description,
 		&i.ImgUrl,
 		&i.Status,
+		&i.Version,
+		&i.SchoolID,
 	)
 	return i, err
 }
 
-const deleteRideRequest = `-- name: DeleteRideRequest :one
-DELETE FROM tb_ride_requests WHERE id = $1 RETURNING id, passenger_id, origin, destination, ride_datetime, drive_offer_id, status, description, img_url
+const deleteRideRequest = `-- name: DeleteRideRequest :execrows
+UPDATE tb_ride_requests SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteRideRequest(ctx context.Context, id int32) (RideRequest, error) {
-	row := q.db.QueryRow(ctx, deleteRideRequest, id)
-	var i RideRequest
-	err := row.Scan(
-		&i.ID,
-		&i.PassengerID,
-		&i.Origin,
-		&i.Destination,
-		&i.RideDatetime,
-		&i.DriveOfferID,
-		&i.Status,
-		&i.Description,
-		&i.ImgUrl,
-	)
-	return i, err
+func (q *Queries) DeleteRideRequest(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRideRequest, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const findAllRideRequests = `-- name: FindAllRideRequests :many
 SELECT
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     img_url,
     status,
+    version,
+    school_id,
     description
 FROM tb_ride_requests
+WHERE deleted_at IS NULL
 `
 
 type FindAllRideRequestsRow struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	ImgUrl       pgtype.Text
 	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
 	Description  pgtype.Text
 }
 
@@ -155,6 +162,8 @@ func (q *Queries) FindAllRideRequests(ctx context.Context) ([]FindAllRideRequest
 			&i.DriveOfferID,
 			&i.ImgUrl,
 			&i.Status,
+			&i.Version,
+			&i.SchoolID,
 			&i.Description,
 		); err != nil {
 			return nil, err
@@ -168,59 +177,69 @@ func (q *Queries) FindAllRideRequests(ctx context.Context) ([]FindAllRideRequest
 }
 
 const findNearRideRequests = `-- name: FindNearRideRequests :many
-WITH trajeto AS (
-    SELECT unnest($1::point[]) as ponto
-)
 SELECT
-    rr.id,
-    rr.passenger_id,
-    ST_AsText(rr.origin) AS origin,
-    ST_AsText(rr.destination) AS destination,
-    rr.ride_datetime,
-    rr.drive_offer_id,
-    rr.status,
-    rr.img_url,
+    id,
+    passenger_id,
+    origin,
+    destination,
+    ride_datetime,
+    drive_offer_id,
+    status,
+    version,
+    school_id,
+    img_url,
     description
-FROM tb_ride_requests rr
-WHERE EXISTS (
-    SELECT 1
-    FROM trajeto
-    WHERE
-        (
-            ST_DWithin(
-                ST_SetSRID(ST_MakePoint(trajeto.ponto[0], trajeto.ponto[1]), 4326)::geography,
-                rr.origin::geography,
-                $2
-            )
-        OR
-            ST_DWithin(
-                ST_SetSRID(ST_MakePoint(trajeto.ponto[0], trajeto.ponto[1]), 4326)::geography,
-                rr.destination::geography,
-                $2
-            )
+FROM tb_ride_requests
+WHERE
+    deleted_at IS NULL
+    AND (
+        ST_DWithin (
+            origin,
+            $1::geography,
+            $2::float8
         )
-)
+        OR ST_DWithin (
+            destination,
+            $1::geography,
+            $2::float8
+        )
+        -- See FindNearRides: everyone going to the same school.
+        OR school_id = $3::int
+    )
+ORDER BY origin <-> $1::geography, id
+LIMIT $4::int
 `
 
 type FindNearRideRequestsParams struct {
-	Column1   []pgtype.Point
-	StDwithin interface{}
+	Route        interface{}
+	RadiusMeters float64
+	SchoolID     int32
+	MaxResults   int32
 }
 
 type FindNearRideRequestsRow struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
 	ImgUrl       pgtype.Text
 	Description  pgtype.Text
 }
 
+// See FindNearRides: one MultiPoint route, GiST-indexed ST_DWithin on origin
+// and destination, nearest origin first.
 func (q *Queries) FindNearRideRequests(ctx context.Context, arg FindNearRideRequestsParams) ([]FindNearRideRequestsRow, error) {
-	rows, err := q.db.Query(ctx, findNearRideRequests, arg.Column1, arg.StDwithin)
+	rows, err := q.db.Query(ctx, findNearRideRequests,
+		arg.Route,
+		arg.RadiusMeters,
+		arg.SchoolID,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -236,6 +255,8 @@ func (q *Queries) FindNearRideRequests(ctx context.Context, arg FindNearRideRequ
 			&i.RideDatetime,
 			&i.DriveOfferID,
 			&i.Status,
+			&i.Version,
+			&i.SchoolID,
 			&i.ImgUrl,
 			&i.Description,
 		); err != nil {
@@ -253,24 +274,28 @@ const findRideRequestByID = `-- name: FindRideRequestByID :one
 SELECT
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     status,
+    version,
+    school_id,
     img_url,
     description
- FROM tb_ride_requests WHERE id = $1
+ FROM tb_ride_requests WHERE id = $1 AND deleted_at IS NULL
 `
 
 type FindRideRequestByIDRow struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
 	ImgUrl       pgtype.Text
 	Description  pgtype.Text
 }
@@ -286,6 +311,8 @@ func (q *Queries) FindRideRequestByID(ctx context.Context, id int32) (FindRideRe
 		&i.RideDatetime,
 		&i.DriveOfferID,
 		&i.Status,
+		&i.Version,
+		&i.SchoolID,
 		&i.ImgUrl,
 		&i.Description,
 	)
@@ -293,7 +320,7 @@ func (q *Queries) FindRideRequestByID(ctx context.Context, id int32) (FindRideRe
 }
 
 const findRideRequestByPassengerID = `-- name: FindRideRequestByPassengerID :many
-SELECT id, passenger_id, origin, destination, ride_datetime, drive_offer_id, status, description, img_url FROM tb_ride_requests WHERE passenger_id = $1
+SELECT id, passenger_id, origin, destination, ride_datetime, drive_offer_id, status, description, img_url, deleted_at, version, school_id FROM tb_ride_requests WHERE passenger_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) FindRideRequestByPassengerID(ctx context.Context, passengerID string) ([]RideRequest, error) {
@@ -315,6 +342,172 @@ func (q *Queries) FindRideRequestByPassengerID(ctx context.Context, passengerID
 			&i.Status,
 			&i.Description,
 			&i.ImgUrl,
+			&i.DeletedAt,
+			&i.Version,
+			&i.SchoolID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRideRequests = `-- name: ListRideRequests :many
+SELECT
+    id,
+    passenger_id,
+    origin,
+    destination,
+    ride_datetime,
+    drive_offer_id,
+    img_url,
+    status,
+    version,
+    school_id,
+    description
+FROM tb_ride_requests
+WHERE
+    deleted_at IS NULL
+    AND (
+        $1::varchar IS NULL
+        OR passenger_id = $1::varchar
+    )
+    AND (
+        $2::varchar IS NULL
+        OR status = $2::varchar
+    )
+    AND (
+        $3::timestamp IS NULL
+        OR ride_datetime >= $3::timestamp
+    )
+    AND (
+        $4::timestamp IS NULL
+        OR ride_datetime < $4::timestamp
+    )
+    AND (
+        $5::float8 IS NULL
+        OR ST_Intersects (
+            origin::geometry,
+            ST_MakeEnvelope (
+                $5::float8,
+                $6::float8,
+                $7::float8,
+                $8::float8,
+                4326
+            )
+        )
+        OR ST_Intersects (
+            destination::geometry,
+            ST_MakeEnvelope (
+                $5::float8,
+                $6::float8,
+                $7::float8,
+                $8::float8,
+                4326
+            )
+        )
+    )
+    -- Keyset pagination: rows strictly after the cursor in the chosen order.
+    AND (
+        $9::int IS NULL
+        OR (
+            $10::text = 'ride_datetime'
+            AND NOT $11::bool
+            AND (ride_datetime, id) > ($12::timestamp, $9::int)
+        )
+        OR (
+            $10::text = 'ride_datetime'
+            AND $11::bool
+            AND (ride_datetime, id) < ($12::timestamp, $9::int)
+        )
+        OR (
+            $10::text = 'id'
+            AND NOT $11::bool
+            AND id > $9::int
+        )
+        OR (
+            $10::text = 'id'
+            AND $11::bool
+            AND id < $9::int
+        )
+    )
+ORDER BY
+    CASE WHEN $10::text = 'ride_datetime' AND NOT $11::bool THEN ride_datetime END ASC,
+    CASE WHEN $10::text = 'ride_datetime' AND $11::bool THEN ride_datetime END DESC,
+    CASE WHEN NOT $11::bool THEN id END ASC,
+    CASE WHEN $11::bool THEN id END DESC
+LIMIT $13::int
+`
+
+type ListRideRequestsParams struct {
+	PassengerID pgtype.Text
+	Status      pgtype.Text
+	RideFrom    pgtype.Timestamp
+	RideTo      pgtype.Timestamp
+	MinLon      pgtype.Float8
+	MinLat      pgtype.Float8
+	MaxLon      pgtype.Float8
+	MaxLat      pgtype.Float8
+	CursorID    pgtype.Int4
+	SortBy      string
+	Descending  bool
+	CursorTime  pgtype.Timestamp
+	PageSize    int32
+}
+
+type ListRideRequestsRow struct {
+	ID           int32
+	PassengerID  string
+	Origin       postgis.Point
+	Destination  postgis.Point
+	RideDatetime pgtype.Timestamp
+	DriveOfferID pgtype.Int4
+	ImgUrl       pgtype.Text
+	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
+	Description  pgtype.Text
+}
+
+func (q *Queries) ListRideRequests(ctx context.Context, arg ListRideRequestsParams) ([]ListRideRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listRideRequests,
+		arg.PassengerID,
+		arg.Status,
+		arg.RideFrom,
+		arg.RideTo,
+		arg.MinLon,
+		arg.MinLat,
+		arg.MaxLon,
+		arg.MaxLat,
+		arg.CursorID,
+		arg.SortBy,
+		arg.Descending,
+		arg.CursorTime,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRideRequestsRow
+	for rows.Next() {
+		var i ListRideRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PassengerID,
+			&i.Origin,
+			&i.Destination,
+			&i.RideDatetime,
+			&i.DriveOfferID,
+			&i.ImgUrl,
+			&i.Status,
+			&i.Version,
+			&i.SchoolID,
+			&i.Description,
 		); err != nil {
 			return nil, err
 		}
@@ -336,18 +529,22 @@ SET
     drive_offer_id = $8,
     status = $9,
     description = $10,
-    img_url = $11
-WHERE id = $1
+    img_url = $11,
+    school_id = $13,
+    version = version + 1
+WHERE id = $1 AND deleted_at IS NULL AND version = $12
 RETURNING
     id,
     passenger_id,
-    ST_AsText(origin) AS origin,
-    ST_AsText(destination) AS destination,
+    origin,
+    destination,
     ride_datetime,
     drive_offer_id,
     description,
     img_url,
-    status
+    status,
+    version,
+    school_id
 `
 
 type UpdateRideRequestParams struct {
@@ -362,18 +559,22 @@ type UpdateRideRequestParams struct {
 	Status        pgtype.Text
 	Description   pgtype.Text
 	ImgUrl        pgtype.Text
+	Version       int32
+	SchoolID      pgtype.Int4
 }
 
 type UpdateRideRequestRow struct {
 	ID           int32
 	PassengerID  string
-	Origin       interface{}
-	Destination  interface{}
+	Origin       postgis.Point
+	Destination  postgis.Point
 	RideDatetime pgtype.Timestamp
 	DriveOfferID pgtype.Int4
 	Description  pgtype.Text
 	ImgUrl       pgtype.Text
 	Status       pgtype.Text
+	Version      int32
+	SchoolID     pgtype.Int4
 }
 
 func (q *Queries) UpdateRideRequest(ctx context.Context, arg UpdateRideRequestParams) (UpdateRideRequestRow, error) {
@@ -389,6 +590,8 @@ func (q *Queries) UpdateRideRequest(ctx context.Context, arg UpdateRideRequestPa
 		arg.Status,
 		arg.Description,
 		arg.ImgUrl,
+		arg.Version,
+		arg.SchoolID,
 	)
 	var i UpdateRideRequestRow
 	err := row.Scan(
@@ -401,12 +604,14 @@ func (q *Queries) UpdateRideRequest(ctx context.Context, arg UpdateRideRequestPa
 		&i.Description,
 		&i.ImgUrl,
 		&i.Status,
+		&i.Version,
+		&i.SchoolID,
 	)
 	return i, err
 }
 
 const updateRideRequestStatus = `-- name: UpdateRideRequestStatus :one
-UPDATE tb_ride_requests SET status = $2 WHERE id = $1 RETURNING id, passenger_id, origin, destination, ride_datetime, drive_offer_id, status, description, img_url
+UPDATE tb_ride_requests SET status = $2, version = version + 1 WHERE id = $1 AND deleted_at IS NULL RETURNING id, passenger_id, origin, destination, ride_datetime, drive_offer_id, status, description, img_url, deleted_at, version, school_id
 `
 
 type UpdateRideRequestStatusParams struct {
@@ -427,6 +632,9 @@ func (q *Queries) UpdateRideRequestStatus(ctx context.Context, arg UpdateRideReq
 		&i.Status,
 		&i.Description,
 		&i.ImgUrl,
+		&i.DeletedAt,
+		&i.Version,
+		&i.SchoolID,
 	)
 	return i, err
 }
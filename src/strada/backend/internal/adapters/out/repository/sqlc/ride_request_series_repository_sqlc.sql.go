@@ -0,0 +1,379 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: ride_request_series_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimRideRequestOccurrence = `-- name: ClaimRideRequestOccurrence :execrows
+INSERT INTO
+    tb_ride_request_occurrences (series_id, departs_at, claimed_at)
+VALUES (
+        $1,
+        $2,
+        $3
+    )
+ON CONFLICT (series_id, departs_at) DO
+UPDATE
+SET
+    claimed_at = EXCLUDED.claimed_at
+WHERE
+    tb_ride_request_occurrences.ride_request_id IS NULL
+    AND tb_ride_request_occurrences.claimed_at < $4
+`
+
+type ClaimRideRequestOccurrenceParams struct {
+	SeriesID    int32
+	DepartsAt   pgtype.Timestamp
+	ClaimedAt   pgtype.Timestamp
+	StaleBefore pgtype.Timestamp
+}
+
+func (q *Queries) ClaimRideRequestOccurrence(ctx context.Context, arg ClaimRideRequestOccurrenceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimRideRequestOccurrence,
+		arg.SeriesID,
+		arg.DepartsAt,
+		arg.ClaimedAt,
+		arg.StaleBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createRideRequestSeries = `-- name: CreateRideRequestSeries :one
+INSERT INTO
+    tb_ride_request_series (
+        passenger_id,
+        origin,
+        destination,
+        description,
+        img_url,
+        weekdays,
+        departure_minutes,
+        time_zone,
+        start_date,
+        end_date,
+        exceptions,
+        materialized_until,
+        school_id
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13
+    )
+RETURNING
+    id, passenger_id, origin, destination, description, img_url, ride_series_id, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id
+`
+
+type CreateRideRequestSeriesParams struct {
+	PassengerID       string
+	Origin            postgis.Point
+	Destination       postgis.Point
+	Description       string
+	ImgUrl            string
+	Weekdays          []int32
+	DepartureMinutes  int32
+	TimeZone          string
+	StartDate         pgtype.Date
+	EndDate           pgtype.Date
+	Exceptions        []pgtype.Date
+	MaterializedUntil pgtype.Timestamp
+	SchoolID          pgtype.Int4
+}
+
+func (q *Queries) CreateRideRequestSeries(ctx context.Context, arg CreateRideRequestSeriesParams) (RideRequestSeries, error) {
+	row := q.db.QueryRow(ctx, createRideRequestSeries,
+		arg.PassengerID,
+		arg.Origin,
+		arg.Destination,
+		arg.Description,
+		arg.ImgUrl,
+		arg.Weekdays,
+		arg.DepartureMinutes,
+		arg.TimeZone,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Exceptions,
+		arg.MaterializedUntil,
+		arg.SchoolID,
+	)
+	var i RideRequestSeries
+	err := row.Scan(
+		&i.ID,
+		&i.PassengerID,
+		&i.Origin,
+		&i.Destination,
+		&i.Description,
+		&i.ImgUrl,
+		&i.RideSeriesID,
+		&i.Weekdays,
+		&i.DepartureMinutes,
+		&i.TimeZone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Exceptions,
+		&i.MaterializedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SchoolID,
+	)
+	return i, err
+}
+
+const deleteRideRequestOccurrenceClaim = `-- name: DeleteRideRequestOccurrenceClaim :exec
+DELETE FROM tb_ride_request_occurrences
+WHERE
+    series_id = $1
+    AND departs_at = $2
+    AND ride_request_id IS NULL
+`
+
+type DeleteRideRequestOccurrenceClaimParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+}
+
+func (q *Queries) DeleteRideRequestOccurrenceClaim(ctx context.Context, arg DeleteRideRequestOccurrenceClaimParams) error {
+	_, err := q.db.Exec(ctx, deleteRideRequestOccurrenceClaim, arg.SeriesID, arg.DepartsAt)
+	return err
+}
+
+const findDueRideRequestSeries = `-- name: FindDueRideRequestSeries :many
+SELECT id, passenger_id, origin, destination, description, img_url, ride_series_id, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id
+FROM tb_ride_request_series
+WHERE
+    materialized_until < $1
+    AND (
+        end_date IS NULL
+        OR end_date >= materialized_until::date
+    )
+ORDER BY materialized_until, id
+`
+
+func (q *Queries) FindDueRideRequestSeries(ctx context.Context, materializedUntil pgtype.Timestamp) ([]RideRequestSeries, error) {
+	rows, err := q.db.Query(ctx, findDueRideRequestSeries, materializedUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RideRequestSeries
+	for rows.Next() {
+		var i RideRequestSeries
+		if err := rows.Scan(
+			&i.ID,
+			&i.PassengerID,
+			&i.Origin,
+			&i.Destination,
+			&i.Description,
+			&i.ImgUrl,
+			&i.RideSeriesID,
+			&i.Weekdays,
+			&i.DepartureMinutes,
+			&i.TimeZone,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Exceptions,
+			&i.MaterializedUntil,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SchoolID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRideRequestOccurrences = `-- name: FindRideRequestOccurrences :many
+SELECT
+    series_id,
+    departs_at,
+    ride_request_id,
+    ride_id
+FROM tb_ride_request_occurrences
+WHERE
+    series_id = $1
+    AND departs_at >= $2
+    AND ride_request_id IS NOT NULL
+ORDER BY departs_at
+`
+
+type FindRideRequestOccurrencesParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+}
+
+type FindRideRequestOccurrencesRow struct {
+	SeriesID      int32
+	DepartsAt     pgtype.Timestamp
+	RideRequestID pgtype.Int4
+	RideID        pgtype.Int4
+}
+
+func (q *Queries) FindRideRequestOccurrences(ctx context.Context, arg FindRideRequestOccurrencesParams) ([]FindRideRequestOccurrencesRow, error) {
+	rows, err := q.db.Query(ctx, findRideRequestOccurrences, arg.SeriesID, arg.DepartsAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindRideRequestOccurrencesRow
+	for rows.Next() {
+		var i FindRideRequestOccurrencesRow
+		if err := rows.Scan(
+			&i.SeriesID,
+			&i.DepartsAt,
+			&i.RideRequestID,
+			&i.RideID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRideRequestSeriesByID = `-- name: FindRideRequestSeriesByID :one
+SELECT id, passenger_id, origin, destination, description, img_url, ride_series_id, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id FROM tb_ride_request_series WHERE id = $1
+`
+
+func (q *Queries) FindRideRequestSeriesByID(ctx context.Context, id int32) (RideRequestSeries, error) {
+	row := q.db.QueryRow(ctx, findRideRequestSeriesByID, id)
+	var i RideRequestSeries
+	err := row.Scan(
+		&i.ID,
+		&i.PassengerID,
+		&i.Origin,
+		&i.Destination,
+		&i.Description,
+		&i.ImgUrl,
+		&i.RideSeriesID,
+		&i.Weekdays,
+		&i.DepartureMinutes,
+		&i.TimeZone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Exceptions,
+		&i.MaterializedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SchoolID,
+	)
+	return i, err
+}
+
+const updateRideRequestOccurrenceRide = `-- name: UpdateRideRequestOccurrenceRide :execrows
+UPDATE tb_ride_request_occurrences
+SET
+    ride_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2
+`
+
+type UpdateRideRequestOccurrenceRideParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+	RideID    pgtype.Int4
+}
+
+func (q *Queries) UpdateRideRequestOccurrenceRide(ctx context.Context, arg UpdateRideRequestOccurrenceRideParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideRequestOccurrenceRide, arg.SeriesID, arg.DepartsAt, arg.RideID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateRideRequestOccurrenceRideRequest = `-- name: UpdateRideRequestOccurrenceRideRequest :execrows
+UPDATE tb_ride_request_occurrences
+SET
+    ride_request_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2
+`
+
+type UpdateRideRequestOccurrenceRideRequestParams struct {
+	SeriesID      int32
+	DepartsAt     pgtype.Timestamp
+	RideRequestID pgtype.Int4
+}
+
+func (q *Queries) UpdateRideRequestOccurrenceRideRequest(ctx context.Context, arg UpdateRideRequestOccurrenceRideRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideRequestOccurrenceRideRequest, arg.SeriesID, arg.DepartsAt, arg.RideRequestID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateRideRequestSeriesMaterializedUntil = `-- name: UpdateRideRequestSeriesMaterializedUntil :execrows
+UPDATE tb_ride_request_series
+SET
+    materialized_until = $2,
+    updated_at = NOW()
+WHERE
+    id = $1
+`
+
+type UpdateRideRequestSeriesMaterializedUntilParams struct {
+	ID                int32
+	MaterializedUntil pgtype.Timestamp
+}
+
+func (q *Queries) UpdateRideRequestSeriesMaterializedUntil(ctx context.Context, arg UpdateRideRequestSeriesMaterializedUntilParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideRequestSeriesMaterializedUntil, arg.ID, arg.MaterializedUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateRideRequestSeriesRideSeries = `-- name: UpdateRideRequestSeriesRideSeries :execrows
+UPDATE tb_ride_request_series
+SET
+    ride_series_id = $2,
+    updated_at = NOW()
+WHERE
+    id = $1
+`
+
+type UpdateRideRequestSeriesRideSeriesParams struct {
+	ID           int32
+	RideSeriesID pgtype.Int4
+}
+
+func (q *Queries) UpdateRideRequestSeriesRideSeries(ctx context.Context, arg UpdateRideRequestSeriesRideSeriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideRequestSeriesRideSeries, arg.ID, arg.RideSeriesID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
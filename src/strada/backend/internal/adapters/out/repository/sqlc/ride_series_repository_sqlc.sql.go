@@ -0,0 +1,363 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: ride_series_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimRideOccurrence = `-- name: ClaimRideOccurrence :execrows
+INSERT INTO
+    tb_ride_occurrences (series_id, departs_at, claimed_at)
+VALUES (
+        $1,
+        $2,
+        $3
+    )
+ON CONFLICT (series_id, departs_at) DO
+UPDATE
+SET
+    claimed_at = EXCLUDED.claimed_at
+WHERE
+    tb_ride_occurrences.ride_id IS NULL
+    AND tb_ride_occurrences.claimed_at < $4
+`
+
+type ClaimRideOccurrenceParams struct {
+	SeriesID    int32
+	DepartsAt   pgtype.Timestamp
+	ClaimedAt   pgtype.Timestamp
+	StaleBefore pgtype.Timestamp
+}
+
+func (q *Queries) ClaimRideOccurrence(ctx context.Context, arg ClaimRideOccurrenceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimRideOccurrence,
+		arg.SeriesID,
+		arg.DepartsAt,
+		arg.ClaimedAt,
+		arg.StaleBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createRideSeries = `-- name: CreateRideSeries :one
+INSERT INTO
+    tb_ride_series (
+        driver_id,
+        vehicle_id,
+        start_point,
+        end_point,
+        stop_points,
+        description,
+        img_url,
+        currency,
+        cost_ceiling,
+        max_detour_meters,
+        max_detour_ms,
+        weekdays,
+        departure_minutes,
+        time_zone,
+        start_date,
+        end_date,
+        exceptions,
+        materialized_until,
+        school_id
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        $8,
+        $9,
+        $10,
+        $11,
+        $12,
+        $13,
+        $14,
+        $15,
+        $16,
+        $17,
+        $18,
+        $19
+    )
+RETURNING
+    id, driver_id, vehicle_id, start_point, end_point, stop_points, description, img_url, currency, cost_ceiling, max_detour_meters, max_detour_ms, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id
+`
+
+type CreateRideSeriesParams struct {
+	DriverID          string
+	VehicleID         int32
+	StartPoint        postgis.Point
+	EndPoint          postgis.Point
+	StopPoints        postgis.MultiPoint
+	Description       string
+	ImgUrl            string
+	Currency          string
+	CostCeiling       pgtype.Numeric
+	MaxDetourMeters   float64
+	MaxDetourMs       int64
+	Weekdays          []int32
+	DepartureMinutes  int32
+	TimeZone          string
+	StartDate         pgtype.Date
+	EndDate           pgtype.Date
+	Exceptions        []pgtype.Date
+	MaterializedUntil pgtype.Timestamp
+	SchoolID          pgtype.Int4
+}
+
+func (q *Queries) CreateRideSeries(ctx context.Context, arg CreateRideSeriesParams) (RideSeries, error) {
+	row := q.db.QueryRow(ctx, createRideSeries,
+		arg.DriverID,
+		arg.VehicleID,
+		arg.StartPoint,
+		arg.EndPoint,
+		arg.StopPoints,
+		arg.Description,
+		arg.ImgUrl,
+		arg.Currency,
+		arg.CostCeiling,
+		arg.MaxDetourMeters,
+		arg.MaxDetourMs,
+		arg.Weekdays,
+		arg.DepartureMinutes,
+		arg.TimeZone,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Exceptions,
+		arg.MaterializedUntil,
+		arg.SchoolID,
+	)
+	var i RideSeries
+	err := row.Scan(
+		&i.ID,
+		&i.DriverID,
+		&i.VehicleID,
+		&i.StartPoint,
+		&i.EndPoint,
+		&i.StopPoints,
+		&i.Description,
+		&i.ImgUrl,
+		&i.Currency,
+		&i.CostCeiling,
+		&i.MaxDetourMeters,
+		&i.MaxDetourMs,
+		&i.Weekdays,
+		&i.DepartureMinutes,
+		&i.TimeZone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Exceptions,
+		&i.MaterializedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SchoolID,
+	)
+	return i, err
+}
+
+const deleteRideOccurrenceClaim = `-- name: DeleteRideOccurrenceClaim :exec
+DELETE FROM tb_ride_occurrences
+WHERE
+    series_id = $1
+    AND departs_at = $2
+    AND ride_id IS NULL
+`
+
+type DeleteRideOccurrenceClaimParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+}
+
+func (q *Queries) DeleteRideOccurrenceClaim(ctx context.Context, arg DeleteRideOccurrenceClaimParams) error {
+	_, err := q.db.Exec(ctx, deleteRideOccurrenceClaim, arg.SeriesID, arg.DepartsAt)
+	return err
+}
+
+const findDueRideSeries = `-- name: FindDueRideSeries :many
+SELECT id, driver_id, vehicle_id, start_point, end_point, stop_points, description, img_url, currency, cost_ceiling, max_detour_meters, max_detour_ms, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id
+FROM tb_ride_series
+WHERE
+    materialized_until < $1
+    AND (
+        end_date IS NULL
+        OR end_date >= materialized_until::date
+    )
+ORDER BY materialized_until, id
+`
+
+func (q *Queries) FindDueRideSeries(ctx context.Context, materializedUntil pgtype.Timestamp) ([]RideSeries, error) {
+	rows, err := q.db.Query(ctx, findDueRideSeries, materializedUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RideSeries
+	for rows.Next() {
+		var i RideSeries
+		if err := rows.Scan(
+			&i.ID,
+			&i.DriverID,
+			&i.VehicleID,
+			&i.StartPoint,
+			&i.EndPoint,
+			&i.StopPoints,
+			&i.Description,
+			&i.ImgUrl,
+			&i.Currency,
+			&i.CostCeiling,
+			&i.MaxDetourMeters,
+			&i.MaxDetourMs,
+			&i.Weekdays,
+			&i.DepartureMinutes,
+			&i.TimeZone,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Exceptions,
+			&i.MaterializedUntil,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SchoolID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRideOccurrences = `-- name: FindRideOccurrences :many
+SELECT series_id, departs_at, ride_id
+FROM tb_ride_occurrences
+WHERE
+    series_id = $1
+    AND departs_at >= $2
+    AND ride_id IS NOT NULL
+ORDER BY departs_at
+`
+
+type FindRideOccurrencesParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+}
+
+type FindRideOccurrencesRow struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+	RideID    pgtype.Int4
+}
+
+func (q *Queries) FindRideOccurrences(ctx context.Context, arg FindRideOccurrencesParams) ([]FindRideOccurrencesRow, error) {
+	rows, err := q.db.Query(ctx, findRideOccurrences, arg.SeriesID, arg.DepartsAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindRideOccurrencesRow
+	for rows.Next() {
+		var i FindRideOccurrencesRow
+		if err := rows.Scan(&i.SeriesID, &i.DepartsAt, &i.RideID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRideSeriesByID = `-- name: FindRideSeriesByID :one
+SELECT id, driver_id, vehicle_id, start_point, end_point, stop_points, description, img_url, currency, cost_ceiling, max_detour_meters, max_detour_ms, weekdays, departure_minutes, time_zone, start_date, end_date, exceptions, materialized_until, created_at, updated_at, school_id FROM tb_ride_series WHERE id = $1
+`
+
+func (q *Queries) FindRideSeriesByID(ctx context.Context, id int32) (RideSeries, error) {
+	row := q.db.QueryRow(ctx, findRideSeriesByID, id)
+	var i RideSeries
+	err := row.Scan(
+		&i.ID,
+		&i.DriverID,
+		&i.VehicleID,
+		&i.StartPoint,
+		&i.EndPoint,
+		&i.StopPoints,
+		&i.Description,
+		&i.ImgUrl,
+		&i.Currency,
+		&i.CostCeiling,
+		&i.MaxDetourMeters,
+		&i.MaxDetourMs,
+		&i.Weekdays,
+		&i.DepartureMinutes,
+		&i.TimeZone,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Exceptions,
+		&i.MaterializedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SchoolID,
+	)
+	return i, err
+}
+
+const updateRideOccurrenceRide = `-- name: UpdateRideOccurrenceRide :execrows
+UPDATE tb_ride_occurrences
+SET
+    ride_id = $3
+WHERE
+    series_id = $1
+    AND departs_at = $2
+`
+
+type UpdateRideOccurrenceRideParams struct {
+	SeriesID  int32
+	DepartsAt pgtype.Timestamp
+	RideID    pgtype.Int4
+}
+
+func (q *Queries) UpdateRideOccurrenceRide(ctx context.Context, arg UpdateRideOccurrenceRideParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideOccurrenceRide, arg.SeriesID, arg.DepartsAt, arg.RideID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateRideSeriesMaterializedUntil = `-- name: UpdateRideSeriesMaterializedUntil :execrows
+UPDATE tb_ride_series
+SET
+    materialized_until = $2,
+    updated_at = NOW()
+WHERE
+    id = $1
+`
+
+type UpdateRideSeriesMaterializedUntilParams struct {
+	ID                int32
+	MaterializedUntil pgtype.Timestamp
+}
+
+func (q *Queries) UpdateRideSeriesMaterializedUntil(ctx context.Context, arg UpdateRideSeriesMaterializedUntilParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateRideSeriesMaterializedUntil, arg.ID, arg.MaterializedUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,182 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: school_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/postgis"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSchool = `-- name: CreateSchool :one
+INSERT INTO
+    tb_schools (
+        name,
+        address,
+        campus,
+        gates,
+        time_zone,
+        class_start_minutes,
+        class_end_minutes
+    )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+    id, name, address, campus, gates, time_zone, class_start_minutes, class_end_minutes, created_at, updated_at
+`
+
+type CreateSchoolParams struct {
+	Name              string
+	Address           string
+	Campus            postgis.Polygon
+	Gates             postgis.MultiPoint
+	TimeZone          string
+	ClassStartMinutes []int32
+	ClassEndMinutes   []int32
+}
+
+func (q *Queries) CreateSchool(ctx context.Context, arg CreateSchoolParams) (School, error) {
+	row := q.db.QueryRow(ctx, createSchool,
+		arg.Name,
+		arg.Address,
+		arg.Campus,
+		arg.Gates,
+		arg.TimeZone,
+		arg.ClassStartMinutes,
+		arg.ClassEndMinutes,
+	)
+	var i School
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.Campus,
+		&i.Gates,
+		&i.TimeZone,
+		&i.ClassStartMinutes,
+		&i.ClassEndMinutes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findSchoolByID = `-- name: FindSchoolByID :one
+SELECT id, name, address, campus, gates, time_zone, class_start_minutes, class_end_minutes, created_at, updated_at FROM tb_schools WHERE id = $1
+`
+
+func (q *Queries) FindSchoolByID(ctx context.Context, id int32) (School, error) {
+	row := q.db.QueryRow(ctx, findSchoolByID, id)
+	var i School
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.Campus,
+		&i.Gates,
+		&i.TimeZone,
+		&i.ClassStartMinutes,
+		&i.ClassEndMinutes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findSchools = `-- name: FindSchools :many
+SELECT id, name, address, campus, gates, time_zone, class_start_minutes, class_end_minutes, created_at, updated_at
+FROM tb_schools
+WHERE
+    $1::varchar IS NULL
+    OR name ILIKE '%' || $1::varchar || '%'
+ORDER BY name, id
+`
+
+func (q *Queries) FindSchools(ctx context.Context, name pgtype.Text) ([]School, error) {
+	rows, err := q.db.Query(ctx, findSchools, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []School
+	for rows.Next() {
+		var i School
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Address,
+			&i.Campus,
+			&i.Gates,
+			&i.TimeZone,
+			&i.ClassStartMinutes,
+			&i.ClassEndMinutes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSchool = `-- name: UpdateSchool :one
+UPDATE tb_schools
+SET
+    name = $2,
+    address = $3,
+    campus = $4,
+    gates = $5,
+    time_zone = $6,
+    class_start_minutes = $7,
+    class_end_minutes = $8,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING
+    id, name, address, campus, gates, time_zone, class_start_minutes, class_end_minutes, created_at, updated_at
+`
+
+type UpdateSchoolParams struct {
+	ID                int32
+	Name              string
+	Address           string
+	Campus            postgis.Polygon
+	Gates             postgis.MultiPoint
+	TimeZone          string
+	ClassStartMinutes []int32
+	ClassEndMinutes   []int32
+}
+
+func (q *Queries) UpdateSchool(ctx context.Context, arg UpdateSchoolParams) (School, error) {
+	row := q.db.QueryRow(ctx, updateSchool,
+		arg.ID,
+		arg.Name,
+		arg.Address,
+		arg.Campus,
+		arg.Gates,
+		arg.TimeZone,
+		arg.ClassStartMinutes,
+		arg.ClassEndMinutes,
+	)
+	var i School
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Address,
+		&i.Campus,
+		&i.Gates,
+		&i.TimeZone,
+		&i.ClassStartMinutes,
+		&i.ClassEndMinutes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
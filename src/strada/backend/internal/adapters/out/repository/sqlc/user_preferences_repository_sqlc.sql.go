@@ -0,0 +1,146 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: user_preferences_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const findUserPreferences = `-- name: FindUserPreferences :one
+SELECT
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in
+FROM tb_user_preferences
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) FindUserPreferences(ctx context.Context, userID string) (UserPreference, error) {
+	row := q.db.QueryRow(ctx, findUserPreferences, userID)
+	var i UserPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.SchoolID,
+		&i.LeaderboardOptOut,
+		&i.UpdatedAt,
+		&i.SchoolVerifiedAt,
+		&i.Minor,
+		&i.LeaderboardOptIn,
+	)
+	return i, err
+}
+
+const findUserPreferencesBySchool = `-- name: FindUserPreferencesBySchool :many
+SELECT
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in
+FROM tb_user_preferences
+WHERE
+    school_id = $1
+ORDER BY user_id
+`
+
+func (q *Queries) FindUserPreferencesBySchool(ctx context.Context, schoolID pgtype.Int4) ([]UserPreference, error) {
+	rows, err := q.db.Query(ctx, findUserPreferencesBySchool, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserPreference
+	for rows.Next() {
+		var i UserPreference
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SchoolID,
+			&i.LeaderboardOptOut,
+			&i.UpdatedAt,
+			&i.SchoolVerifiedAt,
+			&i.Minor,
+			&i.LeaderboardOptIn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const saveUserPreferences = `-- name: SaveUserPreferences :one
+INSERT INTO
+    tb_user_preferences (
+        user_id,
+        school_id,
+        leaderboard_opt_out,
+        school_verified_at,
+        minor,
+        leaderboard_opt_in,
+        updated_at
+    )
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (user_id) DO
+UPDATE
+SET
+    school_id = EXCLUDED.school_id,
+    leaderboard_opt_out = EXCLUDED.leaderboard_opt_out,
+    school_verified_at = EXCLUDED.school_verified_at,
+    minor = EXCLUDED.minor,
+    leaderboard_opt_in = EXCLUDED.leaderboard_opt_in,
+    updated_at = NOW()
+RETURNING
+    user_id,
+    school_id,
+    leaderboard_opt_out,
+    updated_at,
+    school_verified_at,
+    minor,
+    leaderboard_opt_in
+`
+
+type SaveUserPreferencesParams struct {
+	UserID            string
+	SchoolID          pgtype.Int4
+	LeaderboardOptOut bool
+	SchoolVerifiedAt  pgtype.Timestamp
+	Minor             bool
+	LeaderboardOptIn  bool
+}
+
+func (q *Queries) SaveUserPreferences(ctx context.Context, arg SaveUserPreferencesParams) (UserPreference, error) {
+	row := q.db.QueryRow(ctx, saveUserPreferences,
+		arg.UserID,
+		arg.SchoolID,
+		arg.LeaderboardOptOut,
+		arg.SchoolVerifiedAt,
+		arg.Minor,
+		arg.LeaderboardOptIn,
+	)
+	var i UserPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.SchoolID,
+		&i.LeaderboardOptOut,
+		&i.UpdatedAt,
+		&i.SchoolVerifiedAt,
+		&i.Minor,
+		&i.LeaderboardOptIn,
+	)
+	return i, err
+}
@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.28.0
+// source: vehicle_repository_sqlc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const findVehicleByID = `-- name: FindVehicleByID :one
+SELECT
+    id,
+    driver_id,
+    make,
+    model,
+    year,
+    license_plate,
+    fuel_type,
+    fuel_consumption
+FROM tb_vehicles
+WHERE
+    id = $1
+`
+
+type FindVehicleByIDRow struct {
+	ID              int32
+	DriverID        int32
+	Make            string
+	Model           string
+	Year            int32
+	LicensePlate    string
+	FuelType        pgtype.Text
+	FuelConsumption pgtype.Float8
+}
+
+func (q *Queries) FindVehicleByID(ctx context.Context, id int32) (FindVehicleByIDRow, error) {
+	row := q.db.QueryRow(ctx, findVehicleByID, id)
+	var i FindVehicleByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.DriverID,
+		&i.Make,
+		&i.Model,
+		&i.Year,
+		&i.LicensePlate,
+		&i.FuelType,
+		&i.FuelConsumption,
+	)
+	return i, err
+}
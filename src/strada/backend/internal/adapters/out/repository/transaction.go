@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	uniqueViolation      = "23505"
+
+	defaultTxAttempts = 3
+	txRetryBackoff    = 20 * time.Millisecond
+)
+
+type txKey struct{}
+
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+type TransactionManager struct {
+	db          TxBeginner
+	options     pgx.TxOptions
+	maxAttempts int
+}
+
+// NewTransactionManager runs units of work as serializable transactions and
+// retries them when Postgres reports a serialization failure or deadlock.
+func NewTransactionManager(db TxBeginner) out.TransactionManager {
+	return &TransactionManager{
+		db:          db,
+		options:     pgx.TxOptions{IsoLevel: pgx.Serializable},
+		maxAttempts: defaultTxAttempts,
+	}
+}
+
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested units of work join the outer transaction.
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= m.maxAttempts; attempt++ {
+		err = m.run(ctx, fn)
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+
+		logger.Info("retrying transaction", zap.Int("attempt", attempt), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+	return err
+}
+
+func (m *TransactionManager) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.db.BeginTx(ctx, m.options)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.Error("error rolling back transaction", rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
+// queries returns q bound to the transaction carried by ctx, if any.
+func queries(ctx context.Context, q *dbsqlc.Queries) *dbsqlc.Queries {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return q.WithTx(tx)
+	}
+	return q
+}
+
+// NoopTransactionManager runs the unit of work directly. It is meant for
+// repositories without transactional storage, such as in-memory ones.
+type NoopTransactionManager struct{}
+
+func NewNoopTransactionManager() out.TransactionManager {
+	return &NoopTransactionManager{}
+}
+
+func (m *NoopTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -2,12 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/244Walyson/shared-ride/internal/adapters/dto"
 	proto "github.com/244Walyson/shared-ride/internal/adapters/out/repository/grpc"
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	"github.com/244Walyson/shared-ride/internal/application/ports/out"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserRepository struct {
@@ -27,7 +30,56 @@ func (r *UserRepository) FindById(ctx context.Context, id string) (*models.User,
 
 	res, err := r.client.FindById(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, toUserDomainError(err)
 	}
 	return dto.ToModelFromUserResponseDto(res), nil
 }
+
+func (r *UserRepository) FindByIds(ctx context.Context, ids []string) ([]*models.User, error) {
+	req := &proto.FindUsersRequestDto{
+		Ids: ids,
+	}
+
+	res, err := r.client.FindByIds(ctx, req)
+	if err != nil {
+		return nil, toUserDomainError(err)
+	}
+
+	users := make([]*models.User, len(res.Users))
+	for i, user := range res.Users {
+		users[i] = dto.ToModelFromUserResponseDto(user)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) FindGuardianRelation(ctx context.Context, guardianId string, minorId string) (*models.GuardianRelation, error) {
+	req := &proto.FindGuardianRelationRequestDto{
+		GuardianId: guardianId,
+		MinorId:    minorId,
+	}
+
+	res, err := r.client.FindGuardianRelation(ctx, req)
+	if status.Code(err) == codes.NotFound {
+		return nil, models.ErrGuardianNotFound
+	}
+	if err != nil {
+		return nil, toUserDomainError(err)
+	}
+	return dto.ToModelFromGuardianRelationResponseDto(res), nil
+}
+
+func toUserDomainError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", models.ErrUserNotFound, st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return fmt.Errorf("%w: %s", models.ErrUserServiceUnavailable, st.Message())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+)
+
+type UserPreferencesRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewUserPreferencesRepository(db dbsqlc.DBTX) out.UserPreferencesRepository {
+	return &UserPreferencesRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *UserPreferencesRepository) FindByUser(ctx context.Context, userId string) (*models.UserPreferences, error) {
+	row, err := queries(ctx, r.sqlc).FindUserPreferences(ctx, userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &models.UserPreferences{UserID: userId}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toUserPreferences(row), nil
+}
+
+func (r *UserPreferencesRepository) Save(ctx context.Context, preferences *models.UserPreferences) (*models.UserPreferences, error) {
+	row, err := queries(ctx, r.sqlc).SaveUserPreferences(ctx, dbsqlc.SaveUserPreferencesParams{
+		UserID:            preferences.UserID,
+		SchoolID:          idParam(preferences.SchoolID),
+		LeaderboardOptOut: preferences.LeaderboardOptOut,
+		SchoolVerifiedAt:  timestampParam(preferences.SchoolVerifiedAt),
+		Minor:             preferences.Minor,
+		LeaderboardOptIn:  preferences.LeaderboardOptIn,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUserPreferences(row), nil
+}
+
+func (r *UserPreferencesRepository) FindBySchool(ctx context.Context, schoolId int32) ([]*models.UserPreferences, error) {
+	rows, err := queries(ctx, r.sqlc).FindUserPreferencesBySchool(ctx, idParam(schoolId))
+	if err != nil {
+		return nil, err
+	}
+	preferences := make([]*models.UserPreferences, len(rows))
+	for i := range rows {
+		preferences[i] = toUserPreferences(rows[i])
+	}
+	return preferences, nil
+}
+
+func toUserPreferences(row dbsqlc.UserPreference) *models.UserPreferences {
+	return &models.UserPreferences{
+		UserID:            row.UserID,
+		SchoolID:          row.SchoolID.Int32,
+		SchoolVerifiedAt:  row.SchoolVerifiedAt.Time,
+		Minor:             row.Minor,
+		LeaderboardOptOut: row.LeaderboardOptOut,
+		LeaderboardOptIn:  row.LeaderboardOptIn,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	dbsqlc "github.com/244Walyson/shared-ride/internal/adapters/out/repository/sqlc"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"github.com/jackc/pgx/v5"
+)
+
+type VehicleRepository struct {
+	sqlc *dbsqlc.Queries
+}
+
+func NewVehicleRepository(db dbsqlc.DBTX) out.VehicleRepository {
+	return &VehicleRepository{
+		sqlc: dbsqlc.New(db),
+	}
+}
+
+func (r *VehicleRepository) FindById(ctx context.Context, id int32) (*models.Vehicle, error) {
+	vehicle, err := queries(ctx, r.sqlc).FindVehicleByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrVehicleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Vehicle{
+		ID:              vehicle.ID,
+		Make:            vehicle.Make,
+		Model:           vehicle.Model,
+		Year:            vehicle.Year,
+		LicensePlate:    vehicle.LicensePlate,
+		FuelType:        vehicle.FuelType.String,
+		FuelConsumption: vehicle.FuelConsumption.Float64,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/244Walyson/shared-ride/configs"
@@ -17,12 +18,19 @@ import (
 
 type VerifyTokenRepository struct {
 	jwks *keyfunc.JWKS
+	// serviceAudience and serviceIssuer must both match before a token with
+	// a client_id claim is trusted as a service; empty disables service
+	// tokens.
+	serviceAudience string
+	serviceIssuer   string
 }
 
 func NewVerifyTokenRepository() out.VerifyTokenRepository {
 	jwkSet, _ := initializeJwks()
 	return &VerifyTokenRepository{
-		jwks: jwkSet,
+		jwks:            jwkSet,
+		serviceAudience: configs.GetEnv("SERVICE_TOKEN_AUDIENCE", ""),
+		serviceIssuer:   configs.GetEnv("SERVICE_TOKEN_ISSUER", ""),
 	}
 }
 
@@ -71,7 +79,7 @@ func (r *VerifyTokenRepository) ensureJWKS() error {
 	return nil
 }
 
-func (r *VerifyTokenRepository) VerifyToken(ctx context.Context, tokenString string) (*models.User, error) {
+func (r *VerifyTokenRepository) VerifyToken(ctx context.Context, tokenString string) (*models.Principal, error) {
 
 	if err := r.ensureJWKS(); err != nil {
 		return nil, err
@@ -91,10 +99,77 @@ func (r *VerifyTokenRepository) VerifyToken(ctx context.Context, tokenString str
 		return nil, errors.New("could not extract claims from token")
 	}
 
-	user := &models.User{
-		ID:    claims["sub"].(string),
-		Email: claims["email"].(string),
+	// Client-credentials tokens identify a calling service, not a user.
+	if clientId, ok := claims["client_id"].(string); ok && clientId != "" {
+		if err := r.verifyServiceClaims(claims); err != nil {
+			return nil, err
+		}
+		return &models.Principal{
+			ClientID: clientId,
+			Scopes:   scopesFromClaims(claims),
+		}, nil
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, errors.New("token has no subject")
 	}
+	email, _ := claims["email"].(string)
 
-	return user, nil
+	principal := &models.Principal{
+		UserID: sub,
+		Email:  email,
+		Roles:  rolesFromClaims(claims),
+	}
+
+	return principal, nil
+}
+
+// verifyServiceClaims keeps user tokens that happen to carry a client_id from
+// passing as services: the auth service issues client-credentials tokens for
+// a dedicated audience.
+func (r *VerifyTokenRepository) verifyServiceClaims(claims jwt.MapClaims) error {
+	if r.serviceAudience == "" || r.serviceIssuer == "" {
+		return errors.New("service tokens are disabled, SERVICE_TOKEN_AUDIENCE and SERVICE_TOKEN_ISSUER are not set")
+	}
+	if !claims.VerifyAudience(r.serviceAudience, true) {
+		return errors.New("service token has an unexpected audience")
+	}
+	if !claims.VerifyIssuer(r.serviceIssuer, true) {
+		return errors.New("service token has an unexpected issuer")
+	}
+	return nil
+}
+
+// rolesFromClaims accepts either a "roles" array or a single "role" string.
+func rolesFromClaims(claims jwt.MapClaims) []string {
+	var roles []string
+	if raw, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range raw {
+			if role, ok := r.(string); ok {
+				roles = append(roles, strings.ToLower(role))
+			}
+		}
+	}
+	if role, ok := claims["role"].(string); ok && role != "" {
+		roles = append(roles, strings.ToLower(role))
+	}
+	return roles
+}
+
+// scopesFromClaims accepts the OAuth2 space separated "scope" string or a
+// "scopes" array.
+func scopesFromClaims(claims jwt.MapClaims) []string {
+	var scopes []string
+	if scope, ok := claims["scope"].(string); ok {
+		scopes = strings.Fields(scope)
+	}
+	if raw, ok := claims["scopes"].([]interface{}); ok {
+		for _, s := range raw {
+			if scope, ok := s.(string); ok {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
 }
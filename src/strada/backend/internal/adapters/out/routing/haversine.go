@@ -0,0 +1,54 @@
+package routing
+
+import (
+	"context"
+	"math"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// HaversineRouter estimates routes without a routing engine. Roads are
+// longer than straight lines, so every great-circle leg is stretched by
+// detourFactor and driven at speedKmh.
+type HaversineRouter struct {
+	detourFactor float64
+	speedKmh     float64
+}
+
+func NewHaversineRouter(detourFactor float64, speedKmh float64) out.RoutingProvider {
+	return &HaversineRouter{
+		detourFactor: detourFactor,
+		speedKmh:     speedKmh,
+	}
+}
+
+func (r *HaversineRouter) Route(ctx context.Context, points []models.Location) (*models.Route, error) {
+	route := &models.Route{Legs: []models.RouteLeg{}}
+	for i := 1; i < len(points); i++ {
+		leg := r.leg(points[i-1], points[i])
+		route.Legs = append(route.Legs, leg)
+		route.DistanceMeters += leg.DistanceMeters
+		route.DurationMs += leg.DurationMs
+	}
+	return route, nil
+}
+
+func (r *HaversineRouter) Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error) {
+	matrix := make([][]models.RouteLeg, len(points))
+	for i := range points {
+		matrix[i] = make([]models.RouteLeg, len(points))
+		for j := range points {
+			matrix[i][j] = r.leg(points[i], points[j])
+		}
+	}
+	return matrix, nil
+}
+
+func (r *HaversineRouter) leg(from models.Location, to models.Location) models.RouteLeg {
+	meters := models.HaversineMeters(from, to) * r.detourFactor
+	return models.RouteLeg{
+		DistanceMeters: meters,
+		DurationMs:     int64(math.Round(meters / (r.speedKmh / 3.6) * 1000)),
+	}
+}
@@ -0,0 +1,142 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// OsrmRouter asks an OSRM compatible server for the driving route, through
+// the /route/v1 HTTP API.
+type OsrmRouter struct {
+	baseURL string
+	profile string
+	client  *http.Client
+}
+
+func NewOsrmRouter(baseURL string, profile string, timeout time.Duration) out.RoutingProvider {
+	return &OsrmRouter{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		profile: profile,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type osrmLeg struct {
+	Distance float64 `json:"distance"`
+	Duration float64 `json:"duration"`
+}
+
+type osrmResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		Distance float64   `json:"distance"`
+		Duration float64   `json:"duration"`
+		Legs     []osrmLeg `json:"legs"`
+	} `json:"routes"`
+	Durations [][]*float64 `json:"durations"`
+	Distances [][]*float64 `json:"distances"`
+}
+
+func (r *OsrmRouter) Route(ctx context.Context, points []models.Location) (*models.Route, error) {
+	if len(points) < 2 {
+		return &models.Route{Legs: []models.RouteLeg{}}, nil
+	}
+
+	var body osrmResponse
+	if err := r.get(ctx, "route", points, "overview=false", &body); err != nil {
+		return nil, err
+	}
+	if len(body.Routes) == 0 {
+		return nil, models.ErrNoRoute
+	}
+
+	best := body.Routes[0]
+	route := &models.Route{
+		DistanceMeters: best.Distance,
+		DurationMs:     secondsToMs(best.Duration),
+		Legs:           make([]models.RouteLeg, len(best.Legs)),
+	}
+	for i, leg := range best.Legs {
+		route.Legs[i] = models.RouteLeg{DistanceMeters: leg.Distance, DurationMs: secondsToMs(leg.Duration)}
+	}
+	return route, nil
+}
+
+func (r *OsrmRouter) Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error) {
+	// OSRM needs two coordinates; a single point is zero away from itself.
+	if len(points) < 2 {
+		matrix := make([][]models.RouteLeg, len(points))
+		for i := range matrix {
+			matrix[i] = make([]models.RouteLeg, len(points))
+		}
+		return matrix, nil
+	}
+
+	var body osrmResponse
+	if err := r.get(ctx, "table", points, "annotations=duration,distance", &body); err != nil {
+		return nil, err
+	}
+	if len(body.Durations) != len(points) || len(body.Distances) != len(points) {
+		return nil, fmt.Errorf("%w: table of %d points for %d", models.ErrRoutingUnavailable, len(body.Durations), len(points))
+	}
+
+	matrix := make([][]models.RouteLeg, len(points))
+	for i := range points {
+		matrix[i] = make([]models.RouteLeg, len(points))
+		for j := range points {
+			// Unreachable pairs come back as null.
+			if body.Durations[i][j] == nil || body.Distances[i][j] == nil {
+				return nil, models.ErrNoRoute
+			}
+			matrix[i][j] = models.RouteLeg{DistanceMeters: *body.Distances[i][j], DurationMs: secondsToMs(*body.Durations[i][j])}
+		}
+	}
+	return matrix, nil
+}
+
+// get calls the OSRM service through points and decodes its answer into
+// body, mapping the OSRM error codes.
+func (r *OsrmRouter) get(ctx context.Context, service string, points []models.Location, query string, body *osrmResponse) error {
+	coordinates := make([]string, len(points))
+	for i, point := range points {
+		coordinates[i] = strconv.FormatFloat(point.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(point.Latitude, 'f', -1, 64)
+	}
+	url := fmt.Sprintf("%s/%s/v1/%s/%s?%s", r.baseURL, service, r.profile, strings.Join(coordinates, ";"), query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrRoutingUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	// OSRM reports bad requests and unroutable points with a code in the
+	// body; anything without one is an unavailable server.
+	if err := json.NewDecoder(resp.Body).Decode(body); err != nil || body.Code == "" {
+		return fmt.Errorf("%w: status %d", models.ErrRoutingUnavailable, resp.StatusCode)
+	}
+	switch body.Code {
+	case "Ok":
+		return nil
+	case "NoRoute", "NoSegment", "NoTable":
+		return models.ErrNoRoute
+	}
+	return fmt.Errorf("%w: %s %s", models.ErrRoutingUnavailable, body.Code, body.Message)
+}
+
+func secondsToMs(seconds float64) int64 {
+	return int64(math.Round(seconds * 1000))
+}
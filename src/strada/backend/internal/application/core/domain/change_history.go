@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	EntityRide        = "ride"
+	EntityRideRequest = "ride_request"
+
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+type FieldChange struct {
+	From any
+	To   any
+}
+
+// ChangeRecord is one entry of the audit trail kept for rides and ride
+// requests: who did what to which entity, and the fields that changed.
+type ChangeRecord struct {
+	ID         int64
+	EntityType string
+	EntityID   int32
+	Action     string
+	ChangedBy  string
+	Changes    map[string]FieldChange
+	CreatedAt  time.Time
+}
@@ -0,0 +1,94 @@
+package models
+
+import "strings"
+
+// Fuel types accepted in tb_vehicles.fuel_type.
+const (
+	FuelGasoline = "gasoline"
+	FuelEthanol  = "ethanol"
+	FuelFlex     = "flex"
+	FuelDiesel   = "diesel"
+	FuelCNG      = "cng"
+	FuelHybrid   = "hybrid"
+	FuelElectric = "electric"
+)
+
+// DefaultFuelType is assumed for rides whose vehicle is unknown, and for the
+// car a passenger would otherwise have driven alone.
+const DefaultFuelType = FuelGasoline
+
+// FuelFactor is what burning one unit of a fuel emits and how many units an
+// average car burns per 100 km. Units are liters, m³ for CNG and kWh for
+// electric vehicles, whose emissions come from the Brazilian grid.
+type FuelFactor struct {
+	KgCo2PerUnit  float64
+	UnitsPer100Km float64
+}
+
+var FuelFactors = map[string]FuelFactor{
+	FuelGasoline: {KgCo2PerUnit: 2.31, UnitsPer100Km: 8},
+	// Flex cars are assumed to run on gasoline, the worse case.
+	FuelFlex:     {KgCo2PerUnit: 2.31, UnitsPer100Km: 8},
+	FuelEthanol:  {KgCo2PerUnit: 1.51, UnitsPer100Km: 11},
+	FuelDiesel:   {KgCo2PerUnit: 2.68, UnitsPer100Km: 7.5},
+	FuelCNG:      {KgCo2PerUnit: 1.99, UnitsPer100Km: 9},
+	FuelHybrid:   {KgCo2PerUnit: 2.31, UnitsPer100Km: 4.5},
+	FuelElectric: {KgCo2PerUnit: 0.0385, UnitsPer100Km: 17},
+}
+
+// KgPerKm returns the emission per kilometre.
+func (f FuelFactor) KgPerKm() float64 {
+	return f.KgCo2PerUnit * f.UnitsPer100Km / 100
+}
+
+type Vehicle struct {
+	ID           int32
+	Make         string
+	Model        string
+	Year         int32
+	LicensePlate string
+	FuelType     string
+	// FuelConsumption overrides the fuel's average units per 100 km when set.
+	FuelConsumption float64
+}
+
+// NormalizeFuelType folds the case and spacing of a stored fuel type, so
+// "Diesel " counts as FuelDiesel.
+func NormalizeFuelType(fuelType string) string {
+	return strings.ToLower(strings.TrimSpace(fuelType))
+}
+
+// KnownFuelType reports whether the vehicle's fuel type has a factor of its
+// own instead of falling back to DefaultFuelType.
+func (v *Vehicle) KnownFuelType() bool {
+	_, ok := FuelFactors[NormalizeFuelType(v.FuelType)]
+	return ok
+}
+
+// Factor returns the emission factor of the vehicle, falling back to
+// DefaultFuelType for unknown fuels.
+func (v *Vehicle) Factor() FuelFactor {
+	factor, ok := FuelFactors[NormalizeFuelType(v.FuelType)]
+	if !ok {
+		factor = FuelFactors[DefaultFuelType]
+	}
+	if v.FuelConsumption > 0 {
+		factor.UnitsPer100Km = v.FuelConsumption
+	}
+	return factor
+}
+
+// RideEmission is what a ride's vehicle emits and what its passengers save
+// by sharing it instead of driving alone.
+type RideEmission struct {
+	RideID     int32
+	EmissionKg float64
+	SavedKg    float64
+	Passengers []*PassengerEmission
+}
+
+type PassengerEmission struct {
+	UserID         string
+	DistanceMeters float64
+	SavedKg        float64
+}
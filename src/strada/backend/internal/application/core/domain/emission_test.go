@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestVehicleFactor(t *testing.T) {
+	tests := []struct {
+		fuelType string
+		want     FuelFactor
+		known    bool
+	}{
+		{"diesel", FuelFactors[FuelDiesel], true},
+		{"Diesel", FuelFactors[FuelDiesel], true},
+		{" ELECTRIC\n", FuelFactors[FuelElectric], true},
+		{"hydrogen", FuelFactors[DefaultFuelType], false},
+		{"", FuelFactors[DefaultFuelType], false},
+	}
+	for _, tt := range tests {
+		vehicle := &Vehicle{FuelType: tt.fuelType}
+		if got := vehicle.Factor(); got != tt.want {
+			t.Errorf("Factor(%q) = %+v, want %+v", tt.fuelType, got, tt.want)
+		}
+		if got := vehicle.KnownFuelType(); got != tt.known {
+			t.Errorf("KnownFuelType(%q) = %v, want %v", tt.fuelType, got, tt.known)
+		}
+	}
+
+	vehicle := &Vehicle{FuelType: "Ethanol", FuelConsumption: 9}
+	if got := vehicle.Factor(); got.KgCo2PerUnit != FuelFactors[FuelEthanol].KgCo2PerUnit || got.UnitsPer100Km != 9 {
+		t.Errorf("Factor with consumption = %+v", got)
+	}
+}
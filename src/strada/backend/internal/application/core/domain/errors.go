@@ -0,0 +1,44 @@
+package models
+
+import "errors"
+
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrUserServiceUnavailable = errors.New("user service unavailable")
+	ErrRideNotFound           = errors.New("ride not found")
+	ErrRideRequestNotFound    = errors.New("ride request not found")
+	ErrGuardianNotFound       = errors.New("guardian relation not found")
+	ErrUnauthenticated        = errors.New("authentication required")
+	ErrForbidden              = errors.New("operation not allowed for the authenticated user")
+	ErrUnknownEntityType      = errors.New("unknown entity type")
+	ErrInvalidCursor          = errors.New("invalid pagination cursor")
+	ErrInvalidSort            = errors.New("invalid sort field")
+	ErrRideCompleted          = errors.New("ride already completed")
+	ErrInvalidPeriod          = errors.New("invalid period, expected week, month, year or all")
+	ErrInvalidRange           = errors.New("invalid range, from must come before to")
+	ErrNoSchool               = errors.New("no confirmed school membership in the user preferences")
+	ErrVehicleNotFound        = errors.New("vehicle not found")
+	ErrPassengerNotFound      = errors.New("passenger not found in ride")
+	ErrAlreadyJoined          = errors.New("user already joined the ride")
+	ErrDriverCannotJoin       = errors.New("the driver cannot join their own ride as a passenger")
+	ErrCurrencyMismatch       = errors.New("amounts in different currencies")
+	ErrVersionConflict        = errors.New("modified by another request, reload and retry")
+	ErrPaymentNotFound        = errors.New("payment not found")
+	ErrPaymentExists          = errors.New("payment already in progress or settled")
+	ErrRideNotCompleted       = errors.New("ride not completed yet")
+	ErrNothingToCharge        = errors.New("passenger has no fare to pay")
+	ErrNotRefundable          = errors.New("only succeeded charges can be refunded")
+	ErrRefundExceedsCharge    = errors.New("refund exceeds what is left of the charge")
+	ErrUnknownPaymentProvider = errors.New("unknown payment provider")
+	ErrInvalidSignature       = errors.New("invalid callback signature")
+	ErrPaymentProviderFailed  = errors.New("payment provider failed")
+	ErrRoutingUnavailable     = errors.New("routing service unavailable")
+	ErrNoRoute                = errors.New("no road route through the ride points")
+	ErrInvalidDetourBudget    = errors.New("max detour cannot be negative")
+	ErrInvalidRecurrence      = errors.New("invalid recurrence, expected weekdays, a departure time, a time zone and a start date before the end date")
+	ErrSeriesNotFound         = errors.New("series not found")
+	ErrSeriesMismatch         = errors.New("series share no weekday or depart too far apart")
+	ErrSchoolNotFound         = errors.New("school not found")
+	ErrInvalidSchool          = errors.New("invalid school, expected a name, a campus with at least 3 points, a time zone and classes that end after they start")
+	ErrOutsideCampus          = errors.New("drop-off is outside the school campus")
+)
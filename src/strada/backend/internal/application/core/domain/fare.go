@@ -0,0 +1,18 @@
+package models
+
+// FareSplit divides the cost of a ride among its passengers.
+type FareSplit struct {
+	RideID int32
+	Total  Money
+	// Ceiling is the most the driver may collect; zero means no ceiling.
+	Ceiling Money
+	// Capped reports that the shares were scaled down to the ceiling.
+	Capped bool
+	Shares []*PassengerFare
+}
+
+type PassengerFare struct {
+	UserID         string
+	DistanceMeters float64
+	Fare           Money
+}
@@ -0,0 +1,52 @@
+package models
+
+import "math"
+
+const EarthRadiusMeters = 6371008.8
+
+// Near searches match items within NearRadiusMeters of any point of the
+// searched route and return at most NearMaxResults of them.
+const (
+	NearRadiusMeters = 1000
+	NearMaxResults   = 100
+)
+
+// HaversineMeters returns the great-circle distance between two locations.
+func HaversineMeters(a, b Location) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// PathMeters returns the length of the path through points, in order.
+func PathMeters(points ...Location) float64 {
+	var total float64
+	for i := 1; i < len(points); i++ {
+		total += HaversineMeters(points[i-1], points[i])
+	}
+	return total
+}
+
+// RouteMeters returns the ride distance, or the straight-line path through
+// its waypoints when the distance is unknown.
+func (r *Ride) RouteMeters() float64 {
+	if r.DistanceMeters > 0 {
+		return r.DistanceMeters
+	}
+	return PathMeters(r.Waypoints()...)
+}
+
+// Waypoints returns the start, the stops and the end of the ride, in order.
+// The end is not repeated when it already is the last stop.
+func (r *Ride) Waypoints() []Location {
+	points := append([]Location{r.StartPoint}, r.StopPoints...)
+	if len(r.StopPoints) == 0 || r.StopPoints[len(r.StopPoints)-1] != r.EndPoint {
+		points = append(points, r.EndPoint)
+	}
+	return points
+}
@@ -0,0 +1,53 @@
+package models
+
+// Kinds of stops on an itinerary.
+const (
+	RideStopStart    = "start"
+	RideStopWaypoint = "waypoint"
+	RideStopPickup   = "pickup"
+	RideStopDropoff  = "dropoff"
+	RideStopEnd      = "end"
+)
+
+type RideStop struct {
+	Kind string
+	// UserID is the passenger picked up or dropped off.
+	UserID   string
+	Location Location
+	// DistanceMeters and EtaMs are counted from the start of the ride.
+	DistanceMeters float64
+	EtaMs          int64
+}
+
+// Itinerary is the order the driver visits the ride's stops and the
+// passengers' pickups and dropoffs in, from the start to the end.
+type Itinerary struct {
+	RideID         int32
+	Stops          []*RideStop
+	DistanceMeters float64
+	DurationMs     int64
+}
+
+// Detour is what picking up one more passenger adds to a ride's route.
+type Detour struct {
+	DistanceMeters float64
+	DurationMs     int64
+	// WithinBudget is false when the detour exceeds the ride's
+	// MaxDetourMeters or MaxDetourMs.
+	WithinBudget bool
+}
+
+// NewDetour compares the detour against the ride's budget.
+func NewDetour(ride *Ride, distanceMeters float64, durationMs int64) *Detour {
+	return &Detour{
+		DistanceMeters: distanceMeters,
+		DurationMs:     durationMs,
+		WithinBudget: (ride.MaxDetourMeters == 0 || distanceMeters <= ride.MaxDetourMeters) &&
+			(ride.MaxDetourMs == 0 || durationMs <= ride.MaxDetourMs),
+	}
+}
+
+// HasDetourBudget reports whether the driver limited the detour.
+func (r *Ride) HasDetourBudget() bool {
+	return r.MaxDetourMeters > 0 || r.MaxDetourMs > 0
+}
@@ -11,19 +11,38 @@ type Ride struct {
 	ID              int32
 	StartPoint      Location
 	EndPoint        Location
-	Distance        int32
+	DistanceMeters  float64
 	EstimatedTimeMs int32
-	Co2Emission     int32
-	Cost            int32
+	Co2EmissionKg   float64
+	Cost            Money
+	CostCeiling     Money
+	// MaxDetourMeters and MaxDetourMs bound what picking up one more
+	// passenger may add to the route; 0 means no limit.
+	MaxDetourMeters float64
+	MaxDetourMs     int64
+	Co2SavedKg      float64
 	Description     string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	CompletedAt     time.Time
 	StopPoints      []Location
 	ImgUrl          string
 	DriverID        string
 	VehicleID       int32
+	Version         int32
+	// SchoolID is the school the ride goes to, 0 for none.
+	SchoolID int32
+	// DetourUnchecked is set on near search results whose detour for the
+	// ride request could not be routed or checked in time.
+	DetourUnchecked bool
 }
 
+// Roles a user can hold on a ride, see RidePassenger.Role.
+const (
+	RideRoleDriver    = "driver"
+	RideRolePassenger = "passenger"
+)
+
 type RidePassenger struct {
 	RideID     int32
 	UserID     string
@@ -31,6 +50,10 @@ type RidePassenger struct {
 	StartPoint Location
 	EndPoint   Location
 	Role       string
+	// DistanceMeters and Fare are maintained by the pricing service.
+	DistanceMeters float64
+	Fare           Money
+	Co2SavedKg     float64
 }
 
 type RideRequest struct {
@@ -43,6 +66,11 @@ type RideRequest struct {
 	DriveOfferID int32
 	ImgUrl       string
 	Status       string
+	Version      int32
+	// SchoolID is the school the passenger goes to, 0 for none.
+	SchoolID int32
+	// Detour is set by the near search for the searched ride.
+	Detour *Detour
 }
 
 type TbDriverOffer struct {
@@ -64,3 +92,28 @@ type User struct {
 	Username string
 	ImgUrl   string
 }
+
+type GuardianRelation struct {
+	GuardianID      string
+	MinorID         string
+	Relationship    string
+	CanRequestRides bool
+	CanAcceptRides  bool
+}
+
+const (
+	RideEventCreated        = "RIDE_CREATED"
+	RideEventUpdated        = "RIDE_UPDATED"
+	RideEventDeleted        = "RIDE_DELETED"
+	RideRequestEventCreated = "RIDE_REQUEST_CREATED"
+	RideRequestEventUpdated = "RIDE_REQUEST_UPDATED"
+	RideRequestEventDeleted = "RIDE_REQUEST_DELETED"
+)
+
+type RideEvent struct {
+	Type          string
+	RideID        int32
+	RideRequestID int32
+	UserID        string
+	OccurredAt    time.Time
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultCurrency = "BRL"
+
+	// MoneyScale is the number of minor-unit digits kept for every amount.
+	MoneyScale = 2
+	minorUnits = 100
+)
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an amount in hundredths (centavos for BRL) of Currency, an ISO
+// 4217 code. The scale is MoneyScale whatever the currency's own exponent, so
+// amounts in currencies with three decimals are limited to two. Amounts are
+// never stored as floats to avoid rounding drift.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney reads a decimal amount such as "15", "15.5" or "15.50". A comma
+// is accepted as the decimal separator.
+func ParseMoney(amount string, currency string) (Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return Money{}, fmt.Errorf("%w: unknown currency %q", ErrInvalidMoney, currency)
+	}
+
+	amount = strings.ReplaceAll(strings.TrimSpace(amount), ",", ".")
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if !isDigits(whole) || (fraction != "" && !isDigits(fraction)) || len(fraction) > MoneyScale {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+	fraction += strings.Repeat("0", MoneyScale-len(fraction))
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/minorUnits-1 {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+	cents, _ := strconv.ParseInt(fraction, 10, 64)
+
+	total := units*minorUnits + cents
+	if negative {
+		total = -total
+	}
+	return Money{Amount: total, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// String formats the amount with MoneyScale decimals, without the currency.
+func (m Money) String() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorUnits, amount%minorUnits)
+}
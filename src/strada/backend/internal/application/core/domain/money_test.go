@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     Money
+	}{
+		{"15", "BRL", Money{Amount: 1500, Currency: "BRL"}},
+		{"15.5", "BRL", Money{Amount: 1550, Currency: "BRL"}},
+		{"15.50", "BRL", Money{Amount: 1550, Currency: "BRL"}},
+		{"15,05", "BRL", Money{Amount: 1505, Currency: "BRL"}},
+		{"0.01", "BRL", Money{Amount: 1, Currency: "BRL"}},
+		{"15.", "BRL", Money{Amount: 1500, Currency: "BRL"}},
+		{" 007.10 ", "BRL", Money{Amount: 710, Currency: "BRL"}},
+		{"-0.50", "BRL", Money{Amount: -50, Currency: "BRL"}},
+		{"-12.34", "BRL", Money{Amount: -1234, Currency: "BRL"}},
+		{"-0", "BRL", Money{Amount: 0, Currency: "BRL"}},
+		{"1", "", Money{Amount: 100, Currency: DefaultCurrency}},
+		{"1", " usd ", Money{Amount: 100, Currency: "USD"}},
+		// The scale is always MoneyScale, also for currencies without
+		// decimals.
+		{"100", "JPY", Money{Amount: 10000, Currency: "JPY"}},
+		{"92233720368547757.99", "BRL", Money{Amount: 9223372036854775799, Currency: "BRL"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseMoney(tt.amount, tt.currency)
+			if err != nil {
+				t.Fatalf("ParseMoney: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoneyRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+	}{
+		{"excess scale", "1.005", "BRL"},
+		{"excess scale of a three decimal currency", "1.005", "BHD"},
+		{"empty", "", "BRL"},
+		{"missing whole part", ".5", "BRL"},
+		{"double sign", "--1", "BRL"},
+		{"plus sign", "+1", "BRL"},
+		{"exponent", "1e3", "BRL"},
+		{"thousands separator", "1.000,00", "BRL"},
+		{"space after sign", "- 1", "BRL"},
+		{"overflow", "92233720368547758", "BRL"},
+		{"currency too long", "1", "BRLL"},
+		{"currency not letters", "1", "B1L"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ParseMoney(tt.amount, tt.currency); !errors.Is(err, ErrInvalidMoney) {
+				t.Errorf("ParseMoney(%q, %q) = %+v, %v, want ErrInvalidMoney", tt.amount, tt.currency, got, err)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{1550, "15.50"},
+		{-5, "-0.05"},
+		{-1234, "-12.34"},
+	}
+	for _, tt := range tests {
+		if got := NewMoney(tt.amount, "").String(); got != tt.want {
+			t.Errorf("String(%d) = %q, want %q", tt.amount, got, tt.want)
+		}
+		parsed, err := ParseMoney(tt.want, "")
+		if err != nil || parsed.Amount != tt.amount {
+			t.Errorf("ParseMoney(%q) = %+v, %v, want %d", tt.want, parsed, err, tt.amount)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	RideSortCreatedAt = "createdAt"
+	RideSortCost      = "cost"
+	RideSortDistance  = "distance"
+
+	RideRequestSortRideDatetime = "rideDatetime"
+	RideRequestSortID           = "id"
+)
+
+type BoundingBox struct {
+	MinLongitude float64
+	MinLatitude  float64
+	MaxLongitude float64
+	MaxLatitude  float64
+}
+
+// Cursor marks the last item of a page in keyset pagination: the value of
+// the sort key and the id breaking ties. It is only valid for the sort it
+// was issued with.
+type Cursor struct {
+	SortBy     string
+	Descending bool
+	Value      string
+	ID         int32
+}
+
+type cursorJson struct {
+	SortBy     string `json:"s"`
+	Descending bool   `json:"d,omitempty"`
+	Value      string `json:"v,omitempty"`
+	ID         int32  `json:"i"`
+}
+
+// Encode returns the opaque token handed to clients.
+func (c *Cursor) Encode() string {
+	raw, _ := json.Marshal(cursorJson(*c))
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursorJson
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	cursor := Cursor(c)
+	return &cursor, nil
+}
+
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+type RideFilter struct {
+	DriverID    string
+	PassengerID string
+	From        time.Time
+	To          time.Time
+	BoundingBox *BoundingBox
+	SortBy      string
+	Descending  bool
+	Cursor      string
+	Limit       int
+}
+
+type RideRequestFilter struct {
+	PassengerID string
+	Status      string
+	From        time.Time
+	To          time.Time
+	BoundingBox *BoundingBox
+	SortBy      string
+	Descending  bool
+	Cursor      string
+	Limit       int
+}
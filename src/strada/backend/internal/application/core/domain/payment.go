@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Kinds of ledger entries.
+const (
+	PaymentKindCharge = "charge"
+	PaymentKindPayout = "payout"
+	PaymentKindRefund = "refund"
+)
+
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+)
+
+// Payment is one entry of a ride's ledger, always about one passenger:
+// charges collect the passenger's fare, payouts pass a charge on to the
+// driver and refunds return part of a charge to the passenger.
+type Payment struct {
+	ID          int32
+	RideID      int32
+	PassengerID string
+	DriverID    string
+	Kind        string
+	Amount      Money
+	Status      string
+	// ParentID is the charge a payout or refund settles.
+	ParentID      int32
+	Provider      string
+	ProviderRef   string
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (p *Payment) Pending() bool {
+	return p.Status == PaymentStatusPending
+}
+
+// PaymentUpdate is what a provider reports about a payment, either when it
+// is submitted or later through a callback.
+type PaymentUpdate struct {
+	ProviderRef   string
+	Status        string
+	FailureReason string
+}
+
+// PaymentEvent is a provider callback. EventID is unique per provider, so
+// deliveries of the same event are only applied once.
+type PaymentEvent struct {
+	Provider string
+	EventID  string
+	PaymentUpdate
+}
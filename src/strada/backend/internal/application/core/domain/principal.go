@@ -0,0 +1,60 @@
+package models
+
+import "context"
+
+const RoleAdmin = "admin"
+
+const (
+	ScopeRidesRead  = "rides:read"
+	ScopeRidesWrite = "rides:write"
+	// ScopeRideParticipantsRead exposes pickup and dropoff locations.
+	ScopeRideParticipantsRead = "rides:participants:read"
+	ScopeRideEventsRead       = "ride-events:read"
+	// ScopeRideEventsReadAll allows streaming events without a ride or user
+	// filter.
+	ScopeRideEventsReadAll = "ride-events:read:all"
+)
+
+// Principal is the authenticated caller of a request, as asserted by the
+// access token. Service principals carry a ClientID and scopes instead of a
+// user identity.
+type Principal struct {
+	UserID   string
+	Email    string
+	Roles    []string
+	ClientID string
+	Scopes   []string
+}
+
+func (p *Principal) IsService() bool {
+	return p.ClientID != ""
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
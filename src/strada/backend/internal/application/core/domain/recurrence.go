@@ -0,0 +1,138 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// DateLayout is how recurrence dates are written in the API.
+const DateLayout = "2006-01-02"
+
+// RecurrenceMatchWindow is how far apart the departure times of a ride series
+// and a ride request series may be for them to match.
+const RecurrenceMatchWindow = 30 * time.Minute
+
+// OccurrenceClaimTimeout is how long a claimed occurrence may go without its
+// ride or ride request before another materialization takes it over.
+const OccurrenceClaimTimeout = 10 * time.Minute
+
+// Recurrence repeats a ride or ride request on some weekdays at the same
+// local time.
+type Recurrence struct {
+	Weekdays []time.Weekday
+	// DepartureMinutes is the local departure time in minutes after midnight.
+	DepartureMinutes int
+	// TimeZone is an IANA name, such as America/Sao_Paulo.
+	TimeZone string
+	// StartDate, EndDate and Exceptions are days at midnight UTC. A zero
+	// EndDate repeats without end; Exceptions are skipped, such as holidays.
+	StartDate  time.Time
+	EndDate    time.Time
+	Exceptions []time.Time
+}
+
+func (r *Recurrence) Validate() error {
+	if len(r.Weekdays) == 0 || r.DepartureMinutes < 0 || r.DepartureMinutes >= 24*60 || r.StartDate.IsZero() {
+		return ErrInvalidRecurrence
+	}
+	for _, weekday := range r.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return ErrInvalidRecurrence
+		}
+	}
+	if !r.EndDate.IsZero() && r.EndDate.Before(r.StartDate) {
+		return ErrInvalidRecurrence
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil || r.TimeZone == "" {
+		return ErrInvalidRecurrence
+	}
+	return nil
+}
+
+// Occurrences returns the departures in [from, to), in UTC.
+func (r *Recurrence) Occurrences(from time.Time, to time.Time) ([]time.Time, error) {
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return nil, ErrInvalidRecurrence
+	}
+
+	// Days are walked in local time.
+	day, last := Date(from.In(location)), Date(to.In(location))
+	if day.Before(r.StartDate) {
+		day = r.StartDate
+	}
+	var departures []time.Time
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !r.EndDate.IsZero() && day.After(r.EndDate) {
+			break
+		}
+		if !slices.Contains(r.Weekdays, day.Weekday()) || slices.ContainsFunc(r.Exceptions, day.Equal) {
+			continue
+		}
+		departure := time.Date(day.Year(), day.Month(), day.Day(), r.DepartureMinutes/60, r.DepartureMinutes%60, 0, 0, location).UTC()
+		if !departure.Before(from) && departure.Before(to) {
+			departures = append(departures, departure)
+		}
+	}
+	return departures, nil
+}
+
+// Date truncates t to its calendar day, at midnight UTC.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// RideSeries creates a ride for each occurrence of its recurrence.
+type RideSeries struct {
+	ID       int32
+	DriverID string
+	// Ride is the template of the occurrences; only the route, vehicle,
+	// description, image, cost ceiling, detour budget and school are used.
+	Ride       *Ride
+	Recurrence Recurrence
+	// Occurrences departing before MaterializedUntil were already created.
+	MaterializedUntil time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// Upcoming is set when the series is looked up.
+	Upcoming []*Occurrence
+}
+
+// RideRequestSeries creates a ride request for each occurrence of its
+// recurrence and, once matched with a ride series, joins the ride of the same
+// day.
+type RideRequestSeries struct {
+	ID          int32
+	PassengerID string
+	// RideRequest is the template of the occurrences; only the origin,
+	// destination, description, image and school are used.
+	RideRequest *RideRequest
+	Recurrence  Recurrence
+	// RideSeriesID is the matched ride series, 0 for none.
+	RideSeriesID      int32
+	MaterializedUntil time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Upcoming          []*Occurrence
+}
+
+// Occurrence is a ride or ride request created by a series.
+type Occurrence struct {
+	SeriesID  int32
+	DepartsAt time.Time
+	// RideID is the ride created for a ride series, or the ride joined by a
+	// ride request occurrence, 0 while unmatched.
+	RideID        int32
+	RideRequestID int32
+}
+
+// SeriesMatch is the outcome of matching a ride request series with a ride
+// series.
+type SeriesMatch struct {
+	RideSeriesID        int32
+	RideRequestSeriesID int32
+	// Paired are the upcoming ride request occurrences and the ride each one
+	// joined; Unpaired have no ride on the same day or could not join it.
+	Paired   []*Occurrence
+	Unpaired []*Occurrence
+}
@@ -0,0 +1,14 @@
+package models
+
+// Route is the road path through a list of points; Legs[i] goes from point
+// i to point i+1.
+type Route struct {
+	DistanceMeters float64
+	DurationMs     int64
+	Legs           []RouteLeg
+}
+
+type RouteLeg struct {
+	DistanceMeters float64
+	DurationMs     int64
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SchoolGateRadiusMeters is how far from an entry gate a drop-off outside
+// the campus is still accepted, since gates usually open onto the street.
+const SchoolGateRadiusMeters = 100
+
+// School is a destination rides and ride requests can target, so everyone
+// going there can be matched whatever their exact drop-off.
+type School struct {
+	ID      int32
+	Name    string
+	Address string
+	// Campus is the polygon around the school, without repeating the first
+	// point at the end.
+	Campus []Location
+	Gates  []Location
+	// TimeZone is the IANA name ClassTimes are in.
+	TimeZone   string
+	ClassTimes []ClassTime
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ClassTime is a class period, in minutes after midnight in the school's
+// time zone.
+type ClassTime struct {
+	StartMinutes int
+	EndMinutes   int
+}
+
+func (s *School) Validate() error {
+	if strings.TrimSpace(s.Name) == "" || len(s.Campus) < 3 {
+		return ErrInvalidSchool
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil || s.TimeZone == "" {
+		return ErrInvalidSchool
+	}
+	for _, class := range s.ClassTimes {
+		if class.StartMinutes < 0 || class.StartMinutes >= class.EndMinutes || class.EndMinutes > 24*60 {
+			return ErrInvalidSchool
+		}
+	}
+	return nil
+}
+
+// Covers reports whether loc is a valid drop-off: on the campus or within
+// SchoolGateRadiusMeters of a gate.
+func (s *School) Covers(loc Location) bool {
+	for _, gate := range s.Gates {
+		if HaversineMeters(gate, loc) <= SchoolGateRadiusMeters {
+			return true
+		}
+	}
+	return insidePolygon(s.Campus, loc)
+}
+
+// insidePolygon casts a ray along the latitude of loc and counts the edges it
+// crosses. Campuses are small enough to treat coordinates as planar.
+func insidePolygon(ring []Location, loc Location) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > loc.Latitude) != (b.Latitude > loc.Latitude) &&
+			loc.Longitude < (b.Longitude-a.Longitude)*(loc.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
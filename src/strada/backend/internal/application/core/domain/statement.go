@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// StatementAmounts sums the ledger of one passenger, one ride or a whole
+// statement, in a single currency.
+type StatementAmounts struct {
+	// Fares is what the passengers owe for the rides.
+	Fares Money
+	// Paid and Refunded count succeeded charges and refunds; Contributed is
+	// what is left to the driver, Paid minus Refunded.
+	Paid        Money
+	Refunded    Money
+	Contributed Money
+	// PaidOut counts succeeded payouts to the driver.
+	PaidOut Money
+	// Outstanding is the part of the fares not paid yet.
+	Outstanding Money
+}
+
+func NewStatementAmounts(currency string) StatementAmounts {
+	zero := NewMoney(0, currency)
+	return StatementAmounts{
+		Fares:       zero,
+		Paid:        zero,
+		Refunded:    zero,
+		Contributed: zero,
+		PaidOut:     zero,
+		Outstanding: zero,
+	}
+}
+
+// Add sums b into a; both must be in the same currency.
+func (a *StatementAmounts) Add(b StatementAmounts) {
+	a.Fares.Amount += b.Fares.Amount
+	a.Paid.Amount += b.Paid.Amount
+	a.Refunded.Amount += b.Refunded.Amount
+	a.Contributed.Amount += b.Contributed.Amount
+	a.PaidOut.Amount += b.PaidOut.Amount
+	a.Outstanding.Amount += b.Outstanding.Amount
+}
+
+type StatementLine struct {
+	PassengerID    string
+	DistanceMeters float64
+	StatementAmounts
+}
+
+type StatementRide struct {
+	RideID      int32
+	CompletedAt time.Time
+	Passengers  []*StatementLine
+	Totals      StatementAmounts
+}
+
+// Statement lists what each passenger contributed to the rides a driver
+// completed in [From, To).
+type Statement struct {
+	DriverID string
+	From     time.Time
+	To       time.Time
+	Rides    []*StatementRide
+	// Totals has one entry per currency, in currency order.
+	Totals []*StatementAmounts
+}
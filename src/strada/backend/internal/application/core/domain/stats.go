@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// Periods accepted by the statistics endpoints. Each one is a rolling window
+// ending now.
+const (
+	StatsPeriodWeek  = "week"
+	StatsPeriodMonth = "month"
+	StatsPeriodYear  = "year"
+	StatsPeriodAll   = "all"
+)
+
+// StatsWindow returns the start of period counted back from now; zero for
+// StatsPeriodAll.
+func StatsWindow(period string, now time.Time) (time.Time, error) {
+	switch period {
+	case StatsPeriodWeek:
+		return now.AddDate(0, 0, -7), nil
+	case StatsPeriodMonth:
+		return now.AddDate(0, -1, 0), nil
+	case StatsPeriodYear:
+		return now.AddDate(-1, 0, 0), nil
+	case StatsPeriodAll:
+		return time.Time{}, nil
+	}
+	return time.Time{}, ErrInvalidPeriod
+}
+
+// UserStats sums up a user's completed rides, as a driver and as a
+// passenger.
+type UserStats struct {
+	UserID string
+	Period string
+	From   time.Time
+	To     time.Time
+
+	TripsTaken   int
+	TripsOffered int
+	// DistanceSharedMeters counts the passenger's own distance on rides
+	// taken and the whole ride on rides offered with at least one passenger.
+	DistanceSharedMeters float64
+	// Co2SavedKg is what the user saved as a passenger plus what their
+	// passengers saved on rides they drove.
+	Co2SavedKg float64
+	// MoneySaved is what driving alone would have cost minus the fares paid,
+	// plus the fares collected as a driver.
+	MoneySaved Money
+}
+
+type LeaderboardEntry struct {
+	Rank  int
+	Stats *UserStats
+}
+
+type Leaderboard struct {
+	SchoolID int32
+	Period   string
+	Entries  []*LeaderboardEntry
+}
+
+// UserPreferences are kept by this service, the user service knows nothing
+// about schools.
+type UserPreferences struct {
+	UserID   string
+	SchoolID int32
+	// SchoolVerifiedAt is when an admin confirmed the user belongs to the
+	// school, zero until then. Changing the school clears it.
+	SchoolVerifiedAt time.Time
+	// Minor is recorded by the admin confirming the membership.
+	Minor bool
+	// LeaderboardOptOut hides an adult from the leaderboard, while a minor
+	// only shows up with LeaderboardOptIn.
+	LeaderboardOptOut bool
+	LeaderboardOptIn  bool
+	UpdatedAt         time.Time
+}
+
+// SchoolVerified reports whether the user is a confirmed member of their
+// school.
+func (p *UserPreferences) SchoolVerified() bool {
+	return p.SchoolID != 0 && !p.SchoolVerifiedAt.IsZero()
+}
+
+// OnLeaderboard reports whether the user is ranked on their school
+// leaderboard.
+func (p *UserPreferences) OnLeaderboard() bool {
+	if !p.SchoolVerified() {
+		return false
+	}
+	if p.Minor {
+		return p.LeaderboardOptIn
+	}
+	return !p.LeaderboardOptOut
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnLeaderboard(t *testing.T) {
+	verified := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		preferences UserPreferences
+		want        bool
+	}{
+		{"no school", UserPreferences{SchoolVerifiedAt: verified}, false},
+		{"unconfirmed adult", UserPreferences{SchoolID: 1}, false},
+		{"adult", UserPreferences{SchoolID: 1, SchoolVerifiedAt: verified}, true},
+		{"adult who opted out", UserPreferences{SchoolID: 1, SchoolVerifiedAt: verified, LeaderboardOptOut: true}, false},
+		{"minor", UserPreferences{SchoolID: 1, SchoolVerifiedAt: verified, Minor: true}, false},
+		{"minor who opted in", UserPreferences{SchoolID: 1, SchoolVerifiedAt: verified, Minor: true, LeaderboardOptIn: true}, true},
+		{"unconfirmed minor who opted in", UserPreferences{SchoolID: 1, Minor: true, LeaderboardOptIn: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.preferences.OnLeaderboard(); got != tt.want {
+				t.Errorf("OnLeaderboard = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+)
+
+type guardianPermission func(relation *models.GuardianRelation) bool
+
+func canRequestRides(relation *models.GuardianRelation) bool {
+	return relation.CanRequestRides
+}
+
+func canAcceptRides(relation *models.GuardianRelation) bool {
+	return relation.CanAcceptRides
+}
+
+// resolveActor returns the user an operation acts on behalf of. The
+// authenticated principal is always the default; another user may only be
+// named by an admin or by a guardian holding the given permission for them.
+func resolveActor(ctx context.Context, userService in.UserService, requestedId string, allowed guardianPermission) (string, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return "", models.ErrUnauthenticated
+	}
+
+	if requestedId == "" || requestedId == principal.UserID {
+		return principal.UserID, nil
+	}
+
+	if principal.HasRole(models.RoleAdmin) {
+		return requestedId, nil
+	}
+
+	relation, err := userService.FindGuardianRelation(ctx, principal.UserID, requestedId)
+	if errors.Is(err, models.ErrGuardianNotFound) {
+		return "", models.ErrForbidden
+	}
+	if err != nil {
+		return "", err
+	}
+	if !allowed(relation) {
+		return "", models.ErrForbidden
+	}
+
+	return requestedId, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"reflect"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+const systemActor = "system"
+
+type ChangeHistoryService struct {
+	changeHistoryRepository out.ChangeHistoryRepository
+}
+
+func NewChangeHistoryService(changeHistoryRepository out.ChangeHistoryRepository) in.ChangeHistoryService {
+	return &ChangeHistoryService{
+		changeHistoryRepository: changeHistoryRepository,
+	}
+}
+
+// Record stores a change made by the authenticated principal. Updates that
+// changed nothing are not recorded.
+func (s *ChangeHistoryService) Record(ctx context.Context, entityType string, entityId int32, action string, changes map[string]models.FieldChange) error {
+	if action == models.ChangeUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	changedBy := systemActor
+	if principal, ok := models.PrincipalFromContext(ctx); ok {
+		changedBy = principal.UserID
+		if principal.IsService() {
+			changedBy = principal.ClientID
+		}
+	}
+
+	_, err := s.changeHistoryRepository.Create(ctx, &models.ChangeRecord{
+		EntityType: entityType,
+		EntityID:   entityId,
+		Action:     action,
+		ChangedBy:  changedBy,
+		Changes:    changes,
+	})
+	return err
+}
+
+// FindByEntity returns the history of one ride or ride request. It is only
+// available to admins.
+func (s *ChangeHistoryService) FindByEntity(ctx context.Context, entityType string, entityId int32) ([]*models.ChangeRecord, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, models.ErrUnauthenticated
+	}
+	if !principal.HasRole(models.RoleAdmin) {
+		return nil, models.ErrForbidden
+	}
+	if entityType != models.EntityRide && entityType != models.EntityRideRequest {
+		return nil, models.ErrUnknownEntityType
+	}
+	return s.changeHistoryRepository.FindByEntity(ctx, entityType, entityId)
+}
+
+// recordChange is a no-op for services built without a history service.
+func recordChange(ctx context.Context, changeHistoryService in.ChangeHistoryService, entityType string, entityId int32, action string, changes map[string]models.FieldChange) error {
+	if changeHistoryService == nil {
+		return nil
+	}
+	return changeHistoryService.Record(ctx, entityType, entityId, action, changes)
+}
+
+type changeSet map[string]models.FieldChange
+
+func (c changeSet) add(field string, from any, to any) {
+	if !reflect.DeepEqual(from, to) {
+		c[field] = models.FieldChange{From: from, To: to}
+	}
+}
+
+func rideChanges(before *models.Ride, after *models.Ride) map[string]models.FieldChange {
+	changes := changeSet{}
+	changes.add("driverId", before.DriverID, after.DriverID)
+	changes.add("vehicleId", before.VehicleID, after.VehicleID)
+	changes.add("startPoint", before.StartPoint, after.StartPoint)
+	changes.add("endPoint", before.EndPoint, after.EndPoint)
+	changes.add("stopPoints", before.StopPoints, after.StopPoints)
+	changes.add("distanceMeters", before.DistanceMeters, after.DistanceMeters)
+	changes.add("estimatedTimeMs", before.EstimatedTimeMs, after.EstimatedTimeMs)
+	changes.add("co2EmissionKg", before.Co2EmissionKg, after.Co2EmissionKg)
+	changes.add("co2SavedKg", before.Co2SavedKg, after.Co2SavedKg)
+	changes.add("cost", before.Cost, after.Cost)
+	changes.add("costCeiling", before.CostCeiling, after.CostCeiling)
+	changes.add("maxDetourMeters", before.MaxDetourMeters, after.MaxDetourMeters)
+	changes.add("maxDetourMs", before.MaxDetourMs, after.MaxDetourMs)
+	changes.add("schoolId", before.SchoolID, after.SchoolID)
+	changes.add("description", before.Description, after.Description)
+	changes.add("imgUrl", before.ImgUrl, after.ImgUrl)
+	changes.add("completedAt", before.CompletedAt, after.CompletedAt)
+	return changes
+}
+
+func rideRequestChanges(before *models.RideRequest, after *models.RideRequest) map[string]models.FieldChange {
+	changes := changeSet{}
+	changes.add("origin", before.Origin, after.Origin)
+	changes.add("destination", before.Destination, after.Destination)
+	changes.add("schoolId", before.SchoolID, after.SchoolID)
+	changes.add("rideDatetime", before.RideDatetime.UTC(), after.RideDatetime.UTC())
+	changes.add("status", before.Status, after.Status)
+	changes.add("description", before.Description, after.Description)
+	changes.add("imgUrl", before.ImgUrl, after.ImgUrl)
+	return changes
+}
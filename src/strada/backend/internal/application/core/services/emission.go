@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"go.uber.org/zap"
+)
+
+type EmissionService struct {
+	vehicleRepository out.VehicleRepository
+}
+
+func NewEmissionService(vehicleRepository out.VehicleRepository) in.EmissionService {
+	return &EmissionService{
+		vehicleRepository: vehicleRepository,
+	}
+}
+
+// Estimate splits the vehicle's emission evenly among everyone on board
+// over the distance each passenger travels. A passenger saves what driving
+// that distance alone in a DefaultFuelType car would emit, minus their part
+// of the shared car's emission.
+func (s *EmissionService) Estimate(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger) (*models.RideEmission, error) {
+	factor, err := s.vehicleFactor(ctx, ride.VehicleID)
+	if err != nil {
+		return nil, err
+	}
+	distance := ride.RouteMeters()
+
+	var riders []*models.RidePassenger
+	for _, passenger := range passengers {
+		if passenger.RideID == ride.ID && passenger.Role == models.RideRolePassenger {
+			riders = append(riders, passenger)
+		}
+	}
+
+	emission := &models.RideEmission{
+		RideID:     ride.ID,
+		EmissionKg: roundKg(factor.KgPerKm() * distance / 1000),
+		Passengers: make([]*models.PassengerEmission, len(riders)),
+	}
+	alone := models.FuelFactors[models.DefaultFuelType].KgPerKm()
+	shared := factor.KgPerKm() / float64(len(riders)+1)
+	for i, passenger := range riders {
+		meters := passenger.DistanceMeters
+		if meters <= 0 {
+			meters = models.HaversineMeters(passenger.StartPoint, passenger.EndPoint)
+		}
+		saved := roundKg(math.Max(0, (alone-shared)*meters/1000))
+		emission.Passengers[i] = &models.PassengerEmission{
+			UserID:         passenger.UserID,
+			DistanceMeters: meters,
+			SavedKg:        saved,
+		}
+		emission.SavedKg += saved
+	}
+	emission.SavedKg = roundKg(emission.SavedKg)
+	return emission, nil
+}
+
+// vehicleFactor falls back to DefaultFuelType for rides without a known
+// vehicle, and logs vehicles whose fuel type has no factor.
+func (s *EmissionService) vehicleFactor(ctx context.Context, vehicleId int32) (models.FuelFactor, error) {
+	vehicle := &models.Vehicle{FuelType: models.DefaultFuelType}
+	if vehicleId != 0 {
+		found, err := s.vehicleRepository.FindById(ctx, vehicleId)
+		if err != nil && !errors.Is(err, models.ErrVehicleNotFound) {
+			return models.FuelFactor{}, err
+		}
+		if found != nil {
+			vehicle = found
+		}
+		if vehicle.FuelType != "" && !vehicle.KnownFuelType() {
+			logger.Info("unknown vehicle fuel type, assuming the default",
+				zap.Int32("vehicleId", vehicleId), zap.String("fuelType", vehicle.FuelType))
+		}
+	}
+	return vehicle.Factor(), nil
+}
+
+// roundKg keeps grams, finer figures are noise next to the factors.
+func roundKg(kg float64) float64 {
+	return math.Round(kg*1000) / 1000
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// maxExactStops bounds the itineraries ordered exactly, which takes
+// 2^n * n^2 steps; longer ones are built by cheapest insertion.
+const maxExactStops = 12
+
+// maxPlanAttempts bounds how often a change is planned again because the ride
+// changed while it was being routed.
+const maxPlanAttempts = 3
+
+// errStalePlan rolls back a change planned on a ride that has changed since.
+var errStalePlan = errors.New("ride changed while it was planned")
+
+// nearSearchTimeout bounds the routing done by one near search; the rides not
+// checked by then are returned flagged DetourUnchecked.
+const nearSearchTimeout = 5 * time.Second
+
+// plan orders the ride's stops between its fixed start and end for the
+// shortest driving time: the driver's waypoints in the order they were given
+// and every passenger's pickup before their dropoff. It sets the ride's
+// distance and duration to the planned route. The itinerary is nil when no
+// routing provider is configured.
+func (s *RideService) plan(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger) (*models.Itinerary, error) {
+	if s.routingProvider == nil {
+		return nil, nil
+	}
+
+	// after[i] is the stop stops[i] must come after, -1 for none.
+	var stops []*models.RideStop
+	var after []int
+	waypoints := ride.Waypoints()
+	for _, waypoint := range waypoints[1 : len(waypoints)-1] {
+		// Each waypoint follows the previous one; the first gets -1.
+		stops = append(stops, &models.RideStop{Kind: models.RideStopWaypoint, Location: waypoint})
+		after = append(after, len(stops)-2)
+	}
+	for _, passenger := range passengers {
+		if passenger.Role != models.RideRolePassenger {
+			continue
+		}
+		stops = append(stops, &models.RideStop{Kind: models.RideStopPickup, UserID: passenger.UserID, Location: passenger.StartPoint})
+		after = append(after, -1)
+		stops = append(stops, &models.RideStop{Kind: models.RideStopDropoff, UserID: passenger.UserID, Location: passenger.EndPoint})
+		after = append(after, len(stops)-2)
+	}
+
+	points := []models.Location{ride.StartPoint}
+	for _, stop := range stops {
+		points = append(points, stop.Location)
+	}
+	points = append(points, ride.EndPoint)
+	matrix, err := s.routingProvider.Matrix(ctx, points)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence := []*models.RideStop{{Kind: models.RideStopStart, Location: ride.StartPoint}}
+	for _, i := range orderStops(matrix, after) {
+		sequence = append(sequence, stops[i])
+	}
+	sequence = append(sequence, &models.RideStop{Kind: models.RideStopEnd, Location: ride.EndPoint})
+
+	path := make([]models.Location, len(sequence))
+	for i, stop := range sequence {
+		path[i] = stop.Location
+	}
+	route, err := s.routingProvider.Route(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	for i, leg := range route.Legs {
+		if i+1 < len(sequence) {
+			sequence[i+1].DistanceMeters = sequence[i].DistanceMeters + leg.DistanceMeters
+			sequence[i+1].EtaMs = sequence[i].EtaMs + leg.DurationMs
+		}
+	}
+
+	ride.DistanceMeters = route.DistanceMeters
+	ride.EstimatedTimeMs = int32(route.DurationMs)
+	return &models.Itinerary{
+		RideID:         ride.ID,
+		Stops:          sequence,
+		DistanceMeters: route.DistanceMeters,
+		DurationMs:     route.DurationMs,
+	}, nil
+}
+
+// ridePlan is what the ride becomes with a set of passengers.
+type ridePlan struct {
+	// ride is a copy with the planned distance, duration, cost and CO2
+	// figures, at the version it was planned from.
+	ride      *models.Ride
+	itinerary *models.Itinerary
+	split     *models.FareSplit
+	emission  *models.RideEmission
+}
+
+// replan plans the ride with the passengers. Routing providers are remote, so
+// it runs before the transaction storing the plan, which calls checkPlan.
+func (s *RideService) replan(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger) (*ridePlan, error) {
+	planned := *ride
+	itinerary, err := s.plan(ctx, &planned, passengers)
+	if err != nil {
+		return nil, err
+	}
+	if itinerary != nil {
+		passengers = routedDistances(itinerary, passengers)
+	}
+	split, emission, err := s.derive(ctx, &planned, passengers)
+	if err != nil {
+		return nil, err
+	}
+	return &ridePlan{ride: &planned, itinerary: itinerary, split: split, emission: emission}, nil
+}
+
+// routedDistances returns copies of the passengers travelling the distance
+// driven between their pickup and dropoff in the itinerary.
+func routedDistances(itinerary *models.Itinerary, passengers []*models.RidePassenger) []*models.RidePassenger {
+	pickups := make(map[string]float64)
+	dropoffs := make(map[string]float64)
+	for _, stop := range itinerary.Stops {
+		switch stop.Kind {
+		case models.RideStopPickup:
+			pickups[stop.UserID] = stop.DistanceMeters
+		case models.RideStopDropoff:
+			dropoffs[stop.UserID] = stop.DistanceMeters
+		}
+	}
+
+	routed := make([]*models.RidePassenger, len(passengers))
+	for i, passenger := range passengers {
+		c := *passenger
+		pickup, okPickup := pickups[c.UserID]
+		dropoff, okDropoff := dropoffs[c.UserID]
+		if c.Role == models.RideRolePassenger && okPickup && okDropoff {
+			c.DistanceMeters = dropoff - pickup
+		}
+		routed[i] = &c
+	}
+	return routed
+}
+
+// checkPlan reads the ride again in the transaction storing the plan and
+// fails with errStalePlan if it changed after it was planned. Storing a plan
+// bumps the ride's version, and passenger changes that plan nothing leave
+// nothing to go stale.
+func (s *RideService) checkPlan(ctx context.Context, planned *ridePlan) (*models.Ride, error) {
+	current, err := s.rideRepository.FindById(ctx, planned.ride.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version != planned.ride.Version {
+		return nil, errStalePlan
+	}
+	return current, nil
+}
+
+// replanWhileStale runs change, which plans and then stores in a transaction,
+// again as long as the plan went stale, and answers ErrVersionConflict once
+// maxPlanAttempts are used up.
+func replanWhileStale(change func() error) error {
+	for range maxPlanAttempts {
+		if err := change(); !errors.Is(err, errStalePlan) {
+			return err
+		}
+	}
+	return models.ErrVersionConflict
+}
+
+// Detours returns what picking up each ride request would add to the ride's
+// planned route. Entries are nil for requests without a road route, and the
+// result is nil when no routing provider is configured.
+func (s *RideService) Detours(ctx context.Context, ride *models.Ride, rideRequests []*models.RideRequest) ([]*models.Detour, error) {
+	if s.routingProvider == nil {
+		return nil, nil
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, []int32{ride.ID})
+	if err != nil {
+		return nil, err
+	}
+	return s.detours(ctx, ride, passengers, rideRequests)
+}
+
+// detours is Detours for a ride whose passengers were already read. It needs
+// a routing provider.
+func (s *RideService) detours(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger, rideRequests []*models.RideRequest) ([]*models.Detour, error) {
+	current := *ride
+	base, err := s.plan(ctx, &current, passengers)
+	if err != nil {
+		return nil, err
+	}
+
+	detours := make([]*models.Detour, len(rideRequests))
+	for i, rideRequest := range rideRequests {
+		candidate := &models.RidePassenger{
+			RideID:     ride.ID,
+			UserID:     rideRequest.PassengerID,
+			StartPoint: rideRequest.Origin,
+			EndPoint:   rideRequest.Destination,
+			Role:       models.RideRolePassenger,
+		}
+		candidateRide := *ride
+		itinerary, err := s.plan(ctx, &candidateRide, append(slices.Clip(passengers), candidate))
+		if errors.Is(err, models.ErrNoRoute) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Cheapest insertion may find a shorter order than the current one.
+		detours[i] = models.NewDetour(ride,
+			max(itinerary.DistanceMeters-base.DistanceMeters, 0),
+			max(itinerary.DurationMs-base.DurationMs, 0))
+	}
+	return detours, nil
+}
+
+// minDetourMeters is a lower bound of what the ride request adds to the
+// ride's planned distance: no road route from the start through the pickup
+// and the dropoff to the end is shorter than the great-circle one. It is 0
+// for rides never planned.
+func minDetourMeters(ride *models.Ride, rideRequest *models.RideRequest) float64 {
+	if ride.DistanceMeters == 0 {
+		return 0
+	}
+	direct := models.HaversineMeters(ride.StartPoint, rideRequest.Origin) +
+		models.HaversineMeters(rideRequest.Origin, rideRequest.Destination) +
+		models.HaversineMeters(rideRequest.Destination, ride.EndPoint)
+	return max(direct-ride.DistanceMeters, 0)
+}
+
+// orderStops returns the order to visit the stops in, as indexes. The
+// matrix covers the start, the stops and the end, in that order; after[i]
+// is the stop i must follow, or -1.
+func orderStops(matrix [][]models.RouteLeg, after []int) []int {
+	n := len(after)
+	end := n + 1
+	cost := func(from, to int) int64 { return matrix[from][to].DurationMs }
+	if n == 0 {
+		return []int{}
+	}
+
+	if n > maxExactStops {
+		// Stops are listed after the stops they must follow, so each one is
+		// inserted at the cheapest place behind its predecessor.
+		order := []int{}
+		for stop := 0; stop < n; stop++ {
+			first := 0
+			if after[stop] >= 0 {
+				first = slices.Index(order, after[stop]) + 1
+			}
+			bestAt, bestDelta := first, int64(math.MaxInt64)
+			for at := first; at <= len(order); at++ {
+				prev, next := 0, end
+				if at > 0 {
+					prev = order[at-1] + 1
+				}
+				if at < len(order) {
+					next = order[at] + 1
+				}
+				delta := cost(prev, stop+1) + cost(stop+1, next) - cost(prev, next)
+				if delta < bestDelta {
+					bestAt, bestDelta = at, delta
+				}
+			}
+			order = slices.Insert(order, bestAt, stop)
+		}
+		return order
+	}
+
+	// best[visited][last] is the shortest time from the start through the
+	// visited stops ending at last; prev rebuilds the order.
+	full := 1<<n - 1
+	best := make([][]int64, full+1)
+	prev := make([][]int, full+1)
+	for visited := range best {
+		best[visited] = make([]int64, n)
+		prev[visited] = make([]int, n)
+		for last := range best[visited] {
+			best[visited][last] = math.MaxInt64
+		}
+	}
+	for stop := 0; stop < n; stop++ {
+		if after[stop] < 0 {
+			best[1<<stop][stop] = cost(0, stop+1)
+			prev[1<<stop][stop] = -1
+		}
+	}
+	for visited := 1; visited <= full; visited++ {
+		for last := 0; last < n; last++ {
+			if best[visited][last] == math.MaxInt64 {
+				continue
+			}
+			for next := 0; next < n; next++ {
+				if visited&(1<<next) != 0 || (after[next] >= 0 && visited&(1<<after[next]) == 0) {
+					continue
+				}
+				total := best[visited][last] + cost(last+1, next+1)
+				if total < best[visited|1<<next][next] {
+					best[visited|1<<next][next] = total
+					prev[visited|1<<next][next] = last
+				}
+			}
+		}
+	}
+
+	last, bestTotal := 0, int64(math.MaxInt64)
+	for stop := 0; stop < n; stop++ {
+		if best[full][stop] == math.MaxInt64 {
+			continue
+		}
+		if total := best[full][stop] + cost(stop+1, end); total < bestTotal {
+			last, bestTotal = stop, total
+		}
+	}
+	order := make([]int, n)
+	for visited, i := full, n-1; i >= 0; i-- {
+		order[i] = last
+		visited, last = visited&^(1<<last), prev[visited][last]
+	}
+	return order
+}
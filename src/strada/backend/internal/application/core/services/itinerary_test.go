@@ -0,0 +1,161 @@
+package services
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+// lineMatrix puts the start, the stops and the end on a line at the given
+// positions, one second per unit.
+func lineMatrix(positions []int64) [][]models.RouteLeg {
+	matrix := make([][]models.RouteLeg, len(positions))
+	for i := range positions {
+		matrix[i] = make([]models.RouteLeg, len(positions))
+		for j := range positions {
+			d := positions[i] - positions[j]
+			matrix[i][j] = models.RouteLeg{DurationMs: max(d, -d) * 1000}
+		}
+	}
+	return matrix
+}
+
+func randomMatrix(r *rand.Rand, n int) [][]models.RouteLeg {
+	matrix := make([][]models.RouteLeg, n)
+	for i := range matrix {
+		matrix[i] = make([]models.RouteLeg, n)
+		for j := range matrix[i] {
+			if i != j {
+				matrix[i][j] = models.RouteLeg{DurationMs: r.Int64N(1000) + 1}
+			}
+		}
+	}
+	return matrix
+}
+
+func orderCost(matrix [][]models.RouteLeg, order []int) int64 {
+	total, prev := int64(0), 0
+	for _, stop := range order {
+		total += matrix[prev][stop+1].DurationMs
+		prev = stop + 1
+	}
+	return total + matrix[prev][len(order)+1].DurationMs
+}
+
+// checkOrder fails unless order visits every stop once, each after the stop
+// it must follow.
+func checkOrder(t *testing.T, order []int, after []int) {
+	t.Helper()
+	if len(order) != len(after) {
+		t.Fatalf("order %v has %d stops, want %d", order, len(order), len(after))
+	}
+	for stop := range after {
+		at := slices.Index(order, stop)
+		if at < 0 {
+			t.Fatalf("order %v misses stop %d", order, stop)
+		}
+		if after[stop] >= 0 && slices.Index(order, after[stop]) > at {
+			t.Fatalf("order %v visits stop %d before %d", order, stop, after[stop])
+		}
+	}
+}
+
+// bestOrder tries every order allowed by after.
+func bestOrder(matrix [][]models.RouteLeg, after []int) int64 {
+	best := int64(-1)
+	var visit func(order []int)
+	visit = func(order []int) {
+		if len(order) == len(after) {
+			if cost := orderCost(matrix, order); best < 0 || cost < best {
+				best = cost
+			}
+			return
+		}
+		for stop := range after {
+			if slices.Contains(order, stop) || after[stop] >= 0 && !slices.Contains(order, after[stop]) {
+				continue
+			}
+			visit(append(order, stop))
+		}
+	}
+	visit(nil)
+	return best
+}
+
+// passengerStops lists a pickup followed by its dropoff per passenger.
+func passengerStops(passengers int) []int {
+	after := make([]int, 0, 2*passengers)
+	for i := 0; i < passengers; i++ {
+		after = append(after, -1, len(after))
+	}
+	return after
+}
+
+func TestOrderStopsExactIsOptimal(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, after := range [][]int{
+		{},
+		{-1},
+		passengerStops(1),
+		passengerStops(3),
+		// Two waypoints, then two passengers.
+		{-1, 0, -1, 2, -1, 4},
+	} {
+		for range 20 {
+			matrix := randomMatrix(r, len(after)+2)
+			order := orderStops(matrix, after)
+			checkOrder(t, order, after)
+			if got, want := orderCost(matrix, order), bestOrder(matrix, after); got != want {
+				t.Fatalf("after %v: order %v takes %d, best takes %d", after, order, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderStopsPickupBeforeDropoff(t *testing.T) {
+	// Driving from 0 to 10, the passenger goes from 8 back to 2: the dropoff
+	// is on the way to the pickup but cannot come first.
+	matrix := lineMatrix([]int64{0, 8, 2, 10})
+	if order := orderStops(matrix, []int{-1, 0}); !slices.Equal(order, []int{0, 1}) {
+		t.Errorf("order = %v, want [0 1]", order)
+	}
+}
+
+func TestOrderStopsKeepsWaypointOrder(t *testing.T) {
+	// The waypoints were given as 9, 5, 1: visiting them backwards is
+	// shorter, but the driver's order is kept.
+	matrix := lineMatrix([]int64{0, 9, 5, 1, 10})
+	after := []int{-1, 0, 1}
+	if order := orderStops(matrix, after); !slices.Equal(order, []int{0, 1, 2}) {
+		t.Errorf("order = %v, want [0 1 2]", order)
+	}
+}
+
+func TestOrderStopsCheapestInsertion(t *testing.T) {
+	// Beyond maxExactStops passengers are inserted one by one. On a line,
+	// with every pickup ahead of its dropoff, that still drives straight
+	// through.
+	passengers := maxExactStops/2 + 1
+	after := passengerStops(passengers)
+	positions := []int64{0}
+	for i := 0; i < passengers; i++ {
+		positions = append(positions, int64(passengers-i), int64(2*passengers-i))
+	}
+	positions = append(positions, int64(2*passengers+1))
+	matrix := lineMatrix(positions)
+
+	order := orderStops(matrix, after)
+	checkOrder(t, order, after)
+	if got, want := orderCost(matrix, order), positions[len(positions)-1]*1000; got != want {
+		t.Errorf("order %v takes %d, want %d", order, got, want)
+	}
+
+	// Random matrices are not solved exactly, but the constraints hold.
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 20 {
+		order := orderStops(randomMatrix(r, len(after)+2), after)
+		checkOrder(t, order, after)
+	}
+}
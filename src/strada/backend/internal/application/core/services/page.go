@@ -0,0 +1,37 @@
+package services
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return models.DefaultPageSize
+	}
+	return min(limit, models.MaxPageSize)
+}
+
+// pageCursor decodes the client cursor, rejecting cursors issued for a
+// different sort than the one requested.
+func pageCursor(token string, sortBy string, descending bool) (*models.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	cursor, err := models.DecodeCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.SortBy != sortBy || cursor.Descending != descending {
+		return nil, models.ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// buildPage trims the extra item fetched to detect a next page and issues
+// the cursor pointing after the last returned item.
+func buildPage[T any](items []T, limit int, cursorOf func(T) *models.Cursor) *models.Page[T] {
+	page := &models.Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = cursorOf(page.Items[limit-1]).Encode()
+	}
+	return page
+}
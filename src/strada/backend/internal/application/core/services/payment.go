@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type PaymentService struct {
+	paymentRepository out.PaymentRepository
+	rideRepository    out.RideRepository
+	providers         map[string]out.PaymentProvider
+	// defaultProvider takes the new charges; payouts and refunds go through
+	// the provider of their charge.
+	defaultProvider    string
+	userService        in.UserService
+	transactionManager out.TransactionManager
+}
+
+// NewPaymentService charges through the first provider given.
+func NewPaymentService(paymentRepository out.PaymentRepository, rideRepository out.RideRepository, providers ...out.PaymentProvider) in.PaymentService {
+	s := &PaymentService{
+		paymentRepository: paymentRepository,
+		rideRepository:    rideRepository,
+		providers:         make(map[string]out.PaymentProvider, len(providers)),
+	}
+	for _, provider := range providers {
+		s.providers[provider.Name()] = provider
+	}
+	if len(providers) > 0 {
+		s.defaultProvider = providers[0].Name()
+	}
+	return s
+}
+
+func (s *PaymentService) SetUserService(userService in.UserService) {
+	s.userService = userService
+}
+
+func (s *PaymentService) SetTransactionManager(transactionManager out.TransactionManager) {
+	s.transactionManager = transactionManager
+}
+
+// Charge collects the passenger's fare on a completed ride.
+func (s *PaymentService) Charge(ctx context.Context, rideId int32, passengerId string) (*models.Payment, error) {
+	passengerId, err := resolveActor(ctx, s.userService, passengerId, canRequestRides)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.provider(s.defaultProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var charge *models.Payment
+	err = withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		ride, err := s.rideRepository.FindById(ctx, rideId)
+		if err != nil {
+			return err
+		}
+		if ride.CompletedAt.IsZero() {
+			return models.ErrRideNotCompleted
+		}
+		passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(passengers, func(passenger *models.RidePassenger) bool {
+			return passenger.UserID == passengerId && passenger.Role == models.RideRolePassenger
+		})
+		if i < 0 {
+			return models.ErrPassengerNotFound
+		}
+		if passengers[i].Fare.Amount <= 0 {
+			return models.ErrNothingToCharge
+		}
+		charge, err = s.paymentRepository.Create(ctx, &models.Payment{
+			RideID:      rideId,
+			PassengerID: passengerId,
+			DriverID:    ride.DriverID,
+			Kind:        models.PaymentKindCharge,
+			Amount:      passengers[i].Fare,
+			Status:      models.PaymentStatusPending,
+			Provider:    provider.Name(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.submit(ctx, charge)
+}
+
+// Refund returns part of a succeeded charge to the passenger, or all that is
+// left of it when amount is zero. Only the driver may refund.
+func (s *PaymentService) Refund(ctx context.Context, chargeId int32, amount models.Money) (*models.Payment, error) {
+	var refund *models.Payment
+	err := withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		charge, err := s.paymentRepository.FindById(ctx, chargeId)
+		if err != nil {
+			return err
+		}
+		if _, err := resolveActor(ctx, s.userService, charge.DriverID, canAcceptRides); err != nil {
+			return err
+		}
+		if charge.Kind != models.PaymentKindCharge || charge.Status != models.PaymentStatusSucceeded {
+			return models.ErrNotRefundable
+		}
+
+		settlements, err := s.paymentRepository.FindByParent(ctx, chargeId)
+		if err != nil {
+			return err
+		}
+		left := charge.Amount.Amount
+		for _, settlement := range settlements {
+			if settlement.Kind == models.PaymentKindRefund && settlement.Status != models.PaymentStatusFailed {
+				left -= settlement.Amount.Amount
+			}
+		}
+		refundAmount := amount
+		if refundAmount.IsZero() {
+			refundAmount = models.NewMoney(left, charge.Amount.Currency)
+		}
+		if refundAmount.Currency != charge.Amount.Currency {
+			return models.ErrCurrencyMismatch
+		}
+		if refundAmount.Amount <= 0 || refundAmount.Amount > left {
+			return models.ErrRefundExceedsCharge
+		}
+
+		refund, err = s.paymentRepository.Create(ctx, &models.Payment{
+			RideID:      charge.RideID,
+			PassengerID: charge.PassengerID,
+			DriverID:    charge.DriverID,
+			Kind:        models.PaymentKindRefund,
+			Amount:      refundAmount,
+			Status:      models.PaymentStatusPending,
+			ParentID:    charge.ID,
+			Provider:    charge.Provider,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.submit(ctx, refund)
+}
+
+// FindByRide returns the whole ledger to the driver, and to everyone else
+// only the entries of the passengers they may act for.
+func (s *PaymentService) FindByRide(ctx context.Context, rideId int32) ([]*models.Payment, error) {
+	ride, err := s.rideRepository.FindById(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.paymentRepository.FindByRide(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = resolveActor(ctx, s.userService, ride.DriverID, canAcceptRides)
+	if err == nil {
+		return payments, nil
+	}
+	if !errors.Is(err, models.ErrForbidden) {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool)
+	visible := []*models.Payment{}
+	for _, payment := range payments {
+		ok, seen := allowed[payment.PassengerID]
+		if !seen {
+			_, err := resolveActor(ctx, s.userService, payment.PassengerID, canRequestRides)
+			if err != nil && !errors.Is(err, models.ErrForbidden) {
+				return nil, err
+			}
+			ok = err == nil
+			allowed[payment.PassengerID] = ok
+		}
+		if ok {
+			visible = append(visible, payment)
+		}
+	}
+	return visible, nil
+}
+
+// HandleCallback applies a provider status callback. Deliveries of an event
+// already seen change nothing and return the payment as it stands.
+func (s *PaymentService) HandleCallback(ctx context.Context, providerName string, header map[string][]string, body []byte) (*models.Payment, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	event, err := provider.ParseCallback(header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var payment, payout *models.Payment
+	err = withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		payout = nil
+		found, err := s.paymentRepository.FindByProviderRef(ctx, provider.Name(), event.ProviderRef)
+		if err != nil {
+			return err
+		}
+		recorded, err := s.paymentRepository.RecordEvent(ctx, event, found.ID)
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			payment = found
+			return nil
+		}
+		payment, payout, err = s.settle(ctx, found, &event.PaymentUpdate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.submitPayout(ctx, payout); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// submit sends a new payment to its provider and stores the outcome. A
+// provider error fails the payment rather than leaving it pending.
+func (s *PaymentService) submit(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	provider, err := s.provider(payment.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var update *models.PaymentUpdate
+	var providerErr error
+	switch payment.Kind {
+	case models.PaymentKindCharge:
+		update, providerErr = provider.Charge(ctx, payment)
+	case models.PaymentKindPayout:
+		update, providerErr = provider.Payout(ctx, payment)
+	case models.PaymentKindRefund:
+		charge, err := s.paymentRepository.FindById(ctx, payment.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		update, providerErr = provider.Refund(ctx, payment, charge)
+	}
+	if providerErr != nil {
+		update = &models.PaymentUpdate{Status: models.PaymentStatusFailed, FailureReason: providerErr.Error()}
+	}
+
+	var submitted, payout *models.Payment
+	err = withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		// A callback may have settled the payment while the provider answered.
+		current, err := s.paymentRepository.FindById(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+		submitted, payout, err = s.settle(ctx, current, update)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.submitPayout(ctx, payout); err != nil {
+		return nil, err
+	}
+	if providerErr != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrPaymentProviderFailed, providerErr)
+	}
+	return submitted, nil
+}
+
+// settle applies what the provider reported to a pending payment; settled
+// payments keep their status. A charge that succeeds queues the payout of
+// the same amount to the driver, returned so it is only submitted once the
+// transaction commits.
+func (s *PaymentService) settle(ctx context.Context, payment *models.Payment, update *models.PaymentUpdate) (*models.Payment, *models.Payment, error) {
+	if !payment.Pending() {
+		return payment, nil, nil
+	}
+	settled := *payment
+	if update.ProviderRef != "" {
+		settled.ProviderRef = update.ProviderRef
+	}
+	settled.Status = update.Status
+	settled.FailureReason = update.FailureReason
+	updated, err := s.paymentRepository.Update(ctx, &settled)
+	if err != nil || updated.Kind != models.PaymentKindCharge || updated.Status != models.PaymentStatusSucceeded {
+		return updated, nil, err
+	}
+
+	payout, err := s.paymentRepository.Create(ctx, &models.Payment{
+		RideID:      updated.RideID,
+		PassengerID: updated.PassengerID,
+		DriverID:    updated.DriverID,
+		Kind:        models.PaymentKindPayout,
+		Amount:      updated.Amount,
+		Status:      models.PaymentStatusPending,
+		ParentID:    updated.ID,
+		Provider:    updated.Provider,
+	})
+	if errors.Is(err, models.ErrPaymentExists) {
+		return updated, nil, nil
+	}
+	return updated, payout, err
+}
+
+// submitPayout does nothing without a payout. A payout the provider rejects
+// stays failed in the ledger; the charge it follows went through anyway.
+func (s *PaymentService) submitPayout(ctx context.Context, payout *models.Payment) error {
+	if payout == nil {
+		return nil
+	}
+	_, err := s.submit(ctx, payout)
+	if errors.Is(err, models.ErrPaymentProviderFailed) {
+		return nil
+	}
+	return err
+}
+
+func (s *PaymentService) provider(name string) (out.PaymentProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, models.ErrUnknownPaymentProvider
+	}
+	return provider, nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+)
+
+type PricingService struct {
+	farePerKm models.Money
+}
+
+func NewPricingService(farePerKm models.Money) in.PricingService {
+	return &PricingService{
+		farePerKm: farePerKm,
+	}
+}
+
+func (s *PricingService) Split(ride *models.Ride, passengers []*models.RidePassenger) (*models.FareSplit, error) {
+	currency := s.farePerKm.Currency
+	if !ride.CostCeiling.IsZero() && ride.CostCeiling.Currency != currency {
+		return nil, models.ErrCurrencyMismatch
+	}
+
+	split := &models.FareSplit{
+		RideID:  ride.ID,
+		Total:   models.NewMoney(0, currency),
+		Ceiling: ride.CostCeiling,
+		Shares:  []*models.PassengerFare{},
+	}
+	var total int64
+	for _, passenger := range passengers {
+		if passenger.RideID != ride.ID || passenger.Role != models.RideRolePassenger {
+			continue
+		}
+		distance := passenger.DistanceMeters
+		if distance == 0 {
+			distance = models.HaversineMeters(passenger.StartPoint, passenger.EndPoint)
+		}
+		amount := int64(math.Round(float64(s.farePerKm.Amount) * distance / 1000))
+		split.Shares = append(split.Shares, &models.PassengerFare{
+			UserID:         passenger.UserID,
+			DistanceMeters: distance,
+			Fare:           models.NewMoney(amount, currency),
+		})
+		total += amount
+	}
+
+	if ceiling := ride.CostCeiling.Amount; ceiling > 0 && total > ceiling {
+		capShares(split.Shares, total, ceiling)
+		total = ceiling
+		split.Capped = true
+	}
+	split.Total.Amount = total
+	return split, nil
+}
+
+// capShares scales the shares down so they add up to exactly ceiling. Each
+// share is truncated and the centavos left over go to the largest
+// remainders, so nobody pays more than their proportional part plus one.
+func capShares(shares []*models.PassengerFare, total int64, ceiling int64) {
+	remainders := make([]int64, len(shares))
+	order := make([]int, len(shares))
+	left := ceiling
+	for i, share := range shares {
+		scaled := share.Fare.Amount * ceiling
+		share.Fare.Amount = scaled / total
+		remainders[i] = scaled % total
+		order[i] = i
+		left -= share.Fare.Amount
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(remainders[b], remainders[a])
+	})
+	for _, i := range order[:left] {
+		shares[i].Fare.Amount++
+	}
+}
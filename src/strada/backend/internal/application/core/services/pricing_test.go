@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+func fares(amounts ...int64) []*models.PassengerFare {
+	shares := make([]*models.PassengerFare, len(amounts))
+	for i, amount := range amounts {
+		shares[i] = &models.PassengerFare{Fare: models.NewMoney(amount, models.DefaultCurrency)}
+	}
+	return shares
+}
+
+func amounts(shares []*models.PassengerFare) []int64 {
+	got := make([]int64, len(shares))
+	for i, share := range shares {
+		got[i] = share.Fare.Amount
+	}
+	return got
+}
+
+func TestCapShares(t *testing.T) {
+	tests := []struct {
+		name    string
+		shares  []int64
+		ceiling int64
+		want    []int64
+	}{
+		// 16.67, 33.33 and 50: the centavo left goes to the largest remainder.
+		{"remainder to the largest fraction", []int64{100, 200, 300}, 100, []int64{17, 33, 50}},
+		// 33.33 each: ties go to the first passengers.
+		{"ties in order", []int64{100, 100, 100}, 100, []int64{34, 33, 33}},
+		{"exact", []int64{100, 300}, 200, []int64{50, 150}},
+		{"one passenger", []int64{999}, 500, []int64{500}},
+		{"zero share", []int64{0, 100}, 50, []int64{0, 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares := fares(tt.shares...)
+			var total int64
+			for _, amount := range tt.shares {
+				total += amount
+			}
+			capShares(shares, total, tt.ceiling)
+			got := amounts(shares)
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("shares = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCapSharesAddsUpToCeiling(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 1000 {
+		original := make([]int64, r.IntN(8)+1)
+		var total int64
+		for i := range original {
+			original[i] = r.Int64N(100000) + 1
+			total += original[i]
+		}
+		ceiling := r.Int64N(total) + 1
+		shares := fares(original...)
+		capShares(shares, total, ceiling)
+
+		var sum int64
+		for i, share := range shares {
+			sum += share.Fare.Amount
+			// Nobody pays more than their proportional part rounded up.
+			floor := original[i] * ceiling / total
+			if share.Fare.Amount != floor && share.Fare.Amount != floor+1 {
+				t.Fatalf("share %d of %v capped at %d = %d, want %d or %d", i, original, ceiling, share.Fare.Amount, floor, floor+1)
+			}
+		}
+		if sum != ceiling {
+			t.Fatalf("shares of %v capped at %d add up to %d", original, ceiling, sum)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	pricing := NewPricingService(models.NewMoney(60, "BRL"))
+	start := models.Location{Latitude: -23.55, Longitude: -46.63}
+	// About 1112 m north.
+	end := models.Location{Latitude: -23.54, Longitude: -46.63}
+	ride := &models.Ride{ID: 1}
+	passengers := []*models.RidePassenger{
+		{RideID: 1, UserID: "routed", Role: models.RideRolePassenger, StartPoint: start, EndPoint: end, DistanceMeters: 2500},
+		{RideID: 1, UserID: "straight", Role: models.RideRolePassenger, StartPoint: start, EndPoint: end},
+		{RideID: 1, UserID: "driver", Role: models.RideRoleDriver, DistanceMeters: 5000},
+		{RideID: 2, UserID: "other ride", Role: models.RideRolePassenger, DistanceMeters: 5000},
+	}
+
+	split, err := pricing.Split(ride, passengers)
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	if len(split.Shares) != 2 {
+		t.Fatalf("%d shares, want 2", len(split.Shares))
+	}
+	// 0.60 per km: 2.5 km costs 1.50, and 1.112 km rounds to 0.67.
+	if got := amounts(split.Shares); got[0] != 150 || got[1] != 67 {
+		t.Errorf("shares = %v, want [150 67]", got)
+	}
+	if split.Shares[0].DistanceMeters != 2500 {
+		t.Errorf("routed distance = %v, want 2500", split.Shares[0].DistanceMeters)
+	}
+	if split.Total.Amount != 217 || split.Capped {
+		t.Errorf("total = %v, capped %v, want 2.17 uncapped", split.Total, split.Capped)
+	}
+
+	ride.CostCeiling = models.NewMoney(100, "BRL")
+	split, err = pricing.Split(ride, passengers)
+	if err != nil {
+		t.Fatalf("Split: %v", err)
+	}
+	// 150 and 67 scaled to 100: 69.12 and 30.88.
+	if got := amounts(split.Shares); got[0] != 69 || got[1] != 31 || split.Total.Amount != 100 || !split.Capped {
+		t.Errorf("capped shares = %v, total %v, want [69 31] adding up to 1.00", got, split.Total)
+	}
+
+	ride.CostCeiling = models.NewMoney(100, "USD")
+	if _, err := pricing.Split(ride, passengers); !errors.Is(err, models.ErrCurrencyMismatch) {
+		t.Errorf("Split err = %v, want ErrCurrencyMismatch", err)
+	}
+}
@@ -2,17 +2,30 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/244Walyson/shared-ride/configs/logger"
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
 	out "github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"go.uber.org/zap"
 )
 
 type RideService struct {
-	rideRepository     out.RideRepository
-	rideRequestService in.RideRequestService
-	UserService        in.UserService
+	rideRepository       out.RideRepository
+	rideRequestService   in.RideRequestService
+	UserService          in.UserService
+	rideEventService     in.RideEventService
+	transactionManager   out.TransactionManager
+	changeHistoryService in.ChangeHistoryService
+	pricingService       in.PricingService
+	emissionService      in.EmissionService
+	routingProvider      out.RoutingProvider
+	schoolService        in.SchoolService
 }
 
 func NewRideService(rideRepository out.RideRepository) in.RideService {
@@ -29,14 +42,75 @@ func (s *RideService) SetUserService(userService in.UserService) {
 	s.UserService = userService
 }
 
+func (s *RideService) SetRideEventService(rideEventService in.RideEventService) {
+	s.rideEventService = rideEventService
+}
+
+func (s *RideService) SetTransactionManager(transactionManager out.TransactionManager) {
+	s.transactionManager = transactionManager
+}
+
+func (s *RideService) SetChangeHistoryService(changeHistoryService in.ChangeHistoryService) {
+	s.changeHistoryService = changeHistoryService
+}
+
+func (s *RideService) SetPricingService(pricingService in.PricingService) {
+	s.pricingService = pricingService
+}
+
+func (s *RideService) SetEmissionService(emissionService in.EmissionService) {
+	s.emissionService = emissionService
+}
+
+func (s *RideService) SetRoutingProvider(routingProvider out.RoutingProvider) {
+	s.routingProvider = routingProvider
+}
+
+func (s *RideService) SetSchoolService(schoolService in.SchoolService) {
+	s.schoolService = schoolService
+}
+
 func (s *RideService) Create(ctx context.Context, ride *models.Ride) (*models.Ride, error) {
-	user, err := s.UserService.FindById(ctx, ride.DriverID)
+	driverId, err := resolveActor(ctx, s.UserService, ride.DriverID, canAcceptRides)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.UserService.FindById(ctx, driverId)
 	if err != nil {
 		return nil, err
 	}
+	if ride.MaxDetourMeters < 0 || ride.MaxDetourMs < 0 {
+		return nil, models.ErrInvalidDetourBudget
+	}
+	if err := checkDropoff(ctx, s.schoolService, ride.SchoolID, ride.EndPoint); err != nil {
+		return nil, err
+	}
 	ride.DriverID = user.ID
 	ride.StopPoints = append(ride.StopPoints, ride.EndPoint)
-	return s.rideRepository.Create(ctx, ride)
+	itinerary, err := s.plan(ctx, ride, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := s.derive(ctx, ride, nil); err != nil {
+		return nil, err
+	}
+	var created *models.Ride
+	err = withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		var err error
+		created, err = s.rideRepository.Create(ctx, ride)
+		if err != nil {
+			return err
+		}
+		if err := s.storeItinerary(ctx, created.ID, itinerary); err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRide, created.ID, models.ChangeCreated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideEventCreated, created.ID, created.DriverID)
+	return created, nil
 }
 
 func (s *RideService) FindById(ctx context.Context, id int32) (*models.Ride, error) {
@@ -47,21 +121,522 @@ func (s *RideService) FindAll(ctx context.Context) ([]*models.Ride, error) {
 	return s.rideRepository.FindAll(ctx)
 }
 
+func (s *RideService) List(ctx context.Context, filter models.RideFilter) (*models.Page[*models.Ride], error) {
+	if filter.SortBy == "" {
+		filter.SortBy = models.RideSortCreatedAt
+	}
+
+	var cursorValue func(ride *models.Ride) string
+	switch filter.SortBy {
+	case models.RideSortCreatedAt:
+		cursorValue = func(ride *models.Ride) string { return ride.CreatedAt.Format(time.RFC3339Nano) }
+	case models.RideSortCost:
+		cursorValue = func(ride *models.Ride) string { return ride.Cost.String() }
+	case models.RideSortDistance:
+		cursorValue = func(ride *models.Ride) string { return strconv.FormatFloat(ride.DistanceMeters, 'f', -1, 64) }
+	default:
+		return nil, models.ErrInvalidSort
+	}
+
+	after, err := pageCursor(filter.Cursor, filter.SortBy, filter.Descending)
+	if err != nil {
+		return nil, err
+	}
+	limit := pageLimit(filter.Limit)
+
+	rides, err := s.rideRepository.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return buildPage(rides, limit, func(ride *models.Ride) *models.Cursor {
+		return &models.Cursor{SortBy: filter.SortBy, Descending: filter.Descending, Value: cursorValue(ride), ID: ride.ID}
+	}), nil
+}
+
+func (s *RideService) FindByUser(ctx context.Context, userId string) ([]*models.Ride, error) {
+	return s.rideRepository.FindByUser(ctx, userId)
+}
+
 func (s *RideService) FindNear(ctx context.Context, rideRequestId int32) ([]*models.Ride, error) {
-	rideReequest, err := s.rideRequestService.FindById(ctx, rideRequestId)
+	rideRequest, err := s.rideRequestService.FindById(ctx, rideRequestId)
+	if err != nil {
+		return nil, err
+	}
+	locations := []*models.Location{&rideRequest.Origin, &rideRequest.Destination}
+	rides, err := s.rideRepository.FindNear(ctx, locations, rideRequest.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.routingProvider == nil {
+		return rides, nil
+	}
 
-	fmt.Printf("ride req %v\n", rideReequest)
+	// Rides whose driver would go further out of the way than they accept
+	// are left out. Those the straight-line distance already rules out are
+	// not routed, and the passengers of the others are read at once. Rides
+	// whose detour is not known, because routing it failed or the search ran
+	// out of time, are kept and flagged DetourUnchecked.
+	var rideIds []int32
+	for _, ride := range rides {
+		if ride.HasDetourBudget() {
+			rideIds = append(rideIds, ride.ID)
+		}
+	}
+	if len(rideIds) == 0 {
+		return rides, nil
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, rideIds)
 	if err != nil {
 		return nil, err
 	}
-	locations := []*models.Location{&rideReequest.Origin, &rideReequest.Destination}
-	return s.rideRepository.FindNear(ctx, locations)
+	passengersByRide := make(map[int32][]*models.RidePassenger, len(rideIds))
+	for _, passenger := range passengers {
+		passengersByRide[passenger.RideID] = append(passengersByRide[passenger.RideID], passenger)
+	}
+
+	searchCtx, cancel := context.WithTimeout(ctx, nearSearchTimeout)
+	defer cancel()
+	candidate := []*models.RideRequest{rideRequest}
+	near := rides[:0]
+	unchecked := 0
+	for _, ride := range rides {
+		if ride.HasDetourBudget() {
+			if ride.MaxDetourMeters > 0 && minDetourMeters(ride, rideRequest) > ride.MaxDetourMeters {
+				continue
+			}
+			ride.DetourUnchecked = true
+			if searchCtx.Err() == nil {
+				detours, err := s.detours(searchCtx, ride, passengersByRide[ride.ID], candidate)
+				if err != nil && !errors.Is(err, models.ErrNoRoute) && (ctx.Err() != nil || searchCtx.Err() == nil) {
+					return nil, err
+				}
+				if err == nil && detours[0] != nil {
+					if !detours[0].WithinBudget {
+						continue
+					}
+					ride.DetourUnchecked = false
+				}
+			}
+			if ride.DetourUnchecked {
+				unchecked++
+			}
+		}
+		near = append(near, ride)
+	}
+	if unchecked > 0 {
+		logger.Info("near search kept rides whose detour it could not check",
+			zap.Int32("rideRequestId", rideRequestId), zap.Int("rides", unchecked), zap.Bool("timedOut", searchCtx.Err() != nil))
+	}
+	return near, nil
 }
 
 func (s *RideService) Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error) {
-	return s.rideRepository.Update(ctx, id, ride)
+	if ride.MaxDetourMeters < 0 || ride.MaxDetourMs < 0 {
+		return nil, models.ErrInvalidDetourBudget
+	}
+	if err := checkDropoff(ctx, s.schoolService, ride.SchoolID, ride.EndPoint); err != nil {
+		return nil, err
+	}
+	var updated *models.Ride
+	requestedVersion := ride.Version
+	err := replanWhileStale(func() error {
+		existing, err := s.authorizeDriver(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ride.Version, err = baseVersion(requestedVersion, existing.Version); err != nil {
+			return err
+		}
+		ride.ID = id
+		ride.DriverID = existing.DriverID
+		ride.CompletedAt = existing.CompletedAt
+		passengers, err := s.rideRepository.FindPassengers(ctx, []int32{id})
+		if err != nil {
+			return err
+		}
+		planned, err := s.replan(ctx, ride, passengers)
+		if err != nil {
+			return err
+		}
+
+		return withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+			if _, err := s.checkPlan(ctx, planned); err != nil {
+				return err
+			}
+			updated, err = s.rideRepository.Update(ctx, id, planned.ride)
+			if err != nil {
+				return err
+			}
+			if err := s.storeItinerary(ctx, id, planned.itinerary); err != nil {
+				return err
+			}
+			if err := s.storeShares(ctx, id, planned.split, planned.emission); err != nil {
+				return err
+			}
+			return recordChange(ctx, s.changeHistoryService, models.EntityRide, id, models.ChangeUpdated, rideChanges(existing, updated))
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideEventUpdated, id, ride.DriverID)
+	return updated, nil
+}
+
+// Complete marks the ride as done; only completed rides count in the
+// statistics.
+func (s *RideService) Complete(ctx context.Context, id int32) (*models.Ride, error) {
+	var completed *models.Ride
+	err := withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		existing, err := s.authorizeDriver(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !existing.CompletedAt.IsZero() {
+			return models.ErrRideCompleted
+		}
+		ride := *existing
+		ride.CompletedAt = time.Now().UTC()
+		completed, err = s.rideRepository.Update(ctx, id, &ride)
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRide, id, models.ChangeUpdated, rideChanges(existing, completed))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideEventUpdated, id, completed.DriverID)
+	return completed, nil
 }
 
 func (s *RideService) Delete(ctx context.Context, id int32) error {
-	return s.rideRepository.Delete(ctx, id)
+	err := withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		if _, err := s.authorizeDriver(ctx, id); err != nil {
+			return err
+		}
+		if err := s.rideRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRide, id, models.ChangeDeleted, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.publish(ctx, models.RideEventDeleted, id, "")
+	return nil
+}
+
+func (s *RideService) FindPassengers(ctx context.Context, rideIds []int32) ([]*models.RidePassenger, error) {
+	if len(rideIds) == 0 {
+		return []*models.RidePassenger{}, nil
+	}
+	return s.rideRepository.FindPassengers(ctx, rideIds)
+}
+
+// Join adds a passenger to the ride and splits the cost again among
+// everyone on board.
+func (s *RideService) Join(ctx context.Context, rideId int32, passenger *models.RidePassenger) (*models.FareSplit, error) {
+	userId, err := resolveActor(ctx, s.UserService, passenger.UserID, canRequestRides)
+	if err != nil {
+		return nil, err
+	}
+	passenger.RideID = rideId
+	passenger.UserID = userId
+	passenger.Role = models.RideRolePassenger
+	passenger.DistanceMeters = models.HaversineMeters(passenger.StartPoint, passenger.EndPoint)
+
+	var split *models.FareSplit
+	err = replanWhileStale(func() error {
+		ride, err := s.rideRepository.FindById(ctx, rideId)
+		if err != nil {
+			return err
+		}
+		if !ride.CompletedAt.IsZero() {
+			return models.ErrRideCompleted
+		}
+		if ride.DriverID == userId {
+			return models.ErrDriverCannotJoin
+		}
+		passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(passengers, func(p *models.RidePassenger) bool { return p.UserID == userId }) {
+			return models.ErrAlreadyJoined
+		}
+		planned, err := s.replan(ctx, ride, append(passengers, passenger))
+		if err != nil {
+			return err
+		}
+
+		return withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+			current, err := s.checkPlan(ctx, planned)
+			if err != nil {
+				return err
+			}
+			if !current.CompletedAt.IsZero() {
+				return models.ErrRideCompleted
+			}
+			if err := s.rideRepository.AddPassenger(ctx, passenger); err != nil {
+				return err
+			}
+			split, err = s.passengersChanged(ctx, current, planned, models.FieldChange{To: userId})
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideEventUpdated, rideId, userId)
+	return split, nil
+}
+
+// Leave removes a passenger from the ride and splits the cost again among
+// those left.
+func (s *RideService) Leave(ctx context.Context, rideId int32, userId string) (*models.FareSplit, error) {
+	userId, err := resolveActor(ctx, s.UserService, userId, canRequestRides)
+	if err != nil {
+		return nil, err
+	}
+
+	var split *models.FareSplit
+	err = replanWhileStale(func() error {
+		ride, err := s.rideRepository.FindById(ctx, rideId)
+		if err != nil {
+			return err
+		}
+		if !ride.CompletedAt.IsZero() {
+			return models.ErrRideCompleted
+		}
+		passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+		if err != nil {
+			return err
+		}
+		remaining := slices.DeleteFunc(passengers, func(p *models.RidePassenger) bool {
+			return p.UserID == userId && p.Role == models.RideRolePassenger
+		})
+		if len(remaining) == len(passengers) {
+			return models.ErrPassengerNotFound
+		}
+		planned, err := s.replan(ctx, ride, remaining)
+		if err != nil {
+			return err
+		}
+
+		return withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+			current, err := s.checkPlan(ctx, planned)
+			if err != nil {
+				return err
+			}
+			if !current.CompletedAt.IsZero() {
+				return models.ErrRideCompleted
+			}
+			if err := s.rideRepository.RemovePassenger(ctx, rideId, userId); err != nil {
+				return err
+			}
+			split, err = s.passengersChanged(ctx, current, planned, models.FieldChange{From: userId})
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideEventUpdated, rideId, userId)
+	return split, nil
+}
+
+func (s *RideService) Fares(ctx context.Context, rideId int32) (*models.FareSplit, error) {
+	ride, err := s.rideRepository.FindById(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+	if err != nil {
+		return nil, err
+	}
+	if s.pricingService == nil {
+		return storedFares(ride, passengers), nil
+	}
+	return s.pricingService.Split(ride, passengers)
+}
+
+// Itinerary returns the stored stop order. Rides planned before itineraries
+// were stored get one computed on the fly.
+func (s *RideService) Itinerary(ctx context.Context, rideId int32) (*models.Itinerary, error) {
+	ride, err := s.rideRepository.FindById(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	stops, err := s.rideRepository.FindStops(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	if len(stops) > 0 {
+		last := stops[len(stops)-1]
+		return &models.Itinerary{RideID: rideId, Stops: stops, DistanceMeters: last.DistanceMeters, DurationMs: last.EtaMs}, nil
+	}
+
+	passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+	if err != nil {
+		return nil, err
+	}
+	itinerary, err := s.plan(ctx, ride, passengers)
+	if err != nil || itinerary != nil {
+		return itinerary, err
+	}
+	return &models.Itinerary{RideID: rideId, Stops: []*models.RideStop{}}, nil
+}
+
+func (s *RideService) Emissions(ctx context.Context, rideId int32) (*models.RideEmission, error) {
+	ride, err := s.rideRepository.FindById(ctx, rideId)
+	if err != nil {
+		return nil, err
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, []int32{rideId})
+	if err != nil {
+		return nil, err
+	}
+	if s.emissionService == nil {
+		return storedEmission(ride, passengers), nil
+	}
+	return s.emissionService.Estimate(ctx, ride, passengers)
+}
+
+// derive sets the fields of the ride the server computes from its
+// passengers: the cost, when pricing is configured, and the CO2 figures.
+// Either result is nil when its service is not configured.
+func (s *RideService) derive(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger) (*models.FareSplit, *models.RideEmission, error) {
+	var split *models.FareSplit
+	if s.pricingService != nil {
+		var err error
+		if split, err = s.pricingService.Split(ride, passengers); err != nil {
+			return nil, nil, err
+		}
+		ride.Cost = split.Total
+	}
+	var emission *models.RideEmission
+	if s.emissionService != nil {
+		var err error
+		if emission, err = s.emissionService.Estimate(ctx, ride, passengers); err != nil {
+			return nil, nil, err
+		}
+		ride.Co2EmissionKg = emission.EmissionKg
+		ride.Co2SavedKg = emission.SavedKg
+	}
+	return split, emission, nil
+}
+
+// storeShares saves each passenger's fare and CO2 saving.
+func (s *RideService) storeShares(ctx context.Context, rideId int32, split *models.FareSplit, emission *models.RideEmission) error {
+	if split != nil {
+		if err := s.rideRepository.UpdatePassengerFares(ctx, rideId, split.Shares); err != nil {
+			return err
+		}
+	}
+	if emission != nil {
+		return s.rideRepository.UpdatePassengerEmissions(ctx, rideId, emission.Passengers)
+	}
+	return nil
+}
+
+// storeItinerary does nothing without an itinerary.
+func (s *RideService) storeItinerary(ctx context.Context, rideId int32, itinerary *models.Itinerary) error {
+	if itinerary == nil {
+		return nil
+	}
+	return s.rideRepository.ReplaceStops(ctx, rideId, itinerary.Stops)
+}
+
+// passengersChanged stores the ride's itinerary, cost, CO2 figures and
+// passenger shares planned for someone joining or leaving, and records the
+// change in the ride's history. It runs in the transaction that added or
+// removed the passenger.
+func (s *RideService) passengersChanged(ctx context.Context, before *models.Ride, planned *ridePlan, passenger models.FieldChange) (*models.FareSplit, error) {
+	changes := map[string]models.FieldChange{"passenger": passenger}
+	if planned.itinerary != nil || planned.split != nil || planned.emission != nil {
+		updated, err := s.rideRepository.Update(ctx, before.ID, planned.ride)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.storeItinerary(ctx, before.ID, planned.itinerary); err != nil {
+			return nil, err
+		}
+		if err := s.storeShares(ctx, before.ID, planned.split, planned.emission); err != nil {
+			return nil, err
+		}
+		maps.Copy(changes, rideChanges(before, updated))
+	}
+	split := planned.split
+	if split == nil {
+		passengers, err := s.rideRepository.FindPassengers(ctx, []int32{before.ID})
+		if err != nil {
+			return nil, err
+		}
+		split = storedFares(before, passengers)
+	}
+	return split, recordChange(ctx, s.changeHistoryService, models.EntityRide, before.ID, models.ChangeUpdated, changes)
+}
+
+func storedFares(ride *models.Ride, passengers []*models.RidePassenger) *models.FareSplit {
+	split := &models.FareSplit{
+		RideID:  ride.ID,
+		Total:   ride.Cost,
+		Ceiling: ride.CostCeiling,
+		Shares:  []*models.PassengerFare{},
+	}
+	for _, passenger := range passengers {
+		if passenger.Role == models.RideRolePassenger {
+			split.Shares = append(split.Shares, &models.PassengerFare{
+				UserID:         passenger.UserID,
+				DistanceMeters: passenger.DistanceMeters,
+				Fare:           passenger.Fare,
+			})
+		}
+	}
+	return split
+}
+
+func storedEmission(ride *models.Ride, passengers []*models.RidePassenger) *models.RideEmission {
+	emission := &models.RideEmission{
+		RideID:     ride.ID,
+		EmissionKg: ride.Co2EmissionKg,
+		SavedKg:    ride.Co2SavedKg,
+		Passengers: []*models.PassengerEmission{},
+	}
+	for _, passenger := range passengers {
+		if passenger.Role == models.RideRolePassenger {
+			emission.Passengers = append(emission.Passengers, &models.PassengerEmission{
+				UserID:         passenger.UserID,
+				DistanceMeters: passenger.DistanceMeters,
+				SavedKg:        passenger.Co2SavedKg,
+			})
+		}
+	}
+	return emission
+}
+
+// authorizeDriver loads the ride and checks the authenticated user may act
+// on behalf of its driver.
+func (s *RideService) authorizeDriver(ctx context.Context, id int32) (*models.Ride, error) {
+	ride, err := s.rideRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := resolveActor(ctx, s.UserService, ride.DriverID, canAcceptRides); err != nil {
+		return nil, err
+	}
+	return ride, nil
+}
+
+func (s *RideService) publish(ctx context.Context, eventType string, rideId int32, userId string) {
+	if s.rideEventService == nil {
+		return
+	}
+	s.rideEventService.Publish(ctx, &models.RideEvent{
+		Type:   eventType,
+		RideID: rideId,
+		UserID: userId,
+	})
 }
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"go.uber.org/zap"
+)
+
+const rideEventBufferSize = 64
+
+// RideEventService fans out ride and ride request changes to in-process
+// subscribers. Slow subscribers lose events instead of blocking the
+// publisher.
+type RideEventService struct {
+	mu          sync.RWMutex
+	subscribers map[chan *models.RideEvent]struct{}
+}
+
+func NewRideEventService() in.RideEventService {
+	return &RideEventService{
+		subscribers: make(map[chan *models.RideEvent]struct{}),
+	}
+}
+
+func (s *RideEventService) Publish(ctx context.Context, event *models.RideEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			logger.Info("dropping ride event for slow subscriber", zap.String("type", event.Type), zap.Int32("rideId", event.RideID))
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every published event until ctx is
+// done, at which point the channel is closed.
+func (s *RideEventService) Subscribe(ctx context.Context) <-chan *models.RideEvent {
+	ch := make(chan *models.RideEvent, rideEventBufferSize)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.subscribers, ch)
+		close(ch)
+		s.mu.Unlock()
+	}()
+
+	return ch
+}
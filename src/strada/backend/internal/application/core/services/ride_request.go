@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
@@ -12,6 +13,10 @@ type RideRequestService struct {
 	rideRequestRepository out.RideRequestRepository
 	rideRpository         in.RideService
 	UserService           in.UserService
+	rideEventService      in.RideEventService
+	transactionManager    out.TransactionManager
+	changeHistoryService  in.ChangeHistoryService
+	schoolService         in.SchoolService
 }
 
 func NewRideRequestService(rideRequestRepository out.RideRequestRepository) in.RideRequestService {
@@ -28,13 +33,49 @@ func (s *RideRequestService) SetUserService(userService in.UserService) {
 	s.UserService = userService
 }
 
+func (s *RideRequestService) SetRideEventService(rideEventService in.RideEventService) {
+	s.rideEventService = rideEventService
+}
+
+func (s *RideRequestService) SetTransactionManager(transactionManager out.TransactionManager) {
+	s.transactionManager = transactionManager
+}
+
+func (s *RideRequestService) SetChangeHistoryService(changeHistoryService in.ChangeHistoryService) {
+	s.changeHistoryService = changeHistoryService
+}
+
+func (s *RideRequestService) SetSchoolService(schoolService in.SchoolService) {
+	s.schoolService = schoolService
+}
+
 func (s *RideRequestService) Create(ctx context.Context, rideRequest *models.RideRequest) (*models.RideRequest, error) {
-	user, err := s.UserService.FindById(ctx, rideRequest.PassengerID)
+	passengerId, err := resolveActor(ctx, s.UserService, rideRequest.PassengerID, canRequestRides)
 	if err != nil {
 		return nil, err
 	}
+	user, err := s.UserService.FindById(ctx, passengerId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDropoff(ctx, s.schoolService, rideRequest.SchoolID, rideRequest.Destination); err != nil {
+		return nil, err
+	}
 	rideRequest.PassengerID = user.ID
-	return s.rideRequestRepository.Create(ctx, rideRequest)
+	var created *models.RideRequest
+	err = withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		var err error
+		created, err = s.rideRequestRepository.Create(ctx, rideRequest)
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRideRequest, created.ID, models.ChangeCreated, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideRequestEventCreated, created.ID, created.PassengerID)
+	return created, nil
 }
 
 func (s *RideRequestService) FindById(ctx context.Context, id int32) (*models.RideRequest, error) {
@@ -45,6 +86,36 @@ func (s *RideRequestService) FindAll(ctx context.Context) ([]*models.RideRequest
 	return s.rideRequestRepository.FindAll(ctx)
 }
 
+func (s *RideRequestService) List(ctx context.Context, filter models.RideRequestFilter) (*models.Page[*models.RideRequest], error) {
+	if filter.SortBy == "" {
+		filter.SortBy = models.RideRequestSortRideDatetime
+	}
+
+	var cursorValue func(rideRequest *models.RideRequest) string
+	switch filter.SortBy {
+	case models.RideRequestSortRideDatetime:
+		cursorValue = func(rideRequest *models.RideRequest) string { return rideRequest.RideDatetime.Format(time.RFC3339Nano) }
+	case models.RideRequestSortID:
+		cursorValue = func(rideRequest *models.RideRequest) string { return "" }
+	default:
+		return nil, models.ErrInvalidSort
+	}
+
+	after, err := pageCursor(filter.Cursor, filter.SortBy, filter.Descending)
+	if err != nil {
+		return nil, err
+	}
+	limit := pageLimit(filter.Limit)
+
+	rideRequests, err := s.rideRequestRepository.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return buildPage(rideRequests, limit, func(rideRequest *models.RideRequest) *models.Cursor {
+		return &models.Cursor{SortBy: filter.SortBy, Descending: filter.Descending, Value: cursorValue(rideRequest), ID: rideRequest.ID}
+	}), nil
+}
+
 func (s *RideRequestService) FindNear(ctx context.Context, rideId int32) ([]*models.RideRequest, error) {
 	ride, err := s.rideRpository.FindById(ctx, rideId)
 
@@ -59,13 +130,88 @@ func (s *RideRequestService) FindNear(ctx context.Context, rideId int32) ([]*mod
 		}
 	}
 
-	return s.rideRequestRepository.FindNear(ctx, locations)
+	rideRequests, err := s.rideRequestRepository.FindNear(ctx, locations, ride.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Requests over the driver's detour budget stay listed but flagged, since
+	// the driver may still accept them.
+	detours, err := s.rideRpository.Detours(ctx, ride, rideRequests)
+	if err != nil {
+		return nil, err
+	}
+	for i, detour := range detours {
+		rideRequests[i].Detour = detour
+	}
+	return rideRequests, nil
 }
 
 func (s *RideRequestService) Update(ctx context.Context, id int32, rideRequest *models.RideRequest) (*models.RideRequest, error) {
-	return s.rideRequestRepository.Update(ctx, id, rideRequest)
+	if err := checkDropoff(ctx, s.schoolService, rideRequest.SchoolID, rideRequest.Destination); err != nil {
+		return nil, err
+	}
+	var updated *models.RideRequest
+	requestedVersion := rideRequest.Version
+	err := withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		existing, err := s.authorizePassenger(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rideRequest.Version, err = baseVersion(requestedVersion, existing.Version); err != nil {
+			return err
+		}
+		rideRequest.PassengerID = existing.PassengerID
+		updated, err = s.rideRequestRepository.Update(ctx, id, rideRequest)
+		if err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRideRequest, id, models.ChangeUpdated, rideRequestChanges(existing, updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx, models.RideRequestEventUpdated, id, rideRequest.PassengerID)
+	return updated, nil
 }
 
 func (s *RideRequestService) Delete(ctx context.Context, id int32) error {
-	return s.rideRequestRepository.Delete(ctx, id)
+	err := withinTransaction(ctx, s.transactionManager, func(ctx context.Context) error {
+		if _, err := s.authorizePassenger(ctx, id); err != nil {
+			return err
+		}
+		if err := s.rideRequestRepository.Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordChange(ctx, s.changeHistoryService, models.EntityRideRequest, id, models.ChangeDeleted, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.publish(ctx, models.RideRequestEventDeleted, id, "")
+	return nil
+}
+
+// authorizePassenger loads the ride request and checks the authenticated
+// user may act on behalf of its passenger.
+func (s *RideRequestService) authorizePassenger(ctx context.Context, id int32) (*models.RideRequest, error) {
+	rideRequest, err := s.rideRequestRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := resolveActor(ctx, s.UserService, rideRequest.PassengerID, canRequestRides); err != nil {
+		return nil, err
+	}
+	return rideRequest, nil
+}
+
+func (s *RideRequestService) publish(ctx context.Context, eventType string, rideRequestId int32, userId string) {
+	if s.rideEventService == nil {
+		return
+	}
+	s.rideEventService.Publish(ctx, &models.RideEvent{
+		Type:          eventType,
+		RideRequestID: rideRequestId,
+		UserID:        userId,
+	})
 }
@@ -0,0 +1,246 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	"github.com/244Walyson/shared-ride/internal/adapters/out/routing"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/core/services"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// countingRouter counts the matrices asked for, one per planned itinerary.
+type countingRouter struct {
+	out.RoutingProvider
+	matrices atomic.Int32
+}
+
+func (r *countingRouter) Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error) {
+	r.matrices.Add(1)
+	return r.RoutingProvider.Matrix(ctx, points)
+}
+
+func TestFindNearRoutesOnlyPlausibleRides(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}, {ID: "passenger"}}, nil))
+	router := &countingRouter{RoutingProvider: routing.NewHaversineRouter(1.3, 40)}
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideService.SetRoutingProvider(router)
+	rideRequestService := services.NewRideRequestService(memory.NewRideRequestRepository())
+	rideRequestService.SetUserService(userService)
+	rideService.SetRideRequestService(rideRequestService)
+
+	// The request goes about 2.2 km north.
+	home := models.Location{Latitude: -23.550, Longitude: -46.630}
+	school := models.Location{Latitude: -23.530, Longitude: -46.630}
+	driverCtx := asUser(t.Context(), "driver")
+	create := func(ride *models.Ride) *models.Ride {
+		t.Helper()
+		ride.Cost = models.NewMoney(0, models.DefaultCurrency)
+		created, err := rideService.Create(driverCtx, ride)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return created
+	}
+	sameWay := create(&models.Ride{StartPoint: home, EndPoint: school, MaxDetourMeters: 1000})
+	// Heading 3 km south, picking the passenger up adds at least 3 km more
+	// than the whole ride, which no road route can beat.
+	otherWay := create(&models.Ride{StartPoint: home, EndPoint: models.Location{Latitude: -23.577, Longitude: -46.630}, MaxDetourMeters: 1000})
+	unbounded := create(&models.Ride{StartPoint: home, EndPoint: models.Location{Latitude: -23.577, Longitude: -46.631}})
+
+	rideRequest, err := rideRequestService.Create(asUser(t.Context(), "passenger"), &models.RideRequest{Origin: home, Destination: school})
+	if err != nil {
+		t.Fatalf("Create ride request: %v", err)
+	}
+
+	router.matrices.Store(0)
+	near, err := rideService.FindNear(t.Context(), rideRequest.ID)
+	if err != nil {
+		t.Fatalf("FindNear: %v", err)
+	}
+	var got []int32
+	for _, ride := range near {
+		got = append(got, ride.ID)
+	}
+	slices.Sort(got)
+	if want := []int32{sameWay.ID, unbounded.ID}; !slices.Equal(got, want) {
+		t.Errorf("near = %v, want %v without %d", got, want, otherWay.ID)
+	}
+	// Only the ride going the same way is planned, with and without the
+	// passenger.
+	if n := router.matrices.Load(); n != 2 {
+		t.Errorf("%d itineraries planned, want 2", n)
+	}
+}
+
+// noRouteRouter finds no route once fail is set.
+type noRouteRouter struct {
+	out.RoutingProvider
+	fail atomic.Bool
+}
+
+func (r *noRouteRouter) Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error) {
+	if r.fail.Load() {
+		return nil, models.ErrNoRoute
+	}
+	return r.RoutingProvider.Matrix(ctx, points)
+}
+
+func TestFindNearKeepsRidesItCouldNotCheck(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}, {ID: "passenger"}}, nil))
+	router := &noRouteRouter{RoutingProvider: routing.NewHaversineRouter(1.3, 40)}
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideService.SetRoutingProvider(router)
+	rideRequestService := services.NewRideRequestService(memory.NewRideRequestRepository())
+	rideRequestService.SetUserService(userService)
+	rideService.SetRideRequestService(rideRequestService)
+
+	home := models.Location{Latitude: -23.550, Longitude: -46.630}
+	school := models.Location{Latitude: -23.530, Longitude: -46.630}
+	ride, err := rideService.Create(asUser(t.Context(), "driver"), &models.Ride{
+		StartPoint: home, EndPoint: school, MaxDetourMeters: 1000, Cost: models.NewMoney(0, models.DefaultCurrency),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	rideRequest, err := rideRequestService.Create(asUser(t.Context(), "passenger"), &models.RideRequest{Origin: home, Destination: school})
+	if err != nil {
+		t.Fatalf("Create ride request: %v", err)
+	}
+
+	near, err := rideService.FindNear(t.Context(), rideRequest.ID)
+	if err != nil {
+		t.Fatalf("FindNear: %v", err)
+	}
+	if len(near) != 1 || near[0].DetourUnchecked {
+		t.Fatalf("near = %+v, want ride %d checked", near, ride.ID)
+	}
+
+	router.fail.Store(true)
+	near, err = rideService.FindNear(t.Context(), rideRequest.ID)
+	if err != nil {
+		t.Fatalf("FindNear: %v", err)
+	}
+	if len(near) != 1 || near[0].ID != ride.ID || !near[0].DetourUnchecked {
+		t.Errorf("near without routes = %+v, want ride %d flagged unchecked", near, ride.ID)
+	}
+}
+
+// hookRouter runs hook before the first matrix it is asked for.
+type hookRouter struct {
+	out.RoutingProvider
+	hook func()
+}
+
+func (r *hookRouter) Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error) {
+	if hook := r.hook; hook != nil {
+		r.hook = nil
+		hook()
+	}
+	return r.RoutingProvider.Matrix(ctx, points)
+}
+
+func TestUpdatePlansAgainWhenPassengersChange(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}, {ID: "passenger"}}, nil))
+	router := &hookRouter{RoutingProvider: routing.NewHaversineRouter(1.3, 40)}
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideService.SetRoutingProvider(router)
+
+	home := models.Location{Latitude: -23.550, Longitude: -46.630}
+	school := models.Location{Latitude: -23.530, Longitude: -46.630}
+	driverCtx := asUser(t.Context(), "driver")
+	ride, err := rideService.Create(driverCtx, &models.Ride{StartPoint: home, EndPoint: school, Cost: models.NewMoney(0, models.DefaultCurrency)})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// A passenger joins while the update is being routed, so the first plan,
+	// made without them, must not be stored.
+	router.hook = func() {
+		_, err := rideService.Join(asUser(t.Context(), "passenger"), ride.ID, &models.RidePassenger{
+			StartPoint: models.Location{Latitude: -23.545, Longitude: -46.635},
+			EndPoint:   models.Location{Latitude: -23.535, Longitude: -46.635},
+		})
+		if err != nil {
+			t.Errorf("Join: %v", err)
+		}
+	}
+	update := *ride
+	update.Version = 0
+	update.Description = "updated"
+	if _, err := rideService.Update(driverCtx, ride.ID, &update); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	itinerary, err := rideService.Itinerary(t.Context(), ride.ID)
+	if err != nil {
+		t.Fatalf("Itinerary: %v", err)
+	}
+	var kinds []string
+	for _, stop := range itinerary.Stops {
+		kinds = append(kinds, stop.Kind)
+	}
+	want := []string{models.RideStopStart, models.RideStopPickup, models.RideStopDropoff, models.RideStopEnd}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("stops = %v, want %v", kinds, want)
+	}
+
+	// A client that asked for the version it read gets a conflict instead.
+	router.hook = func() {
+		if _, err := rideService.Leave(asUser(t.Context(), "passenger"), ride.ID, "passenger"); err != nil {
+			t.Errorf("Leave: %v", err)
+		}
+	}
+	current, err := rideService.FindById(t.Context(), ride.ID)
+	if err != nil {
+		t.Fatalf("FindById: %v", err)
+	}
+	update = *current
+	if _, err := rideService.Update(driverCtx, ride.ID, &update); !errors.Is(err, models.ErrVersionConflict) {
+		t.Errorf("Update err = %v, want ErrVersionConflict", err)
+	}
+}
+
+func TestJoinPricesTheRoutedDistance(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}, {ID: "passenger"}}, nil))
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideService.SetPricingService(services.NewPricingService(models.NewMoney(60, models.DefaultCurrency)))
+	// Every road is 1.3 times the straight line.
+	rideService.SetRoutingProvider(routing.NewHaversineRouter(1.3, 40))
+
+	ride, err := rideService.Create(asUser(t.Context(), "driver"), &models.Ride{
+		StartPoint: models.Location{Latitude: -23.550, Longitude: -46.630},
+		EndPoint:   models.Location{Latitude: -23.530, Longitude: -46.630},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	pickup := models.Location{Latitude: -23.545, Longitude: -46.630}
+	dropoff := models.Location{Latitude: -23.535, Longitude: -46.630}
+	split, err := rideService.Join(asUser(t.Context(), "passenger"), ride.ID, &models.RidePassenger{StartPoint: pickup, EndPoint: dropoff})
+	if err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	want := models.HaversineMeters(pickup, dropoff) * 1.3
+	if got := split.Shares[0].DistanceMeters; math.Abs(got-want) > 0.01 {
+		t.Errorf("share distance = %v, want the routed %v", got, want)
+	}
+	fares, err := rideService.Fares(t.Context(), ride.ID)
+	if err != nil {
+		t.Fatalf("Fares: %v", err)
+	}
+	if fares.Shares[0].Fare != split.Shares[0].Fare {
+		t.Errorf("stored fare = %v, want %v", fares.Shares[0].Fare, split.Shares[0].Fare)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type SchoolService struct {
+	schoolRepository out.SchoolRepository
+}
+
+func NewSchoolService(schoolRepository out.SchoolRepository) in.SchoolService {
+	return &SchoolService{
+		schoolRepository: schoolRepository,
+	}
+}
+
+// Create registers a school. Only admins maintain the registry.
+func (s *SchoolService) Create(ctx context.Context, school *models.School) (*models.School, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := school.Validate(); err != nil {
+		return nil, err
+	}
+	return s.schoolRepository.Create(ctx, school)
+}
+
+func (s *SchoolService) FindById(ctx context.Context, id int32) (*models.School, error) {
+	return s.schoolRepository.FindById(ctx, id)
+}
+
+func (s *SchoolService) FindAll(ctx context.Context, name string) ([]*models.School, error) {
+	return s.schoolRepository.FindAll(ctx, name)
+}
+
+func (s *SchoolService) Update(ctx context.Context, id int32, school *models.School) (*models.School, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := school.Validate(); err != nil {
+		return nil, err
+	}
+	return s.schoolRepository.Update(ctx, id, school)
+}
+
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return models.ErrUnauthenticated
+	}
+	if !principal.HasRole(models.RoleAdmin) {
+		return models.ErrForbidden
+	}
+	return nil
+}
+
+// checkDropoff requires the drop-off of a ride or ride request going to a
+// school to be on its campus. Without a school service no school is known.
+func checkDropoff(ctx context.Context, schoolService in.SchoolService, schoolId int32, dropoff models.Location) error {
+	if schoolId == 0 {
+		return nil
+	}
+	if schoolService == nil {
+		return models.ErrSchoolNotFound
+	}
+	school, err := schoolService.FindById(ctx, schoolId)
+	if err != nil {
+		return err
+	}
+	if !school.Covers(dropoff) {
+		return models.ErrOutsideCampus
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/244Walyson/shared-ride/configs/logger"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+	"go.uber.org/zap"
+)
+
+type SeriesService struct {
+	rideSeriesRepository        out.RideSeriesRepository
+	rideRequestSeriesRepository out.RideRequestSeriesRepository
+	// horizon is how far ahead occurrences are created.
+	horizon            time.Duration
+	rideService        in.RideService
+	rideRequestService in.RideRequestService
+	userService        in.UserService
+	schoolService      in.SchoolService
+}
+
+func NewSeriesService(rideSeriesRepository out.RideSeriesRepository, rideRequestSeriesRepository out.RideRequestSeriesRepository, horizon time.Duration) in.SeriesService {
+	return &SeriesService{
+		rideSeriesRepository:        rideSeriesRepository,
+		rideRequestSeriesRepository: rideRequestSeriesRepository,
+		horizon:                     horizon,
+	}
+}
+
+func (s *SeriesService) SetRideService(rideService in.RideService) {
+	s.rideService = rideService
+}
+
+func (s *SeriesService) SetRideRequestService(rideRequestService in.RideRequestService) {
+	s.rideRequestService = rideRequestService
+}
+
+func (s *SeriesService) SetUserService(userService in.UserService) {
+	s.userService = userService
+}
+
+func (s *SeriesService) SetSchoolService(schoolService in.SchoolService) {
+	s.schoolService = schoolService
+}
+
+func (s *SeriesService) CreateRideSeries(ctx context.Context, series *models.RideSeries) (*models.RideSeries, error) {
+	driverId, err := resolveActor(ctx, s.userService, series.DriverID, canAcceptRides)
+	if err != nil {
+		return nil, err
+	}
+	if err := series.Recurrence.Validate(); err != nil {
+		return nil, err
+	}
+	if series.Ride.MaxDetourMeters < 0 || series.Ride.MaxDetourMs < 0 {
+		return nil, models.ErrInvalidDetourBudget
+	}
+	// Checked up front, otherwise every occurrence would fail.
+	if err := checkDropoff(ctx, s.schoolService, series.Ride.SchoolID, series.Ride.EndPoint); err != nil {
+		return nil, err
+	}
+	series.DriverID = driverId
+	series.Ride.DriverID = driverId
+	now := time.Now().UTC()
+	series.MaterializedUntil = now
+
+	created, err := s.rideSeriesRepository.Create(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.materializeRideSeries(ctx, created, now.Add(s.horizon)); err != nil {
+		return nil, err
+	}
+	return s.FindRideSeries(ctx, created.ID)
+}
+
+func (s *SeriesService) FindRideSeries(ctx context.Context, id int32) (*models.RideSeries, error) {
+	series, err := s.rideSeriesRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if series.Upcoming, err = s.rideSeriesRepository.FindOccurrences(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (s *SeriesService) CreateRideRequestSeries(ctx context.Context, series *models.RideRequestSeries) (*models.RideRequestSeries, error) {
+	passengerId, err := resolveActor(ctx, s.userService, series.PassengerID, canRequestRides)
+	if err != nil {
+		return nil, err
+	}
+	if err := series.Recurrence.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkDropoff(ctx, s.schoolService, series.RideRequest.SchoolID, series.RideRequest.Destination); err != nil {
+		return nil, err
+	}
+	series.PassengerID = passengerId
+	series.RideRequest.PassengerID = passengerId
+	series.RideSeriesID = 0
+	now := time.Now().UTC()
+	series.MaterializedUntil = now
+
+	created, err := s.rideRequestSeriesRepository.Create(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.materializeRideRequestSeries(ctx, created, now.Add(s.horizon)); err != nil {
+		return nil, err
+	}
+	return s.FindRideRequestSeries(ctx, created.ID)
+}
+
+func (s *SeriesService) FindRideRequestSeries(ctx context.Context, id int32) (*models.RideRequestSeries, error) {
+	series, err := s.rideRequestSeriesRepository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if series.Upcoming, err = s.rideRequestSeriesRepository.FindOccurrences(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// Match pairs a ride request series with a ride series: every upcoming ride
+// request occurrence joins the ride of the same day, and later occurrences
+// join as they are created.
+func (s *SeriesService) Match(ctx context.Context, rideRequestSeriesId int32, rideSeriesId int32) (*models.SeriesMatch, error) {
+	requestSeries, err := s.rideRequestSeriesRepository.FindById(ctx, rideRequestSeriesId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := resolveActor(ctx, s.userService, requestSeries.PassengerID, canRequestRides); err != nil {
+		return nil, err
+	}
+	rideSeries, err := s.rideSeriesRepository.FindById(ctx, rideSeriesId)
+	if err != nil {
+		return nil, err
+	}
+	if rideSeries.DriverID == requestSeries.PassengerID {
+		return nil, models.ErrDriverCannotJoin
+	}
+	if !recurrencesMatch(&rideSeries.Recurrence, &requestSeries.Recurrence) {
+		return nil, models.ErrSeriesMismatch
+	}
+
+	if err := s.rideRequestSeriesRepository.SetRideSeries(ctx, requestSeries.ID, rideSeries.ID); err != nil {
+		return nil, err
+	}
+	requestSeries.RideSeriesID = rideSeries.ID
+	return s.pair(ctx, requestSeries, rideSeries)
+}
+
+// Materialize goes through the ride series first, so ride request occurrences
+// find the rides of their day.
+func (s *SeriesService) Materialize(ctx context.Context, now time.Time) error {
+	until := now.Add(s.horizon)
+	var errs []error
+
+	rideSeries, err := s.rideSeriesRepository.FindDue(ctx, until)
+	if err != nil {
+		return err
+	}
+	for _, series := range rideSeries {
+		errs = append(errs, s.materializeRideSeries(ctx, series, until))
+	}
+
+	requestSeries, err := s.rideRequestSeriesRepository.FindDue(ctx, until)
+	if err != nil {
+		return err
+	}
+	for _, series := range requestSeries {
+		errs = append(errs, s.materializeRideRequestSeries(ctx, series, until))
+	}
+	return errors.Join(errs...)
+}
+
+// materializeRideSeries creates the rides outside of any transaction, since
+// creating one plans its route and publishes it. Each departure is claimed
+// first so concurrent runs create it once.
+func (s *SeriesService) materializeRideSeries(ctx context.Context, series *models.RideSeries, until time.Time) error {
+	departures, err := series.Recurrence.Occurrences(series.MaterializedUntil, until)
+	if err != nil {
+		return err
+	}
+	existing, err := s.rideSeriesRepository.FindOccurrences(ctx, series.ID, series.MaterializedUntil)
+	if err != nil {
+		return err
+	}
+
+	// Occurrences are created as the driver, like a ride they post.
+	ownerCtx := asUser(ctx, series.DriverID)
+	materializedUntil := until
+	for _, departure := range departures {
+		if hasOccurrence(existing, departure) {
+			continue
+		}
+		claimed, err := s.rideSeriesRepository.ClaimOccurrence(ctx, series.ID, departure, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			materializedUntil = claimedElsewhere(materializedUntil, departure)
+			continue
+		}
+		ride := *series.Ride
+		ride.StopPoints = slices.Clone(series.Ride.StopPoints)
+		created, err := s.rideService.Create(ownerCtx, &ride)
+		if err != nil {
+			return errors.Join(err, s.rideSeriesRepository.ReleaseOccurrence(context.WithoutCancel(ctx), series.ID, departure))
+		}
+		if err := s.rideSeriesRepository.SetOccurrenceRide(ctx, series.ID, departure, created.ID); err != nil {
+			return err
+		}
+	}
+	return s.rideSeriesRepository.SetMaterializedUntil(ctx, series.ID, materializedUntil)
+}
+
+// materializeRideRequestSeries creates the ride requests like
+// materializeRideSeries, then joins the matched ride series.
+func (s *SeriesService) materializeRideRequestSeries(ctx context.Context, series *models.RideRequestSeries, until time.Time) error {
+	departures, err := series.Recurrence.Occurrences(series.MaterializedUntil, until)
+	if err != nil {
+		return err
+	}
+	existing, err := s.rideRequestSeriesRepository.FindOccurrences(ctx, series.ID, series.MaterializedUntil)
+	if err != nil {
+		return err
+	}
+
+	ownerCtx := asUser(ctx, series.PassengerID)
+	materializedUntil := until
+	for _, departure := range departures {
+		if hasOccurrence(existing, departure) {
+			continue
+		}
+		claimed, err := s.rideRequestSeriesRepository.ClaimOccurrence(ctx, series.ID, departure, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			materializedUntil = claimedElsewhere(materializedUntil, departure)
+			continue
+		}
+		rideRequest := *series.RideRequest
+		rideRequest.RideDatetime = departure
+		created, err := s.rideRequestService.Create(ownerCtx, &rideRequest)
+		if err != nil {
+			return errors.Join(err, s.rideRequestSeriesRepository.ReleaseOccurrence(context.WithoutCancel(ctx), series.ID, departure))
+		}
+		if err := s.rideRequestSeriesRepository.SetOccurrenceRideRequest(ctx, series.ID, departure, created.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.rideRequestSeriesRepository.SetMaterializedUntil(ctx, series.ID, materializedUntil); err != nil {
+		return err
+	}
+
+	if series.RideSeriesID == 0 {
+		return nil
+	}
+	rideSeries, err := s.rideSeriesRepository.FindById(ctx, series.RideSeriesID)
+	if err != nil {
+		return err
+	}
+	_, err = s.pair(ctx, series, rideSeries)
+	return err
+}
+
+// pair joins every upcoming ride request occurrence without a ride to the
+// ride series occurrence of the same local day. Occurrences that cannot join,
+// say because the ride is full, are left unpaired and tried again on the next
+// run instead of failing the others.
+func (s *SeriesService) pair(ctx context.Context, requestSeries *models.RideRequestSeries, rideSeries *models.RideSeries) (*models.SeriesMatch, error) {
+	now := time.Now()
+	rides, err := s.rideSeriesRepository.FindOccurrences(ctx, rideSeries.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := s.rideRequestSeriesRepository.FindOccurrences(ctx, requestSeries.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	rideLocation, err := time.LoadLocation(rideSeries.Recurrence.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	requestLocation, err := time.LoadLocation(requestSeries.Recurrence.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	ridesByDay := make(map[time.Time]int32, len(rides))
+	for _, ride := range rides {
+		ridesByDay[models.Date(ride.DepartsAt.In(rideLocation))] = ride.RideID
+	}
+
+	match := &models.SeriesMatch{RideSeriesID: rideSeries.ID, RideRequestSeriesID: requestSeries.ID}
+	passengerCtx := asUser(ctx, requestSeries.PassengerID)
+	for _, occurrence := range requests {
+		if occurrence.RideID == 0 {
+			rideId, ok := ridesByDay[models.Date(occurrence.DepartsAt.In(requestLocation))]
+			if !ok {
+				match.Unpaired = append(match.Unpaired, occurrence)
+				continue
+			}
+			_, err := s.rideService.Join(passengerCtx, rideId, &models.RidePassenger{
+				UserID:     requestSeries.PassengerID,
+				StartPoint: requestSeries.RideRequest.Origin,
+				EndPoint:   requestSeries.RideRequest.Destination,
+			})
+			if err != nil && !errors.Is(err, models.ErrAlreadyJoined) {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				logger.Info("series occurrence could not join its ride",
+					zap.Int32("rideRequestSeriesId", requestSeries.ID), zap.Int32("rideId", rideId), zap.Error(err))
+				match.Unpaired = append(match.Unpaired, occurrence)
+				continue
+			}
+			// A passenger who joined without the occurrence being recorded
+			// gets ErrAlreadyJoined next time, so this is retried as well.
+			if err := s.rideRequestSeriesRepository.SetOccurrenceRide(ctx, requestSeries.ID, occurrence.DepartsAt, rideId); err != nil {
+				return nil, err
+			}
+			occurrence.RideID = rideId
+		}
+		match.Paired = append(match.Paired, occurrence)
+	}
+	return match, nil
+}
+
+// recurrencesMatch requires overlapping dates, a shared weekday and departures
+// within models.RecurrenceMatchWindow of each other.
+func recurrencesMatch(ride *models.Recurrence, request *models.Recurrence) bool {
+	if !ride.EndDate.IsZero() && ride.EndDate.Before(request.StartDate) ||
+		!request.EndDate.IsZero() && request.EndDate.Before(ride.StartDate) {
+		return false
+	}
+	if !slices.ContainsFunc(request.Weekdays, func(weekday time.Weekday) bool { return slices.Contains(ride.Weekdays, weekday) }) {
+		return false
+	}
+
+	rideLocation, err := time.LoadLocation(ride.TimeZone)
+	if err != nil {
+		return false
+	}
+	requestLocation, err := time.LoadLocation(request.TimeZone)
+	if err != nil {
+		return false
+	}
+	day := ride.StartDate
+	if request.StartDate.After(day) {
+		day = request.StartDate
+	}
+	departure := func(minutes int, location *time.Location) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, location)
+	}
+	gap := departure(ride.DepartureMinutes, rideLocation).Sub(departure(request.DepartureMinutes, requestLocation))
+	return gap.Abs() <= models.RecurrenceMatchWindow
+}
+
+// claimedElsewhere keeps the series materialized only up to the first
+// departure another run is still creating, so the next run looks at it again
+// and takes it over if that run never finished.
+func claimedElsewhere(materializedUntil time.Time, departure time.Time) time.Time {
+	if departure.Before(materializedUntil) {
+		return departure
+	}
+	return materializedUntil
+}
+
+func hasOccurrence(occurrences []*models.Occurrence, departsAt time.Time) bool {
+	return slices.ContainsFunc(occurrences, func(occurrence *models.Occurrence) bool { return occurrence.DepartsAt.Equal(departsAt) })
+}
+
+// asUser acts as the owner of a series, for the occurrences created in the
+// background.
+func asUser(ctx context.Context, userId string) context.Context {
+	return models.ContextWithPrincipal(ctx, &models.Principal{UserID: userId})
+}
@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/core/services"
+)
+
+func TestMatchLeavesFailedJoinsUnpaired(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}, {ID: "passenger"}}, nil))
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideRequestService := services.NewRideRequestService(memory.NewRideRequestRepository())
+	rideRequestService.SetUserService(userService)
+	seriesService := services.NewSeriesService(memory.NewRideSeriesRepository(), memory.NewRideRequestSeriesRepository(), 7*24*time.Hour)
+	seriesService.SetRideService(rideService)
+	seriesService.SetRideRequestService(rideRequestService)
+	seriesService.SetUserService(userService)
+
+	recurrence := models.Recurrence{
+		Weekdays:         []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+		DepartureMinutes: 12 * 60,
+		TimeZone:         "UTC",
+		StartDate:        models.Date(time.Now().UTC()),
+	}
+	home := models.Location{Latitude: -23.55, Longitude: -46.63}
+	school := models.Location{Latitude: -23.56, Longitude: -46.65}
+
+	driverCtx := asUser(t.Context(), "driver")
+	rideSeries, err := seriesService.CreateRideSeries(driverCtx, &models.RideSeries{
+		Ride:       &models.Ride{StartPoint: home, EndPoint: school, Cost: models.NewMoney(0, models.DefaultCurrency)},
+		Recurrence: recurrence,
+	})
+	if err != nil {
+		t.Fatalf("CreateRideSeries: %v", err)
+	}
+	if len(rideSeries.Upcoming) < 2 {
+		t.Fatalf("ride series has %d upcoming occurrences, want at least 2", len(rideSeries.Upcoming))
+	}
+	// Nobody can join a completed ride.
+	closed := rideSeries.Upcoming[0]
+	if _, err := rideService.Complete(driverCtx, closed.RideID); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	passengerCtx := asUser(t.Context(), "passenger")
+	requestSeries, err := seriesService.CreateRideRequestSeries(passengerCtx, &models.RideRequestSeries{
+		RideRequest: &models.RideRequest{Origin: home, Destination: school},
+		Recurrence:  recurrence,
+	})
+	if err != nil {
+		t.Fatalf("CreateRideRequestSeries: %v", err)
+	}
+
+	match, err := seriesService.Match(passengerCtx, requestSeries.ID, rideSeries.ID)
+	if err != nil {
+		t.Fatalf("Match: %v", err)
+	}
+	if len(match.Unpaired) != 1 || !match.Unpaired[0].DepartsAt.Equal(closed.DepartsAt) {
+		t.Errorf("unpaired = %v, want only the occurrence departing %v", match.Unpaired, closed.DepartsAt)
+	}
+	if len(match.Paired) != len(rideSeries.Upcoming)-1 {
+		t.Errorf("%d paired, want %d", len(match.Paired), len(rideSeries.Upcoming)-1)
+	}
+
+	// The next run tries the unpaired occurrence again without failing.
+	if err := seriesService.Materialize(t.Context(), time.Now()); err != nil {
+		t.Errorf("Materialize: %v", err)
+	}
+}
+
+func TestMaterializeClaimsOccurrences(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}}, nil))
+	rideRepository := memory.NewRideRepository()
+	rideService := services.NewRideService(rideRepository)
+	rideService.SetUserService(userService)
+	rideSeriesRepository := memory.NewRideSeriesRepository()
+	seriesService := services.NewSeriesService(rideSeriesRepository, memory.NewRideRequestSeriesRepository(), 3*24*time.Hour)
+	seriesService.SetRideService(rideService)
+	seriesService.SetUserService(userService)
+
+	now := time.Now().UTC()
+	home := models.Location{Latitude: -23.55, Longitude: -46.63}
+	series, err := rideSeriesRepository.Create(t.Context(), &models.RideSeries{
+		DriverID: "driver",
+		Ride:     &models.Ride{StartPoint: home, EndPoint: home, Cost: models.NewMoney(0, models.DefaultCurrency)},
+		Recurrence: models.Recurrence{
+			Weekdays:         []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+			DepartureMinutes: 12 * 60,
+			TimeZone:         "UTC",
+			StartDate:        models.Date(now),
+		},
+		MaterializedUntil: now,
+	})
+	if err != nil {
+		t.Fatalf("Create series: %v", err)
+	}
+	departures, err := series.Recurrence.Occurrences(now, now.Add(3*24*time.Hour))
+	if err != nil || len(departures) < 2 {
+		t.Fatalf("Occurrences = %v, %v, want at least 2", departures, err)
+	}
+
+	// Another run is still creating the second departure.
+	if _, err := rideSeriesRepository.ClaimOccurrence(t.Context(), series.ID, departures[1], now); err != nil {
+		t.Fatalf("ClaimOccurrence: %v", err)
+	}
+	if err := seriesService.Materialize(t.Context(), now); err != nil {
+		t.Fatalf("Materialize: %v", err)
+	}
+	rides, _ := rideRepository.FindAll(t.Context())
+	if len(rides) != len(departures)-1 {
+		t.Errorf("%d rides, want %d without the claimed departure", len(rides), len(departures)-1)
+	}
+	stored, _ := rideSeriesRepository.FindById(t.Context(), series.ID)
+	if !stored.MaterializedUntil.Equal(departures[1]) {
+		t.Errorf("materialized until %v, want the claimed departure %v", stored.MaterializedUntil, departures[1])
+	}
+
+	// That run never finished: its claim, stood in for by one made long ago,
+	// is taken over once stale.
+	if err := rideSeriesRepository.ReleaseOccurrence(t.Context(), series.ID, departures[1]); err != nil {
+		t.Fatalf("ReleaseOccurrence: %v", err)
+	}
+	if _, err := rideSeriesRepository.ClaimOccurrence(t.Context(), series.ID, departures[1], now.Add(-2*models.OccurrenceClaimTimeout)); err != nil {
+		t.Fatalf("ClaimOccurrence: %v", err)
+	}
+	if err := seriesService.Materialize(t.Context(), now); err != nil {
+		t.Fatalf("Materialize: %v", err)
+	}
+	occurrences, _ := rideSeriesRepository.FindOccurrences(t.Context(), series.ID, now)
+	if len(occurrences) != len(departures) {
+		t.Errorf("%d occurrences, want %d", len(occurrences), len(departures))
+	}
+	rides, _ = rideRepository.FindAll(t.Context())
+	if len(rides) != len(departures) {
+		t.Errorf("%d rides, want one per departure, %d", len(rides), len(departures))
+	}
+}
+
+func TestMaterializeReleasesFailedOccurrences(t *testing.T) {
+	userService := services.NewUserService(memory.NewUserRepository([]*models.User{{ID: "driver"}}, nil))
+	rideService := services.NewRideService(memory.NewRideRepository())
+	rideService.SetUserService(userService)
+	rideSeriesRepository := memory.NewRideSeriesRepository()
+	seriesService := services.NewSeriesService(rideSeriesRepository, memory.NewRideRequestSeriesRepository(), 24*time.Hour)
+	seriesService.SetRideService(rideService)
+	seriesService.SetUserService(userService)
+
+	now := time.Now().UTC()
+	home := models.Location{Latitude: -23.55, Longitude: -46.63}
+	// The ride service rejects the template, so every ride fails.
+	series, err := rideSeriesRepository.Create(t.Context(), &models.RideSeries{
+		DriverID: "driver",
+		Ride:     &models.Ride{StartPoint: home, EndPoint: home, MaxDetourMeters: -1},
+		Recurrence: models.Recurrence{
+			Weekdays:         []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+			DepartureMinutes: 12 * 60,
+			TimeZone:         "UTC",
+			StartDate:        models.Date(now),
+		},
+		MaterializedUntil: now,
+	})
+	if err != nil {
+		t.Fatalf("Create series: %v", err)
+	}
+	departures, err := series.Recurrence.Occurrences(now, now.Add(24*time.Hour))
+	if err != nil || len(departures) == 0 {
+		t.Fatalf("Occurrences = %v, %v, want some", departures, err)
+	}
+
+	if err := seriesService.Materialize(t.Context(), now); !errors.Is(err, models.ErrInvalidDetourBudget) {
+		t.Fatalf("Materialize error = %v, want ErrInvalidDetourBudget", err)
+	}
+	claimed, err := rideSeriesRepository.ClaimOccurrence(t.Context(), series.ID, departures[0], now)
+	if err != nil || !claimed {
+		t.Errorf("ClaimOccurrence after the failed run = %v, %v, want the departure released", claimed, err)
+	}
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type ServiceAuthService struct {
+	VerifyTokenRepository out.VerifyTokenRepository
+	ApiKeyRepository      out.ApiKeyRepository
+}
+
+func NewServiceAuthService(t out.VerifyTokenRepository, k out.ApiKeyRepository) in.ServiceAuthService {
+	return &ServiceAuthService{
+		VerifyTokenRepository: t,
+		ApiKeyRepository:      k,
+	}
+}
+
+// VerifyToken only accepts client-credentials tokens; user tokens are
+// rejected so end users cannot reach internal endpoints.
+func (s *ServiceAuthService) VerifyToken(ctx context.Context, token string) (*models.Principal, error) {
+	principal, err := s.VerifyTokenRepository.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !principal.IsService() {
+		return nil, models.ErrForbidden
+	}
+	return principal, nil
+}
+
+func (s *ServiceAuthService) VerifyApiKey(ctx context.Context, apiKey string) (*models.Principal, error) {
+	return s.ApiKeyRepository.VerifyApiKey(ctx, apiKey)
+}
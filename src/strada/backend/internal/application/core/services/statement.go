@@ -0,0 +1,137 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type StatementService struct {
+	rideRepository    out.RideRepository
+	paymentRepository out.PaymentRepository
+	userService       in.UserService
+}
+
+func NewStatementService(rideRepository out.RideRepository, paymentRepository out.PaymentRepository) in.StatementService {
+	return &StatementService{
+		rideRepository:    rideRepository,
+		paymentRepository: paymentRepository,
+	}
+}
+
+func (s *StatementService) SetUserService(userService in.UserService) {
+	s.userService = userService
+}
+
+// DriverStatement covers the rides the driver completed in [from, to), with
+// the fare of every passenger next to what the ledger shows they paid.
+func (s *StatementService) DriverStatement(ctx context.Context, driverId string, from time.Time, to time.Time) (*models.Statement, error) {
+	driverId, err := resolveActor(ctx, s.userService, driverId, canAcceptRides)
+	if err != nil {
+		return nil, err
+	}
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil, models.ErrInvalidRange
+	}
+
+	statement := &models.Statement{
+		DriverID: driverId,
+		From:     from,
+		To:       to,
+		Rides:    []*models.StatementRide{},
+		Totals:   []*models.StatementAmounts{},
+	}
+	rides, err := s.rideRepository.FindCompleted(ctx, []string{driverId}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	rides = slices.DeleteFunc(rides, func(ride *models.Ride) bool { return ride.DriverID != driverId })
+	if len(rides) == 0 {
+		return statement, nil
+	}
+
+	rideIds := make([]int32, len(rides))
+	for i, ride := range rides {
+		rideIds[i] = ride.ID
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, rideIds)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.paymentRepository.FindByRides(ctx, rideIds)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[int32][]*models.StatementLine)
+	lineOf := func(rideId int32, passengerId string, currency string) *models.StatementLine {
+		for _, line := range lines[rideId] {
+			if line.PassengerID == passengerId {
+				return line
+			}
+		}
+		line := &models.StatementLine{PassengerID: passengerId, StatementAmounts: models.NewStatementAmounts(currency)}
+		lines[rideId] = append(lines[rideId], line)
+		return line
+	}
+	currencies := make(map[int32]string, len(rides))
+	for _, ride := range rides {
+		currencies[ride.ID] = ride.Cost.Currency
+	}
+	for _, passenger := range passengers {
+		if passenger.Role != models.RideRolePassenger {
+			continue
+		}
+		line := lineOf(passenger.RideID, passenger.UserID, currencies[passenger.RideID])
+		line.DistanceMeters = passenger.DistanceMeters
+		line.Fares.Amount = passenger.Fare.Amount
+	}
+	for _, payment := range payments {
+		if payment.Status != models.PaymentStatusSucceeded {
+			continue
+		}
+		line := lineOf(payment.RideID, payment.PassengerID, currencies[payment.RideID])
+		switch payment.Kind {
+		case models.PaymentKindCharge:
+			line.Paid.Amount += payment.Amount.Amount
+		case models.PaymentKindRefund:
+			line.Refunded.Amount += payment.Amount.Amount
+		case models.PaymentKindPayout:
+			line.PaidOut.Amount += payment.Amount.Amount
+		}
+	}
+
+	totals := make(map[string]*models.StatementAmounts)
+	for _, ride := range rides {
+		currency := currencies[ride.ID]
+		statementRide := &models.StatementRide{
+			RideID:      ride.ID,
+			CompletedAt: ride.CompletedAt,
+			Passengers:  []*models.StatementLine{},
+			Totals:      models.NewStatementAmounts(currency),
+		}
+		for _, line := range lines[ride.ID] {
+			line.Contributed.Amount = line.Paid.Amount - line.Refunded.Amount
+			line.Outstanding.Amount = max(line.Fares.Amount-line.Paid.Amount, 0)
+			statementRide.Passengers = append(statementRide.Passengers, line)
+			statementRide.Totals.Add(line.StatementAmounts)
+		}
+		statement.Rides = append(statement.Rides, statementRide)
+
+		if totals[currency] == nil {
+			amounts := models.NewStatementAmounts(currency)
+			totals[currency] = &amounts
+			statement.Totals = append(statement.Totals, totals[currency])
+		}
+		totals[currency].Add(statementRide.Totals)
+	}
+	slices.SortFunc(statement.Totals, func(a, b *models.StatementAmounts) int {
+		return cmp.Compare(a.Fares.Currency, b.Fares.Currency)
+	})
+	return statement, nil
+}
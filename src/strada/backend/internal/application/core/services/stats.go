@@ -0,0 +1,248 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	in "github.com/244Walyson/shared-ride/internal/application/ports/in"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type StatsService struct {
+	rideRepository        out.RideRepository
+	preferencesRepository out.UserPreferencesRepository
+	schoolService         in.SchoolService
+	// drivingCostPerKm is what driving alone costs, the baseline for the
+	// money a passenger saves.
+	drivingCostPerKm models.Money
+}
+
+func NewStatsService(rideRepository out.RideRepository, preferencesRepository out.UserPreferencesRepository, drivingCostPerKm models.Money) in.StatsService {
+	return &StatsService{
+		rideRepository:        rideRepository,
+		preferencesRepository: preferencesRepository,
+		drivingCostPerKm:      drivingCostPerKm,
+	}
+}
+
+func (s *StatsService) SetSchoolService(schoolService in.SchoolService) {
+	s.schoolService = schoolService
+}
+
+func (s *StatsService) UserStats(ctx context.Context, period string) (*models.UserStats, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, models.ErrUnauthenticated
+	}
+	stats, err := s.compute(ctx, []string{principal.UserID}, period)
+	if err != nil {
+		return nil, err
+	}
+	return stats[0], nil
+}
+
+func (s *StatsService) Leaderboard(ctx context.Context, period string, limit int) (*models.Leaderboard, error) {
+	preferences, err := s.FindPreferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !preferences.SchoolVerified() {
+		return nil, models.ErrNoSchool
+	}
+	members, err := s.preferencesRepository.FindBySchool(ctx, preferences.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIds []string
+	for _, member := range members {
+		if member.OnLeaderboard() {
+			userIds = append(userIds, member.UserID)
+		}
+	}
+	stats, err := s.compute(ctx, userIds, period)
+	if err != nil {
+		return nil, err
+	}
+	stats = slices.DeleteFunc(stats, func(stats *models.UserStats) bool {
+		return stats.TripsTaken+stats.TripsOffered == 0
+	})
+	slices.SortFunc(stats, func(a, b *models.UserStats) int {
+		if c := cmp.Compare(b.Co2SavedKg, a.Co2SavedKg); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.DistanceSharedMeters, a.DistanceSharedMeters); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+
+	leaderboard := &models.Leaderboard{
+		SchoolID: preferences.SchoolID,
+		Period:   statsPeriod(period),
+		Entries:  []*models.LeaderboardEntry{},
+	}
+	for i, userStats := range stats[:min(len(stats), pageLimit(limit))] {
+		rank := i + 1
+		// Users tied on CO2 share the rank.
+		if i > 0 && userStats.Co2SavedKg == stats[i-1].Co2SavedKg {
+			rank = leaderboard.Entries[i-1].Rank
+		}
+		leaderboard.Entries = append(leaderboard.Entries, &models.LeaderboardEntry{Rank: rank, Stats: userStats})
+	}
+	return leaderboard, nil
+}
+
+func (s *StatsService) FindPreferences(ctx context.Context) (*models.UserPreferences, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, models.ErrUnauthenticated
+	}
+	return s.preferencesRepository.FindByUser(ctx, principal.UserID)
+}
+
+func (s *StatsService) SavePreferences(ctx context.Context, preferences *models.UserPreferences) (*models.UserPreferences, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, models.ErrUnauthenticated
+	}
+	preferences.UserID = principal.UserID
+	if err := s.checkSchool(ctx, preferences.SchoolID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.preferencesRepository.FindByUser(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// Only an admin confirms memberships and records minors; a user picking
+	// another school has to be confirmed again.
+	preferences.Minor = current.Minor
+	preferences.SchoolVerifiedAt = time.Time{}
+	if preferences.SchoolID == current.SchoolID {
+		preferences.SchoolVerifiedAt = current.SchoolVerifiedAt
+	}
+	return s.preferencesRepository.Save(ctx, preferences)
+}
+
+func (s *StatsService) VerifySchoolMember(ctx context.Context, schoolId int32, userId string, minor bool) (*models.UserPreferences, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.checkSchool(ctx, schoolId); err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.preferencesRepository.FindByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	preferences.SchoolID = schoolId
+	preferences.SchoolVerifiedAt = time.Now().UTC()
+	preferences.Minor = minor
+	return s.preferencesRepository.Save(ctx, preferences)
+}
+
+// checkSchool fails with ErrSchoolNotFound unless the school is registered;
+// 0 stands for no school.
+func (s *StatsService) checkSchool(ctx context.Context, schoolId int32) error {
+	if schoolId == 0 {
+		return nil
+	}
+	if s.schoolService == nil {
+		return models.ErrSchoolNotFound
+	}
+	_, err := s.schoolService.FindById(ctx, schoolId)
+	return err
+}
+
+// compute returns the statistics of each user, in the order given, from the
+// rides they completed as driver or passenger during the period.
+func (s *StatsService) compute(ctx context.Context, userIds []string, period string) ([]*models.UserStats, error) {
+	period = statsPeriod(period)
+	now := time.Now().UTC()
+	from, err := models.StatsWindow(period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*models.UserStats, len(userIds))
+	stats := make([]*models.UserStats, len(userIds))
+	for i, userId := range userIds {
+		stats[i] = &models.UserStats{
+			UserID:     userId,
+			Period:     period,
+			From:       from,
+			To:         now,
+			MoneySaved: models.NewMoney(0, s.drivingCostPerKm.Currency),
+		}
+		byUser[userId] = stats[i]
+	}
+	if len(userIds) == 0 {
+		return stats, nil
+	}
+
+	rides, err := s.rideRepository.FindCompleted(ctx, userIds, from, now)
+	if err != nil || len(rides) == 0 {
+		return stats, err
+	}
+	rideIds := make([]int32, len(rides))
+	for i, ride := range rides {
+		rideIds[i] = ride.ID
+	}
+	passengers, err := s.rideRepository.FindPassengers(ctx, rideIds)
+	if err != nil {
+		return nil, err
+	}
+	riders := make(map[int32][]*models.RidePassenger)
+	for _, passenger := range passengers {
+		if passenger.Role == models.RideRolePassenger {
+			riders[passenger.RideID] = append(riders[passenger.RideID], passenger)
+		}
+	}
+
+	currency := s.drivingCostPerKm.Currency
+	for _, ride := range rides {
+		if driver, ok := byUser[ride.DriverID]; ok {
+			driver.TripsOffered++
+			if len(riders[ride.ID]) > 0 {
+				driver.DistanceSharedMeters += ride.RouteMeters()
+				driver.Co2SavedKg += ride.Co2SavedKg
+			}
+			for _, rider := range riders[ride.ID] {
+				if rider.Fare.Currency == currency {
+					driver.MoneySaved.Amount += rider.Fare.Amount
+				}
+			}
+		}
+		for _, rider := range riders[ride.ID] {
+			passenger, ok := byUser[rider.UserID]
+			if !ok {
+				continue
+			}
+			passenger.TripsTaken++
+			passenger.DistanceSharedMeters += rider.DistanceMeters
+			passenger.Co2SavedKg += rider.Co2SavedKg
+			passenger.MoneySaved.Amount += int64(math.Round(float64(s.drivingCostPerKm.Amount) * rider.DistanceMeters / 1000))
+			if rider.Fare.Currency == currency {
+				passenger.MoneySaved.Amount -= rider.Fare.Amount
+			}
+		}
+	}
+	for _, userStats := range stats {
+		userStats.Co2SavedKg = roundKg(userStats.Co2SavedKg)
+	}
+	return stats, nil
+}
+
+// statsPeriod defaults to the whole history.
+func statsPeriod(period string) string {
+	if period == "" {
+		return models.StatsPeriodAll
+	}
+	return period
+}
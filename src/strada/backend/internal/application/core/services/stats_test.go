@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/244Walyson/shared-ride/internal/adapters/out/repository/memory"
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/core/services"
+	"github.com/244Walyson/shared-ride/internal/application/ports/in"
+)
+
+func newStatsService(t *testing.T) (in.StatsService, *models.School) {
+	t.Helper()
+	schoolService := services.NewSchoolService(memory.NewSchoolRepository())
+	school, err := schoolService.Create(asAdmin(t.Context()), &models.School{
+		Name:     "School",
+		Campus:   []models.Location{{}, {Longitude: 0.01}, {Latitude: 0.01, Longitude: 0.01}},
+		TimeZone: "UTC",
+	})
+	if err != nil {
+		t.Fatalf("create school: %v", err)
+	}
+	statsService := services.NewStatsService(memory.NewRideRepository(), memory.NewUserPreferencesRepository(), models.NewMoney(0, models.DefaultCurrency))
+	statsService.SetSchoolService(schoolService)
+	return statsService, school
+}
+
+func asUser(ctx context.Context, userId string) context.Context {
+	return models.ContextWithPrincipal(ctx, &models.Principal{UserID: userId})
+}
+
+func asAdmin(ctx context.Context) context.Context {
+	return models.ContextWithPrincipal(ctx, &models.Principal{UserID: "admin", Roles: []string{models.RoleAdmin}})
+}
+
+func TestSavePreferencesRejectsUnknownSchool(t *testing.T) {
+	statsService, school := newStatsService(t)
+	_, err := statsService.SavePreferences(asUser(t.Context(), "user-1"), &models.UserPreferences{SchoolID: school.ID + 1})
+	if !errors.Is(err, models.ErrSchoolNotFound) {
+		t.Errorf("err = %v, want ErrSchoolNotFound", err)
+	}
+}
+
+func TestSchoolMembership(t *testing.T) {
+	statsService, school := newStatsService(t)
+	ctx := asUser(t.Context(), "user-1")
+
+	// Naming a school is not enough to see or join its leaderboard.
+	if _, err := statsService.SavePreferences(ctx, &models.UserPreferences{SchoolID: school.ID}); err != nil {
+		t.Fatalf("SavePreferences: %v", err)
+	}
+	if _, err := statsService.Leaderboard(ctx, "", 0); !errors.Is(err, models.ErrNoSchool) {
+		t.Errorf("unconfirmed Leaderboard err = %v, want ErrNoSchool", err)
+	}
+
+	if _, err := statsService.VerifySchoolMember(ctx, school.ID, "user-1", false); !errors.Is(err, models.ErrForbidden) {
+		t.Errorf("VerifySchoolMember by the user err = %v, want ErrForbidden", err)
+	}
+	if _, err := statsService.VerifySchoolMember(asAdmin(t.Context()), school.ID, "user-1", true); err != nil {
+		t.Fatalf("VerifySchoolMember: %v", err)
+	}
+	if _, err := statsService.Leaderboard(ctx, "", 0); err != nil {
+		t.Errorf("confirmed Leaderboard: %v", err)
+	}
+
+	// The user cannot clear the minor flag, and keeps the confirmation while
+	// staying at the same school.
+	saved, err := statsService.SavePreferences(ctx, &models.UserPreferences{SchoolID: school.ID, LeaderboardOptIn: true})
+	if err != nil {
+		t.Fatalf("SavePreferences: %v", err)
+	}
+	if !saved.Minor || !saved.SchoolVerified() || !saved.OnLeaderboard() {
+		t.Errorf("preferences = %+v, want a confirmed minor on the leaderboard", saved)
+	}
+
+	saved, err = statsService.SavePreferences(ctx, &models.UserPreferences{})
+	if err != nil {
+		t.Fatalf("SavePreferences: %v", err)
+	}
+	saved, err = statsService.SavePreferences(ctx, &models.UserPreferences{SchoolID: school.ID})
+	if err != nil {
+		t.Fatalf("SavePreferences: %v", err)
+	}
+	if saved.SchoolVerified() {
+		t.Errorf("preferences = %+v, want the confirmation cleared after leaving the school", saved)
+	}
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+// withinTransaction runs fn through the transaction manager, or directly when
+// the service was built without one.
+func withinTransaction(ctx context.Context, transactionManager out.TransactionManager, fn func(ctx context.Context) error) error {
+	if transactionManager == nil {
+		return fn(ctx)
+	}
+	return transactionManager.WithinTransaction(ctx, fn)
+}
@@ -21,3 +21,14 @@ func NewUserService(r out.UserRepository) in.UserService {
 func (s *UserService) FindById(ctx context.Context, id string) (*models.User, error) {
 	return s.repository.FindById(ctx, id)
 }
+
+func (s *UserService) FindByIds(ctx context.Context, ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+	return s.repository.FindByIds(ctx, ids)
+}
+
+func (s *UserService) FindGuardianRelation(ctx context.Context, guardianId string, minorId string) (*models.GuardianRelation, error) {
+	return s.repository.FindGuardianRelation(ctx, guardianId, minorId)
+}
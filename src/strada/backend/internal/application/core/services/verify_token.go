@@ -18,6 +18,6 @@ func NewVerifyTokenService(r out.VerifyTokenRepository) in.VerifyTokenService {
 	}
 }
 
-func (s *VerifyTokenService) VerifyToken(ctx context.Context, verifyToken string) (*models.User, error) {
+func (s *VerifyTokenService) VerifyToken(ctx context.Context, verifyToken string) (*models.Principal, error) {
 	return s.VerifyTokenRepository.VerifyToken(ctx, verifyToken)
 }
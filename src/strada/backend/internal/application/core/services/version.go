@@ -0,0 +1,13 @@
+package services
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+// baseVersion returns the version an update is applied on. A requested
+// version of 0 means the caller chose to overwrite the current one, which
+// the API only allows with "If-Match: *".
+func baseVersion(requested int32, current int32) (int32, error) {
+	if requested != 0 && requested != current {
+		return 0, models.ErrVersionConflict
+	}
+	return current, nil
+}
@@ -0,0 +1,12 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type ChangeHistoryService interface {
+	Record(ctx context.Context, entityType string, entityId int32, action string, changes map[string]models.FieldChange) error
+	FindByEntity(ctx context.Context, entityType string, entityId int32) ([]*models.ChangeRecord, error)
+}
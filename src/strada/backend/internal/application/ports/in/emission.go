@@ -0,0 +1,13 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type EmissionService interface {
+	// Estimate computes the CO2 the ride's vehicle emits and what each
+	// passenger saves versus driving the same distance alone.
+	Estimate(ctx context.Context, ride *models.Ride, passengers []*models.RidePassenger) (*models.RideEmission, error)
+}
@@ -0,0 +1,17 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
+)
+
+type PaymentService interface {
+	Charge(ctx context.Context, rideId int32, passengerId string) (*models.Payment, error)
+	Refund(ctx context.Context, chargeId int32, amount models.Money) (*models.Payment, error)
+	FindByRide(ctx context.Context, rideId int32) ([]*models.Payment, error)
+	HandleCallback(ctx context.Context, provider string, header map[string][]string, body []byte) (*models.Payment, error)
+	SetUserService(userService UserService)
+	SetTransactionManager(transactionManager out.TransactionManager)
+}
@@ -0,0 +1,10 @@
+package in
+
+import models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+
+type PricingService interface {
+	// Split prices every passenger of the ride by the distance they travel,
+	// their DistanceMeters or else the straight line between where they
+	// board and leave, and caps the total at the ride's cost ceiling, if any.
+	Split(ride *models.Ride, passengers []*models.RidePassenger) (*models.FareSplit, error)
+}
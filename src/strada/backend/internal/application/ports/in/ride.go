@@ -4,16 +4,34 @@ import (
 	"context"
 
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+	"github.com/244Walyson/shared-ride/internal/application/ports/out"
 )
 
 type RideService interface {
 	Create(ctx context.Context, ride *models.Ride) (*models.Ride, error)
 	FindById(ctx context.Context, id int32) (*models.Ride, error)
 	FindAll(ctx context.Context) ([]*models.Ride, error)
+	List(ctx context.Context, filter models.RideFilter) (*models.Page[*models.Ride], error)
+	FindByUser(ctx context.Context, userId string) ([]*models.Ride, error)
 	FindNear(ctx context.Context, rideId int32) ([]*models.Ride, error)
 	Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error)
+	Complete(ctx context.Context, id int32) (*models.Ride, error)
 	Delete(ctx context.Context, id int32) error
+	FindPassengers(ctx context.Context, rideIds []int32) ([]*models.RidePassenger, error)
+	Join(ctx context.Context, rideId int32, passenger *models.RidePassenger) (*models.FareSplit, error)
+	Leave(ctx context.Context, rideId int32, userId string) (*models.FareSplit, error)
+	Fares(ctx context.Context, rideId int32) (*models.FareSplit, error)
+	Emissions(ctx context.Context, rideId int32) (*models.RideEmission, error)
+	Itinerary(ctx context.Context, rideId int32) (*models.Itinerary, error)
+	Detours(ctx context.Context, ride *models.Ride, rideRequests []*models.RideRequest) ([]*models.Detour, error)
 
 	SetRideRequestService(rideRequestService RideRequestService)
 	SetUserService(userService UserService)
+	SetRideEventService(rideEventService RideEventService)
+	SetTransactionManager(transactionManager out.TransactionManager)
+	SetChangeHistoryService(changeHistoryService ChangeHistoryService)
+	SetPricingService(pricingService PricingService)
+	SetEmissionService(emissionService EmissionService)
+	SetRoutingProvider(routingProvider out.RoutingProvider)
+	SetSchoolService(schoolService SchoolService)
 }
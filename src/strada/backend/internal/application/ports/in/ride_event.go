@@ -0,0 +1,12 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type RideEventService interface {
+	Publish(ctx context.Context, event *models.RideEvent)
+	Subscribe(ctx context.Context) <-chan *models.RideEvent
+}
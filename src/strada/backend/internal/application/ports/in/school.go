@@ -0,0 +1,14 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type SchoolService interface {
+	Create(ctx context.Context, school *models.School) (*models.School, error)
+	FindById(ctx context.Context, id int32) (*models.School, error)
+	FindAll(ctx context.Context, name string) ([]*models.School, error)
+	Update(ctx context.Context, id int32, school *models.School) (*models.School, error)
+}
@@ -0,0 +1,24 @@
+package in
+
+import (
+	"context"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type SeriesService interface {
+	CreateRideSeries(ctx context.Context, series *models.RideSeries) (*models.RideSeries, error)
+	FindRideSeries(ctx context.Context, id int32) (*models.RideSeries, error)
+	CreateRideRequestSeries(ctx context.Context, series *models.RideRequestSeries) (*models.RideRequestSeries, error)
+	FindRideRequestSeries(ctx context.Context, id int32) (*models.RideRequestSeries, error)
+	Match(ctx context.Context, rideRequestSeriesId int32, rideSeriesId int32) (*models.SeriesMatch, error)
+	// Materialize creates the occurrences of every series up to the horizon
+	// after now.
+	Materialize(ctx context.Context, now time.Time) error
+
+	SetRideService(rideService RideService)
+	SetRideRequestService(rideRequestService RideRequestService)
+	SetUserService(userService UserService)
+	SetSchoolService(schoolService SchoolService)
+}
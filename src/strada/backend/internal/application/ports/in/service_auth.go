@@ -0,0 +1,12 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type ServiceAuthService interface {
+	VerifyToken(ctx context.Context, token string) (*models.Principal, error)
+	VerifyApiKey(ctx context.Context, apiKey string) (*models.Principal, error)
+}
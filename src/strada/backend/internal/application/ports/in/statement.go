@@ -0,0 +1,13 @@
+package in
+
+import (
+	"context"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type StatementService interface {
+	DriverStatement(ctx context.Context, driverId string, from time.Time, to time.Time) (*models.Statement, error)
+	SetUserService(userService UserService)
+}
@@ -0,0 +1,24 @@
+package in
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type StatsService interface {
+	UserStats(ctx context.Context, period string) (*models.UserStats, error)
+	// Leaderboard ranks the confirmed members of the authenticated user's
+	// school by CO2 saved, leaving out adults who opted out and minors who
+	// did not opt in. It fails with ErrNoSchool unless the user is a
+	// confirmed member.
+	Leaderboard(ctx context.Context, period string, limit int) (*models.Leaderboard, error)
+	FindPreferences(ctx context.Context) (*models.UserPreferences, error)
+	// SavePreferences fails with ErrSchoolNotFound for a school that is not
+	// registered.
+	SavePreferences(ctx context.Context, preferences *models.UserPreferences) (*models.UserPreferences, error)
+	// VerifySchoolMember confirms, for admins only, that the user belongs to
+	// the school and records whether they are a minor.
+	VerifySchoolMember(ctx context.Context, schoolId int32, userId string, minor bool) (*models.UserPreferences, error)
+	SetSchoolService(schoolService SchoolService)
+}
@@ -8,4 +8,6 @@ import (
 
 type UserService interface {
 	FindById(ctx context.Context, id string) (*models.User, error)
+	FindByIds(ctx context.Context, ids []string) ([]*models.User, error)
+	FindGuardianRelation(ctx context.Context, guardianId string, minorId string) (*models.GuardianRelation, error)
 }
@@ -0,0 +1,11 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type ApiKeyRepository interface {
+	VerifyApiKey(ctx context.Context, apiKey string) (*models.Principal, error)
+}
@@ -0,0 +1,12 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type ChangeHistoryRepository interface {
+	Create(ctx context.Context, record *models.ChangeRecord) (*models.ChangeRecord, error)
+	FindByEntity(ctx context.Context, entityType string, entityId int32) ([]*models.ChangeRecord, error)
+}
@@ -0,0 +1,34 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type PaymentRepository interface {
+	// Create returns models.ErrPaymentExists when the passenger already has a
+	// charge that did not fail, or the charge already has a payout.
+	Create(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	FindById(ctx context.Context, id int32) (*models.Payment, error)
+	FindByRide(ctx context.Context, rideId int32) ([]*models.Payment, error)
+	FindByRides(ctx context.Context, rideIds []int32) ([]*models.Payment, error)
+	FindByParent(ctx context.Context, parentId int32) ([]*models.Payment, error)
+	FindByProviderRef(ctx context.Context, provider string, providerRef string) (*models.Payment, error)
+	// Update stores the status, provider reference and failure reason.
+	Update(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	// RecordEvent returns false when the event was already recorded.
+	RecordEvent(ctx context.Context, event *models.PaymentEvent, paymentId int32) (bool, error)
+}
+
+// PaymentProvider moves the money. Submitting the same payment twice must
+// not move it twice, so implementations key their requests on the payment
+// id.
+type PaymentProvider interface {
+	Name() string
+	Charge(ctx context.Context, payment *models.Payment) (*models.PaymentUpdate, error)
+	Payout(ctx context.Context, payment *models.Payment) (*models.PaymentUpdate, error)
+	Refund(ctx context.Context, refund *models.Payment, charge *models.Payment) (*models.PaymentUpdate, error)
+	// ParseCallback authenticates a status callback and decodes its event.
+	ParseCallback(header map[string][]string, body []byte) (*models.PaymentEvent, error)
+}
@@ -2,6 +2,7 @@ package out
 
 import (
 	"context"
+	"time"
 
 	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
 )
@@ -10,7 +11,28 @@ type RideRepository interface {
 	Create(ctx context.Context, ride *models.Ride) (*models.Ride, error)
 	FindById(ctx context.Context, id int32) (*models.Ride, error)
 	FindAll(ctx context.Context) ([]*models.Ride, error)
-	FindNear(ctx context.Context, locations []*models.Location) ([]*models.Ride, error)
+	List(ctx context.Context, filter models.RideFilter, after *models.Cursor, limit int) ([]*models.Ride, error)
+	FindByUser(ctx context.Context, userId string) ([]*models.Ride, error)
+	// FindCompleted returns the rides completed in [from, to) where any of
+	// the users was the driver or a passenger. Zero bounds are open.
+	FindCompleted(ctx context.Context, userIds []string, from time.Time, to time.Time) ([]*models.Ride, error)
+	// FindNear matches rides near the route and, unless schoolId is 0, every
+	// ride going to that school. Completed rides are left out since they can
+	// no longer be joined.
+	FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.Ride, error)
+	// Update fails with models.ErrVersionConflict unless ride.Version is the
+	// stored version, and increments it.
 	Update(ctx context.Context, id int32, ride *models.Ride) (*models.Ride, error)
 	Delete(ctx context.Context, id int32) error
+	FindPassengers(ctx context.Context, rideIds []int32) ([]*models.RidePassenger, error)
+	// AddPassenger fails with models.ErrAlreadyJoined when the user is
+	// already on the ride.
+	AddPassenger(ctx context.Context, passenger *models.RidePassenger) error
+	RemovePassenger(ctx context.Context, rideId int32, userId string) error
+	UpdatePassengerFares(ctx context.Context, rideId int32, fares []*models.PassengerFare) error
+	UpdatePassengerEmissions(ctx context.Context, rideId int32, emissions []*models.PassengerEmission) error
+	// ReplaceStops stores the itinerary of the ride, dropping the previous
+	// one.
+	ReplaceStops(ctx context.Context, rideId int32, stops []*models.RideStop) error
+	FindStops(ctx context.Context, rideId int32) ([]*models.RideStop, error)
 }
@@ -10,7 +10,12 @@ type RideRequestRepository interface {
 	Create(ctx context.Context, rideRequest *models.RideRequest) (*models.RideRequest, error)
 	FindById(ctx context.Context, id int32) (*models.RideRequest, error)
 	FindAll(ctx context.Context) ([]*models.RideRequest, error)
-	FindNear(ctx context.Context, locations []*models.Location) ([]*models.RideRequest, error)
+	List(ctx context.Context, filter models.RideRequestFilter, after *models.Cursor, limit int) ([]*models.RideRequest, error)
+	// FindNear matches ride requests near the route and, unless schoolId is
+	// 0, every ride request going to that school.
+	FindNear(ctx context.Context, locations []*models.Location, schoolId int32) ([]*models.RideRequest, error)
+	// Update fails with models.ErrVersionConflict unless rideRequest.Version
+	// is the stored version, and increments it.
 	Update(ctx context.Context, id int32, rideRequest *models.RideRequest) (*models.RideRequest, error)
 	Delete(ctx context.Context, id int32) error
 }
@@ -0,0 +1,15 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type RoutingProvider interface {
+	// Route returns the path through at least two points, in order.
+	Route(ctx context.Context, points []models.Location) (*models.Route, error)
+	// Matrix returns the leg from every point to every other one; the
+	// diagonal is zero.
+	Matrix(ctx context.Context, points []models.Location) ([][]models.RouteLeg, error)
+}
@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type SchoolRepository interface {
+	Create(ctx context.Context, school *models.School) (*models.School, error)
+	FindById(ctx context.Context, id int32) (*models.School, error)
+	// FindAll returns the schools whose name contains name, ignoring case,
+	// or every school for an empty name, sorted by name.
+	FindAll(ctx context.Context, name string) ([]*models.School, error)
+	Update(ctx context.Context, id int32, school *models.School) (*models.School, error)
+}
@@ -0,0 +1,44 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type RideSeriesRepository interface {
+	Create(ctx context.Context, series *models.RideSeries) (*models.RideSeries, error)
+	FindById(ctx context.Context, id int32) (*models.RideSeries, error)
+	// FindDue returns the series with occurrences before until still to
+	// create, oldest MaterializedUntil first.
+	FindDue(ctx context.Context, until time.Time) ([]*models.RideSeries, error)
+	SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error
+	// ClaimOccurrence reserves a departure for the caller to create its ride.
+	// It returns false when the series already has the occurrence, unless it
+	// is a claim without ride older than models.OccurrenceClaimTimeout.
+	ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error)
+	// SetOccurrenceRide records the ride created for a claimed occurrence.
+	SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error
+	// ReleaseOccurrence drops a claim whose ride could not be created.
+	ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error
+	// FindOccurrences returns the occurrences departing at or after from
+	// whose ride was created, earliest first.
+	FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error)
+}
+
+type RideRequestSeriesRepository interface {
+	Create(ctx context.Context, series *models.RideRequestSeries) (*models.RideRequestSeries, error)
+	FindById(ctx context.Context, id int32) (*models.RideRequestSeries, error)
+	FindDue(ctx context.Context, until time.Time) ([]*models.RideRequestSeries, error)
+	SetMaterializedUntil(ctx context.Context, id int32, until time.Time) error
+	SetRideSeries(ctx context.Context, id int32, rideSeriesId int32) error
+	ClaimOccurrence(ctx context.Context, seriesId int32, departsAt time.Time, claimedAt time.Time) (bool, error)
+	// SetOccurrenceRideRequest records the ride request created for a
+	// claimed occurrence.
+	SetOccurrenceRideRequest(ctx context.Context, seriesId int32, departsAt time.Time, rideRequestId int32) error
+	ReleaseOccurrence(ctx context.Context, seriesId int32, departsAt time.Time) error
+	// SetOccurrenceRide records the ride the occurrence joined.
+	SetOccurrenceRide(ctx context.Context, seriesId int32, departsAt time.Time, rideId int32) error
+	FindOccurrences(ctx context.Context, seriesId int32, from time.Time) ([]*models.Occurrence, error)
+}
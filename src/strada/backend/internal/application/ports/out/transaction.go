@@ -0,0 +1,11 @@
+package out
+
+import "context"
+
+// TransactionManager runs fn as a single unit of work. Repository calls made
+// with the ctx passed to fn join the transaction; fn may be invoked more than
+// once when the transaction has to be retried, so it must not have side
+// effects outside the repositories.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,14 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type UserPreferencesRepository interface {
+	// FindByUser returns the defaults for users that never saved any.
+	FindByUser(ctx context.Context, userId string) (*models.UserPreferences, error)
+	Save(ctx context.Context, preferences *models.UserPreferences) (*models.UserPreferences, error)
+	FindBySchool(ctx context.Context, schoolId int32) ([]*models.UserPreferences, error)
+}
@@ -0,0 +1,11 @@
+package out
+
+import (
+	"context"
+
+	models "github.com/244Walyson/shared-ride/internal/application/core/domain"
+)
+
+type VehicleRepository interface {
+	FindById(ctx context.Context, id int32) (*models.Vehicle, error)
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type VerifyTokenRepository interface {
-	VerifyToken(ctx context.Context, token string) (*models.User, error)
+	VerifyToken(ctx context.Context, token string) (*models.Principal, error)
 }